@@ -89,7 +89,10 @@ func (cmd *command) Run() error {
 	// if not verbose, use asyncui for clean output
 	var callback func(deployment.ProcessUpdate)
 	if !cmd.Verbose {
-		ui := asyncui.AsyncUI{StepFactory: &cmd.Factory}
+		ui := asyncui.AsyncUI{StepFactory: &cmd.Factory, Context: cmd.Context()}
+		if !cmd.NonInteractive {
+			ui.Components = compList
+		}
 		callback = ui.Callback()
 		if err != nil {
 			return err
@@ -107,7 +110,9 @@ func (cmd *command) Run() error {
 		return err
 	}
 
-	uninstallErr := installer.StartKymaUninstallation()
+	// the deletion of Hydroform doesn't accept a context, so an interrupted deletion is abandoned rather than cancelled
+	cmd.OnInterrupt("Kyma deletion", "Run the same command again to delete the remaining components.")
+	uninstallErr := cmd.RunInterruptible(installer.StartKymaUninstallation)
 
 	if uninstallErr == nil {
 		cmd.showSuccessMessage()
@@ -343,7 +343,10 @@ func (cmd *command) deployKyma(overrides *overrides.Builder) error {
 	// if not verbose, use asyncui for clean output
 	var callback func(deployment.ProcessUpdate)
 	if !cmd.Verbose {
-		ui := asyncui.AsyncUI{StepFactory: &cmd.Factory}
+		ui := asyncui.AsyncUI{StepFactory: &cmd.Factory, Context: cmd.Context()}
+		if !cmd.NonInteractive {
+			ui.Components = compList
+		}
 		callback = ui.Callback()
 		if err != nil {
 			return err
@@ -355,7 +358,11 @@ func (cmd *command) deployKyma(overrides *overrides.Builder) error {
 		return err
 	}
 
-	return silenceStderr(cmd.Options.Verbose, installer.StartKymaDeployment)
+	// the deployment of Hydroform doesn't accept a context, so an interrupted deployment is abandoned rather than cancelled
+	cmd.OnInterrupt("Kyma deployment", "Run the same command again to resume the deployment. Components which are already deployed are upgraded.")
+	return cmd.RunInterruptible(func() error {
+		return silenceStderr(cmd.Options.Verbose, installer.StartKymaDeployment)
+	})
 }
 
 func (cmd *command) createCompList() (*installConfig.ComponentList, error) {
@@ -0,0 +1,16 @@
+package k3s
+
+import (
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new k3s command
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "k3s",
+		Short: "Manages the lifecycle of local k3s clusters.",
+		Long: `Use this command to inspect, stop, start, and delete k3s clusters which you provisioned with ` + "`kyma alpha provision k3s`" + `.
+The commands use k3d under the hood, so you don't need to call k3d directly.`,
+	}
+	return cmd
+}
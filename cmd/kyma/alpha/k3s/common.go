@@ -0,0 +1,23 @@
+package k3s
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli/internal/k3s"
+)
+
+//DefaultClusterName is the name of the k3s cluster used if no name is provided
+const DefaultClusterName = "kyma"
+
+//NodeCount formats the number of running and total nodes
+func NodeCount(running, total int) string {
+	return fmt.Sprintf("%d/%d", running, total)
+}
+
+//GetCluster verifies that k3d is installed and returns the cluster with the given name
+func GetCluster(verbose bool, name string) (*k3s.Cluster, error) {
+	if err := k3s.Initialize(verbose); err != nil {
+		return nil, err
+	}
+	return k3s.GetCluster(verbose, name)
+}
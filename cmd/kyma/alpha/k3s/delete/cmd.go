@@ -0,0 +1,91 @@
+package delete
+
+import (
+	"fmt"
+	"time"
+
+	k3sCmd "github.com/kyma-project/cli/cmd/kyma/alpha/k3s"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/k3s"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new k3s delete command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Deletes a local k3s cluster.",
+		Long: `Use this command to delete a local k3s cluster including all its nodes and data, and to remove it from your kubeconfig.
+The local image registry created for the cluster is deleted as well, unless you use the ` + "`--keep-registry`" + ` flag.`,
+		RunE:  func(_ *cobra.Command, _ []string) error { return c.Run() },
+	}
+
+	cmd.Flags().StringVar(&o.Name, "name", k3sCmd.DefaultClusterName, "Name of the k3s cluster.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 2*time.Minute, "Maximum time to delete the cluster.")
+	cmd.Flags().BoolVar(&o.KeepRegistry, "keep-registry", false, "Keeps the local image registry of the cluster, so the images are still available if the cluster is provisioned again.")
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run() error {
+	if c.opts.CI {
+		c.Factory.NonInteractive = true
+	}
+	if c.opts.Verbose {
+		c.Factory.UseLogger = true
+	}
+
+	s := c.NewStep(fmt.Sprintf("Deleting k3s cluster '%s'", c.opts.Name))
+	if _, err := k3sCmd.GetCluster(c.opts.Verbose, c.opts.Name); err != nil {
+		s.Failure()
+		return err
+	}
+
+	if !c.avoidUserInteraction() {
+		if !s.PromptYesNo(fmt.Sprintf("Do you really want to delete the k3s cluster '%s'? ", c.opts.Name)) {
+			s.Failure()
+			return fmt.Errorf("Aborting deletion")
+		}
+	}
+
+	if err := k3s.DeleteCluster(c.opts.Verbose, c.opts.Timeout, c.opts.Name); err != nil {
+		s.Failure()
+		return err
+	}
+	s.Successf("K3s cluster '%s' deleted", c.opts.Name)
+
+	if c.opts.KeepRegistry {
+		return nil
+	}
+	return c.deleteRegistry()
+}
+
+func (c *command) deleteRegistry() error {
+	name := k3s.RegistryName(c.opts.Name)
+	exists, err := k3s.RegistryExists(c.opts.Verbose, name)
+	if err != nil || !exists {
+		return err
+	}
+
+	s := c.NewStep(fmt.Sprintf("Deleting registry '%s'", name))
+	if err := k3s.DeleteRegistry(c.opts.Verbose, c.opts.Timeout, name); err != nil {
+		s.Failure()
+		return err
+	}
+	s.Successf("Registry '%s' deleted", name)
+	return nil
+}
+
+func (c *command) avoidUserInteraction() bool {
+	return c.opts.NonInteractive || c.opts.CI
+}
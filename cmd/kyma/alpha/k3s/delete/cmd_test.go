@@ -0,0 +1,32 @@
+package delete
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/stretchr/testify/require"
+)
+
+// TestK3sDeleteFlags ensures that the provided command flags are stored in the options.
+func TestK3sDeleteFlags(t *testing.T) {
+	t.Parallel()
+	o := NewOptions(&cli.Options{})
+	c := NewCmd(o)
+
+	// test default flag values
+	require.Equal(t, "kyma", o.Name, "Default value for the name flag not as expected.")
+	require.Equal(t, 2*time.Minute, o.Timeout, "Default value for the timeout flag not as expected.")
+	require.False(t, o.KeepRegistry, "Default value for the keep-registry flag not as expected.")
+
+	// test passing flags
+	err := c.ParseFlags([]string{
+		"--name", "my-cluster",
+		"--timeout", "30s",
+		"--keep-registry",
+	})
+	require.NoError(t, err, "Parsing flags should not return an error")
+	require.Equal(t, "my-cluster", o.Name, "The parsed value for the name flag not as expected.")
+	require.Equal(t, 30*time.Second, o.Timeout, "The parsed value for the timeout flag not as expected.")
+	require.True(t, o.KeepRegistry, "The parsed value for the keep-registry flag not as expected.")
+}
@@ -0,0 +1,21 @@
+package delete
+
+import (
+	"time"
+
+	"github.com/kyma-project/cli/internal/cli"
+)
+
+//Options defines available options for the k3s delete command
+type Options struct {
+	*cli.Options
+
+	Name         string
+	Timeout      time.Duration
+	KeepRegistry bool
+}
+
+//NewOptions creates options with default values
+func NewOptions(o *cli.Options) *Options {
+	return &Options{Options: o}
+}
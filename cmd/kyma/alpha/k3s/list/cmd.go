@@ -0,0 +1,92 @@
+package list
+
+import (
+	"fmt"
+
+	k3sCmd "github.com/kyma-project/cli/cmd/kyma/alpha/k3s"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/k3s"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new k3s list command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the local k3s clusters.",
+		Long:    `Use this command to list all local k3s clusters with the number of running server and agent nodes.`,
+		RunE:    func(_ *cobra.Command, _ []string) error { return c.Run() },
+		Aliases: []string{"l"},
+	}
+	output.Enable(cmd, &o.OutputFormat)
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run() error {
+	if err := k3s.Initialize(c.opts.Verbose); err != nil {
+		return err
+	}
+
+	clusterList, err := k3s.ListClusters(c.opts.Verbose)
+	if err != nil {
+		return err
+	}
+
+	if len(clusterList.Clusters) == 0 && !c.StructuredOutput() {
+		fmt.Println("No k3s clusters found")
+		return nil
+	}
+
+	result := clusters{}
+	for i := range clusterList.Clusters {
+		cluster := &clusterList.Clusters[i]
+		result = append(result, newClusterSummary(cluster))
+	}
+	return c.PrintResult(result)
+}
+
+//clusterSummary is the state of a k3s cluster printed by the command
+type clusterSummary struct {
+	Name           string `json:"name"`
+	State          string `json:"state"`
+	RunningServers int    `json:"runningServers"`
+	Servers        int    `json:"servers"`
+	RunningAgents  int    `json:"runningAgents"`
+	Agents         int    `json:"agents"`
+}
+
+func newClusterSummary(cluster *k3s.Cluster) clusterSummary {
+	s := clusterSummary{Name: cluster.Name, State: cluster.State()}
+	s.RunningServers, s.Servers = cluster.Servers()
+	s.RunningAgents, s.Agents = cluster.Agents()
+	return s
+}
+
+//clusters is the result of the command
+type clusters []clusterSummary
+
+//TableRows returns the clusters as table rows
+func (l clusters) TableRows(wide bool) ([]string, [][]string) {
+	rows := make([][]string, 0, len(l))
+	for _, s := range l {
+		rows = append(rows, []string{
+			s.Name,
+			s.State,
+			k3sCmd.NodeCount(s.RunningServers, s.Servers),
+			k3sCmd.NodeCount(s.RunningAgents, s.Agents),
+		})
+	}
+	return []string{"NAME", "STATE", "SERVERS", "AGENTS"}, rows
+}
@@ -0,0 +1,60 @@
+package start
+
+import (
+	"fmt"
+	"time"
+
+	k3sCmd "github.com/kyma-project/cli/cmd/kyma/alpha/k3s"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/k3s"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new k3s start command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Starts a stopped local k3s cluster.",
+		Long:  `Use this command to start all nodes of a local k3s cluster which was stopped with ` + "`kyma alpha k3s stop`" + `.`,
+		RunE:  func(_ *cobra.Command, _ []string) error { return c.Run() },
+	}
+
+	cmd.Flags().StringVar(&o.Name, "name", k3sCmd.DefaultClusterName, "Name of the k3s cluster.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 5*time.Minute, "Maximum time to start the cluster.")
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run() error {
+	if c.opts.Verbose {
+		c.Factory.UseLogger = true
+	}
+
+	s := c.NewStep(fmt.Sprintf("Starting k3s cluster '%s'", c.opts.Name))
+	cluster, err := k3sCmd.GetCluster(c.opts.Verbose, c.opts.Name)
+	if err != nil {
+		s.Failure()
+		return err
+	}
+	if cluster.State() == k3s.ClusterRunning {
+		s.Successf("K3s cluster '%s' is already running", c.opts.Name)
+		return nil
+	}
+
+	if err := k3s.ResumeCluster(c.opts.Verbose, c.opts.Timeout, c.opts.Name); err != nil {
+		s.Failure()
+		return err
+	}
+	s.Successf("K3s cluster '%s' started", c.opts.Name)
+	return nil
+}
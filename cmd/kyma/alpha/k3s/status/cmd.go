@@ -0,0 +1,168 @@
+package status
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	k3sCmd "github.com/kyma-project/cli/cmd/kyma/alpha/k3s"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/clusterinfo"
+	"github.com/kyma-project/cli/internal/k3s"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const clusterInfoTimeout = 10 * time.Second
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new k3s status command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows the status of a local k3s cluster.",
+		Long: `Use this command to show the state of the nodes of a local k3s cluster, the ports mapped to the host, and the cluster information stored by Kyma CLI.
+The cluster information is only available if the cluster is running.`,
+		RunE:    func(_ *cobra.Command, _ []string) error { return c.Run() },
+		Aliases: []string{"s"},
+	}
+
+	cmd.Flags().StringVar(&o.Name, "name", k3sCmd.DefaultClusterName, "Name of the k3s cluster.")
+	output.Enable(cmd, &o.OutputFormat, output.JSON, output.YAML, output.None, output.JSONPath, output.Template)
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run() error {
+	cluster, err := k3sCmd.GetCluster(c.opts.Verbose, c.opts.Name)
+	if err != nil {
+		return err
+	}
+
+	result := clusterStatus{Name: cluster.Name, State: cluster.State(), Ports: cluster.Ports()}
+	result.RunningServers, result.Servers = cluster.Servers()
+	result.RunningAgents, result.Agents = cluster.Agents()
+	for _, n := range cluster.Nodes {
+		result.Nodes = append(result.Nodes, nodeStatus{Name: n.Name, Role: n.Role, Status: n.State.Status})
+	}
+	var infoErr error
+	if cluster.State() == k3s.ClusterRunning {
+		result.Info, infoErr = c.clusterInfo()
+	}
+
+	if c.StructuredOutput() {
+		if infoErr != nil {
+			fmt.Fprintf(os.Stderr, "Cluster information not available: %s\n", infoErr)
+		}
+		return c.PrintResult(result)
+	}
+	result.print(infoErr)
+	return nil
+}
+
+//clusterStatus is the result of the command
+type clusterStatus struct {
+	Name           string       `json:"name"`
+	State          string       `json:"state"`
+	RunningServers int          `json:"runningServers"`
+	Servers        int          `json:"servers"`
+	RunningAgents  int          `json:"runningAgents"`
+	Agents         int          `json:"agents"`
+	Ports          []string     `json:"ports,omitempty"`
+	Nodes          []nodeStatus `json:"nodes"`
+	// Info is the cluster information stored by Kyma CLI, which is only available if the cluster is running
+	Info *clusterInfo `json:"info,omitempty"`
+}
+
+type nodeStatus struct {
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+	Status string `json:"status"`
+}
+
+type clusterInfo struct {
+	Provider clusterinfo.ClusterProvider `json:"provider"`
+	Local    bool                        `json:"local"`
+	Registry *registry                   `json:"registry,omitempty"`
+}
+
+type registry struct {
+	Address     string `json:"address"`
+	PushAddress string `json:"pushAddress"`
+}
+
+//print prints the status in the human-readable format
+func (s clusterStatus) print(infoErr error) {
+	fmt.Printf("Cluster:  %s\n", s.Name)
+	fmt.Printf("State:    %s\n", s.State)
+	fmt.Printf("Servers:  %s\n", k3sCmd.NodeCount(s.RunningServers, s.Servers))
+	fmt.Printf("Agents:   %s\n", k3sCmd.NodeCount(s.RunningAgents, s.Agents))
+	if len(s.Ports) > 0 {
+		fmt.Printf("Ports:    %s\n", strings.Join(s.Ports, ", "))
+	}
+
+	fmt.Println()
+	writer := output.NewTableWriter([]string{"NODE", "ROLE", "STATUS"}, os.Stdout)
+	for _, n := range s.Nodes {
+		writer.Append([]string{n.Name, n.Role, n.Status})
+	}
+	writer.Render()
+
+	if s.State != k3s.ClusterRunning {
+		return
+	}
+
+	fmt.Println()
+	if infoErr != nil {
+		fmt.Printf("Cluster information not available: %s\n", infoErr)
+		return
+	}
+	fmt.Printf("Provider: %s\n", s.Info.Provider)
+	fmt.Printf("Local:    %t\n", s.Info.Local)
+	if s.Info.Registry != nil {
+		fmt.Printf("Registry: %s (push to %s)\n", s.Info.Registry.Address, s.Info.Registry.PushAddress)
+	}
+}
+
+//clusterInfo reads the cluster information using the kubeconfig of the k3s cluster, independently of the current kubeconfig context
+func (c *command) clusterInfo() (*clusterInfo, error) {
+	kubeconfig, err := k3s.Kubeconfig(c.opts.Verbose, c.opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read the kubeconfig of the k3s cluster")
+	}
+	config.Timeout = clusterInfoTimeout
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not initialize the Kubernetes client")
+	}
+
+	info := clusterinfo.New(client)
+	if err := info.Read(); err != nil {
+		return nil, err
+	}
+	result := &clusterInfo{}
+	result.Provider, _ = info.Provider()
+	result.Local, _ = info.IsLocal()
+	if r, _ := info.Registry(); r.Address != "" {
+		result.Registry = &registry{Address: r.Address, PushAddress: r.PushAddress}
+	}
+	return result, nil
+}
@@ -0,0 +1,15 @@
+package status
+
+import "github.com/kyma-project/cli/internal/cli"
+
+//Options defines available options for the k3s status command
+type Options struct {
+	*cli.Options
+
+	Name string
+}
+
+//NewOptions creates options with default values
+func NewOptions(o *cli.Options) *Options {
+	return &Options{Options: o}
+}
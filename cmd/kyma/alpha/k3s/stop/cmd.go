@@ -0,0 +1,61 @@
+package stop
+
+import (
+	"fmt"
+	"time"
+
+	k3sCmd "github.com/kyma-project/cli/cmd/kyma/alpha/k3s"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/k3s"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new k3s stop command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stops a local k3s cluster.",
+		Long: `Use this command to stop all nodes of a local k3s cluster without deleting it.
+To continue working with the cluster, run ` + "`kyma alpha k3s start`" + `.`,
+		RunE: func(_ *cobra.Command, _ []string) error { return c.Run() },
+	}
+
+	cmd.Flags().StringVar(&o.Name, "name", k3sCmd.DefaultClusterName, "Name of the k3s cluster.")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 2*time.Minute, "Maximum time to stop the cluster.")
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run() error {
+	if c.opts.Verbose {
+		c.Factory.UseLogger = true
+	}
+
+	s := c.NewStep(fmt.Sprintf("Stopping k3s cluster '%s'", c.opts.Name))
+	cluster, err := k3sCmd.GetCluster(c.opts.Verbose, c.opts.Name)
+	if err != nil {
+		s.Failure()
+		return err
+	}
+	if cluster.State() == k3s.ClusterStopped {
+		s.Successf("K3s cluster '%s' is already stopped", c.opts.Name)
+		return nil
+	}
+
+	if err := k3s.StopCluster(c.opts.Verbose, c.opts.Timeout, c.opts.Name); err != nil {
+		s.Failure()
+		return err
+	}
+	s.Successf("K3s cluster '%s' stopped", c.opts.Name)
+	return nil
+}
@@ -0,0 +1,20 @@
+package stop
+
+import (
+	"time"
+
+	"github.com/kyma-project/cli/internal/cli"
+)
+
+//Options defines available options for the k3s stop command
+type Options struct {
+	*cli.Options
+
+	Name    string
+	Timeout time.Duration
+}
+
+//NewOptions creates options with default values
+func NewOptions(o *cli.Options) *Options {
+	return &Options{Options: o}
+}
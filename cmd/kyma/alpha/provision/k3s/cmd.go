@@ -9,6 +9,7 @@ import (
 
 	"github.com/kyma-project/cli/internal/cli"
 	"github.com/kyma-project/cli/internal/clusterinfo"
+	"github.com/kyma-project/cli/internal/clusterspec"
 	"github.com/kyma-project/cli/internal/k3s"
 	"github.com/kyma-project/cli/internal/kube"
 	"github.com/pkg/errors"
@@ -44,6 +45,11 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().StringSliceVarP(&o.K3dArgs, "k3d-arg", "", []string{}, "One or more arguments passed to the k3d provisioning command (e.g. --k3d-arg='--no-rollback')")
 	cmd.Flags().StringVarP(&o.KubernetesVersion, "kube-version", "k", "1.20.7", "Kubernetes version of the cluster")
 	cmd.Flags().StringSliceVarP(&o.PortMapping, "port", "p", []string{"8000:80@loadbalancer", "8443:443@loadbalancer"}, "Map ports 80 and 443 of K3D loadbalancer (e.g. -p 8000:80@loadbalancer -p 8443:443@loadbalancer)")
+	cmd.Flags().StringVar(&o.RegistryUse, "registry-use", "", "Address (NAME:PORT) of an existing k3d registry to use instead of the registry created for the cluster (e.g. --registry-use='k3d-my-registry:5000')")
+	cmd.Flags().IntVar(&o.RegistryPort, "registry-port", 5001, "Port of the local machine on which the registry created for the cluster is exposed")
+	cmd.Flags().BoolVar(&o.NoSwitchContext, "no-switch-context", false, "Keeps the current context of the kubeconfig instead of switching to the context of the k3s cluster")
+
+	clusterspec.Bind(cmd, clusterspec.ProviderK3s)
 	return cmd
 }
 
@@ -56,18 +62,54 @@ func (c *command) Run() error {
 		c.Factory.UseLogger = true
 	}
 
+	registry, err := c.registry()
+	if err != nil {
+		return err
+	}
+
 	if err := c.verifyK3sStatus(); err != nil {
 		return err
 	}
-	if err := c.createK3sCluster(); err != nil {
+	if err := c.prepareRegistry(registry); err != nil {
 		return err
 	}
-	if err := c.createK3sClusterInfo(); err != nil {
+	if err := c.createK3sCluster(registry); err != nil {
 		return err
 	}
+	if err := c.createK3sClusterInfo(registry); err != nil {
+		return err
+	}
+
+	c.printRegistryInstructions(registry)
+	if c.opts.NoSwitchContext {
+		fmt.Printf("\nKubectl context '%s' added without switching to it. To find and use it, run: kyma kubeconfig list\n", k3s.ContextName(c.opts.Name))
+	}
 	return nil
 }
 
+//registry returns the addresses of the registry used by the cluster
+func (c *command) registry() (clusterinfo.Registry, error) {
+	if c.opts.RegistryUse == "" {
+		return clusterinfo.Registry{
+			Address:     fmt.Sprintf("%s:%d", k3s.RegistryName(c.opts.Name), c.opts.RegistryPort),
+			PushAddress: fmt.Sprintf("localhost:%d", c.opts.RegistryPort),
+		}, nil
+	}
+
+	i := strings.LastIndex(c.opts.RegistryUse, ":")
+	if i < 1 {
+		return clusterinfo.Registry{}, fmt.Errorf("Invalid registry '%s'. Please provide the registry as NAME:PORT", c.opts.RegistryUse)
+	}
+	port, err := strconv.Atoi(c.opts.RegistryUse[i+1:])
+	if err != nil {
+		return clusterinfo.Registry{}, fmt.Errorf("Invalid port of registry '%s'. Please provide the registry as NAME:PORT", c.opts.RegistryUse)
+	}
+	return clusterinfo.Registry{
+		Address:     c.opts.RegistryUse,
+		PushAddress: fmt.Sprintf("localhost:%d", port),
+	}, nil
+}
+
 func extractPortsFromFlag(portFlag []string) ([]int, error) {
 	ports := []int{}
 	for _, rawport := range portFlag {
@@ -147,18 +189,52 @@ func (c *command) allocatePorts(ports ...int) error {
 	return nil
 }
 
+//Create the registry of the cluster unless an existing registry is used.
+//The registry is managed separately from the k3d cluster. "kyma alpha k3s delete" removes it unless "--keep-registry" is set, in which case it is reused here with the images pushed to it.
+func (c *command) prepareRegistry(registry clusterinfo.Registry) error {
+	if c.opts.RegistryUse != "" {
+		return nil
+	}
+
+	s := c.NewStep("Prepare local image registry")
+	name := k3s.RegistryName(c.opts.Name)
+	exists, err := k3s.RegistryExists(c.opts.Verbose, name)
+	if err != nil {
+		s.Failure()
+		return err
+	}
+	if exists {
+		s.Successf("Using existing registry '%s'", registry.Address)
+		return nil
+	}
+
+	if err := c.allocatePorts(c.opts.RegistryPort); err != nil {
+		s.Failure()
+		return errors.Wrap(err, "Registry port cannot be allocated")
+	}
+	if err := k3s.CreateRegistry(c.opts.Verbose, c.opts.Timeout, name, c.opts.RegistryPort); err != nil {
+		s.Failuref("Could not create registry")
+		return err
+	}
+	s.Successf("Registry '%s' created", registry.Address)
+	return nil
+}
+
 //Create a k3s cluster
-func (c *command) createK3sCluster() error {
+func (c *command) createK3sCluster(registry clusterinfo.Registry) error {
 	s := c.NewStep("Create K3s instance")
 	s.Status("Start K3s cluster")
 	k3sSettings := k3s.Settings{
-		ClusterName: c.opts.Name,
-		Args:        c.opts.K3dArgs,
-		Version:     c.opts.KubernetesVersion,
-		PortMapping: c.opts.PortMapping,
+		ClusterName:     c.opts.Name,
+		Args:            c.opts.K3dArgs,
+		Version:         c.opts.KubernetesVersion,
+		PortMapping:     c.opts.PortMapping,
+		RegistryUse:     registry.Address,
+		NoSwitchContext: c.opts.NoSwitchContext,
 	}
-	err := k3s.StartCluster(c.Verbose, c.opts.Timeout, c.opts.Workers, c.opts.ServerArgs, c.opts.AgentArgs, k3sSettings)
-	if err != nil {
+	// k3d is stopped when the command is interrupted
+	c.OnInterrupt(fmt.Sprintf("Creation of k3s cluster '%s'", c.opts.Name), "Run the same command again to delete the partly created cluster and create a new one.")
+	if err := k3s.StartCluster(c.Context(), c.Verbose, c.opts.Timeout, c.opts.Workers, c.opts.ServerArgs, c.opts.AgentArgs, k3sSettings); err != nil {
 		s.Failuref("Could not start k3s cluster")
 		return err
 	}
@@ -167,23 +243,39 @@ func (c *command) createK3sCluster() error {
 	return nil
 }
 
-func (c *command) createK3sClusterInfo() error {
+func (c *command) createK3sClusterInfo(registry clusterinfo.Registry) error {
 	s := c.NewStep("Prepare Kyma installer configuration")
 	s.Status("Adding configuration")
 
-	// K8s client needs to be created here because before the kubeconfig is not ready to use
+	// K8s client needs to be created here because before the kubeconfig is not ready to use.
+	// It uses the context of the cluster, which is not the current one with --no-switch-context.
 	var err error
-	c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath)
+	c.K8s, err = kube.NewFromContext(c.KubeconfigPath, k3s.ContextName(c.opts.Name))
 	if err != nil {
 		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
 	}
 
 	clusterInfo := clusterinfo.New(c.K8s.Static())
 
-	if err := clusterInfo.Write(clusterinfo.ClusterProviderK3s, true); err != nil {
+	if err := clusterInfo.Write(clusterinfo.ClusterProviderK3s, true, clusterinfo.WithRegistry(registry)); err != nil {
+		s.Failure()
+		return err
+	}
+	// k3d writes the context itself, so it is tagged afterwards to be managed with the kyma kubeconfig commands
+	tag := kube.ClusterTag{Provider: clusterinfo.ClusterProviderK3s, Cluster: c.opts.Name}
+	if err := kube.TagContext(c.KubeconfigPath, k3s.ContextName(c.opts.Name), tag); err != nil {
 		s.Failure()
 		return err
 	}
 	s.Successf("Configuration created")
 	return nil
 }
+
+func (c *command) printRegistryInstructions(registry clusterinfo.Registry) {
+	fmt.Printf(`
+Images for the cluster can be pushed to the local registry '%[1]s':
+  docker tag {IMAGE} %[2]s/{IMAGE}
+  docker push %[2]s/{IMAGE}
+In your workloads, reference the image as '%[1]s/{IMAGE}'.
+`, registry.Address, registry.PushAddress)
+}
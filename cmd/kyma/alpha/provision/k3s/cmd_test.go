@@ -12,3 +12,21 @@ func TestExtractPortsFromFlag(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []int{8000, 8443}, res)
 }
+
+func TestRegistry(t *testing.T) {
+	c := command{opts: &Options{Name: "kyma", RegistryPort: 5001}}
+	registry, err := c.registry()
+	require.NoError(t, err)
+	require.Equal(t, "k3d-kyma-registry:5001", registry.Address)
+	require.Equal(t, "localhost:5001", registry.PushAddress)
+
+	c.opts.RegistryUse = "k3d-my-registry:12345"
+	registry, err = c.registry()
+	require.NoError(t, err)
+	require.Equal(t, "k3d-my-registry:12345", registry.Address)
+	require.Equal(t, "localhost:12345", registry.PushAddress)
+
+	c.opts.RegistryUse = "k3d-my-registry"
+	_, err = c.registry()
+	require.Error(t, err)
+}
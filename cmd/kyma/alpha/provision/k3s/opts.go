@@ -18,6 +18,9 @@ type Options struct {
 	K3dArgs           []string
 	KubernetesVersion string
 	PortMapping       []string
+	RegistryUse       string
+	RegistryPort      int
+	NoSwitchContext   bool
 }
 
 //NewOptions creates options with default values
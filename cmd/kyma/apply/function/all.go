@@ -0,0 +1,162 @@
+package function
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/pkg/errors"
+)
+
+// maxParallelApplies is the maximum number of Functions which are applied at the same time
+const maxParallelApplies = 5
+
+// functionResult is the outcome of applying one Function of a project
+type functionResult struct {
+	file   string
+	cfg    workspace.Cfg
+	output bytes.Buffer
+	// resources are the resources printed with the "--output" flag
+	resources bytes.Buffer
+	err       error
+}
+
+// runAll applies all Functions of the project in parallel and prints the output of each Function once it is applied
+func (c *command) runAll() error {
+	switch {
+	case c.opts.Filename != "":
+		return errors.New("The flags --all and --filename cannot be used together")
+	case c.opts.WatchSources:
+		return errors.New("The flags --all and --watch-sources cannot be used together")
+	}
+	if c.opts.Dir == "" {
+		var err error
+		if c.opts.Dir, err = os.Getwd(); err != nil {
+			return err
+		}
+	}
+
+	step := c.NewStep("Loading project...")
+	results, err := loadProjectFunctions(c.opts.Dir, c.opts.Env)
+	if err != nil {
+		step.Failure()
+		return err
+	}
+	step.Successf("Project with %d Functions loaded", len(results))
+
+	if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
+		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
+	}
+
+	step = c.NewStep(fmt.Sprintf("Applying %d Functions...", len(results)))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxParallelApplies)
+	for _, result := range results {
+		wg.Add(1)
+		go func(result *functionResult) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			result.err = c.worker(&result.output, &result.resources).apply(result.cfg)
+		}(result)
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, result := range results {
+		if result.err != nil {
+			failed = append(failed, result.cfg.Name)
+		}
+	}
+	if len(failed) > 0 {
+		step.Failuref("%d of %d Functions could not be applied", len(failed), len(results))
+	} else {
+		step.Successf("%d Functions applied", len(results))
+	}
+
+	logs := c.progress()
+	for _, result := range results {
+		fmt.Fprintf(logs, "\nFunction '%s' (%s):\n%s", result.cfg.Name, result.file, result.output.String())
+		if result.err != nil {
+			fmt.Fprintf(logs, "Error: %s\n", result.err)
+		}
+		fmt.Print(result.resources.String())
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("Could not apply the Functions %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// worker returns a copy of the command which writes its steps and resources to the given writers, so that several Functions can be applied at the same time
+func (c *command) worker(steps, out *bytes.Buffer) *command {
+	cliOpts := *c.opts.Options
+	cliOpts.Factory.Output = steps
+	opts := *c.opts
+	opts.Options = &cliOpts
+	return &command{
+		opts:    &opts,
+		Command: cli.Command{Options: &cliOpts, K8s: c.K8s},
+		out:     out,
+	}
+}
+
+// loadProjectFunctions reads the configurations of all Functions of the project in the folder and applies the project settings to them.
+// If an environment is set, the overlays of the environment are merged into the configurations of the Functions which have one.
+// Functions without an overlay get a warning, and if no Function has one, the environment is most likely misspelled and loading fails.
+func loadProjectFunctions(dir, env string) ([]*functionResult, error) {
+	project, files, err := serverless.LoadProject(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("No Functions found in '%s'. Add a '%s' project manifest or '%s' files of the Functions", dir, serverless.ProjectFilename, workspace.CfgFilename)
+	}
+
+	results := make([]*functionResult, 0, len(files))
+	cfgs := make([]workspace.Cfg, 0, len(files))
+	overlays := 0
+	for _, file := range files {
+		cfg, warnings, err := readConfiguration(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not load '%s'", file)
+		}
+		project.Apply(&cfg)
+		// the overlays of the environment take precedence over the settings of the project
+		if env != "" {
+			overlay := serverless.OverlayFilename(file, env)
+			if _, err := os.Stat(overlay); err == nil {
+				// the warnings of the merged configuration replace the ones of the config file
+				if cfg, warnings, err = serverless.LoadOverlay(cfg, overlay); err != nil {
+					return nil, err
+				}
+				overlays++
+			} else {
+				warnings = append(warnings, serverless.Issue{
+					Message: fmt.Sprintf("no overlay '%s' for environment '%s', the config file is applied unchanged", overlay, env),
+					Warning: true,
+				})
+			}
+		}
+		cfgs = append(cfgs, cfg)
+		result := &functionResult{file: file, cfg: cfg}
+		for _, warning := range warnings {
+			fmt.Fprintf(&result.output, "Warning: %s\n", warning)
+		}
+		results = append(results, result)
+	}
+	if env != "" && overlays == 0 {
+		return nil, fmt.Errorf("No Function of the project has an overlay for environment '%s'. Check the name of the environment, the overlay of 'config.yaml' for it is 'config.%s.yaml'", env, env)
+	}
+	if err := serverless.ValidateNames(cfgs, files); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
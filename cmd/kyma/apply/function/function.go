@@ -2,11 +2,11 @@ package function
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/kyma-incubator/hydroform/function/pkg/client"
 	"github.com/kyma-incubator/hydroform/function/pkg/manager"
@@ -15,16 +15,22 @@ import (
 	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
 	"github.com/kyma-project/cli/internal/cli"
 	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/kyma-project/cli/internal/serverless"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type command struct {
 	opts *Options
 	cli.Command
+
+	// functionChanged is set if the last apply created or updated the Function
+	functionChanged bool
+	// out is the writer of the resources printed with the "--output" flag. It defaults to the standard output.
+	out io.Writer
 }
 
 //NewCmd creates a new apply command
@@ -44,47 +50,102 @@ Use the flags to specify the desired location for the source files or run the co
 	}
 
 	cmd.Flags().StringVarP(&o.Filename, "filename", "f", "", `Full path to the config file.`)
+	cmd.Flags().StringVar(&o.Env, "env", "", `Name of the environment, such as "stage", whose overlay file is merged on top of the config file. The overlay of "config.yaml" for the "stage" environment is "config.stage.yaml". Fields set in the overlay replace the fields of the config file. Labels and resources are merged by key, and env variables, APIRules, and subscriptions are merged by name.`)
+	cmd.Flags().BoolVar(&o.All, "all", false, `Applies all Functions of the project in the folder set with the "--dir" flag. The Functions are listed in the "functions.yaml" project manifest or, if there is no manifest, all "config.yaml" files in the folder and its subfolders are applied.`)
+	cmd.Flags().StringVarP(&o.Dir, "dir", "d", "", `Full path to the project folder used with the "--all" flag. Defaults to the current folder.`)
 	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, `Validated list of objects to be created from sources.`)
 	cmd.Flags().DurationVarP(&o.Timeout, "timeout", "t", 0, `Maximum time during which the local resources are being applied, where "0" means "infinite". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
 	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", false, `Flag used to watch resources applied to the cluster to make sure that everything is applied in the correct order.`)
+	cmd.Flags().BoolVar(&o.WatchSources, "watch-sources", false, `Flag used to watch the Function's sources and config file, and to apply them to the cluster again after every change. After each change, the command waits until the Function is running and prints the logs if the build or the Function fails.`)
 	cmd.Flags().Var(&o.OnError, "onerror", `Flag used to define the Kyma CLI's reaction to an error when applying resources to the cluster. Use one of these options: 
 - nothing
 - purge`)
-	cmd.Flags().VarP(&o.Output, "output", "o", `Flag used to define the command output format. Use one of these options:
-- text
-- json
-- yaml
-- none`)
+	output.Enable(cmd, &o.OutputFormat, output.JSON, output.YAML, output.None, output.JSONPath, output.Template)
 
 	return cmd
 }
 
 func (c *command) Run() error {
+	if c.opts.All {
+		return c.runAll()
+	}
 	if c.opts.Filename == "" {
 		c.opts.Filename = defaultFilename()
 	}
+	if c.opts.WatchSources && c.opts.DryRun {
+		return errors.New("The flags --watch-sources and --dry-run cannot be used together")
+	}
 
-	file, err := os.Open(c.opts.Filename)
+	configuration, err := c.loadConfiguration()
 	if err != nil {
 		return err
 	}
 
+	if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
+		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
+	}
+
+	if !c.opts.WatchSources {
+		return c.apply(configuration)
+	}
+	return c.watchSources(configuration)
+}
+
+func (c *command) loadConfiguration() (workspace.Cfg, error) {
+	if _, err := os.Stat(c.opts.Filename); err != nil {
+		return workspace.Cfg{}, err
+	}
+
 	// Load project configuration
 	step := c.NewStep("Loading configuration...")
-	var configuration workspace.Cfg
-	if err := yaml.NewDecoder(file).Decode(&configuration); err != nil {
+	configuration, warnings, err := readConfiguration(c.opts.Filename)
+	if err != nil {
 		step.Failure()
-		return errors.Wrap(err, "Could not decode the configuration file")
+		return configuration, err
 	}
+	if c.opts.Env != "" {
+		// the warnings of the merged configuration replace the ones of the config file
+		overlay := serverless.OverlayFilename(c.opts.Filename, c.opts.Env)
+		if configuration, warnings, err = serverless.LoadOverlay(configuration, overlay); err != nil {
+			step.Failure()
+			return configuration, errors.Wrapf(err, "Could not load the configuration for environment '%s'", c.opts.Env)
+		}
+	}
+	for _, warning := range warnings {
+		step.LogError(warning.String())
+	}
+	if c.opts.Env != "" {
+		step.Successf("Configuration for environment '%s' loaded", c.opts.Env)
+		return configuration, nil
+	}
+	step.Successf("Configuration loaded")
+	return configuration, nil
+}
 
-	if configuration.Source.SourcePath == "" {
-		configuration.Source.SourcePath = filepath.Dir(c.opts.Filename)
+// readConfiguration reads and validates the config file. It returns the warnings found in the file.
+func readConfiguration(filename string) (workspace.Cfg, []serverless.Issue, error) {
+	var configuration workspace.Cfg
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return configuration, nil, err
 	}
 
-	if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
-		step.Failure()
-		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
+	warnings, err := serverless.ValidateConfigFile(filename, content)
+	if err != nil {
+		return configuration, nil, err
+	}
+	if err := yaml.Unmarshal(content, &configuration); err != nil {
+		return configuration, nil, errors.Wrap(err, "Could not decode the configuration file")
 	}
+
+	if configuration.Source.SourcePath == "" {
+		configuration.Source.SourcePath = filepath.Dir(filename)
+	}
+	return configuration, warnings, nil
+}
+
+func (c *command) apply(configuration workspace.Cfg) error {
+	step := c.NewStep("Preparing resources...")
 	client := c.K8s.Dynamic()
 
 	mgr := manager.NewManager()
@@ -101,7 +162,7 @@ func (c *command) Run() error {
 		return err
 	}
 
-	kymaAddress, err := c.kymaHostAddress()
+	kymaAddress, err := serverless.KymaHostAddress(c.Context(), c.K8s.Istio())
 	if err != nil {
 		step.LogErrorf("%s\n%s", err, "Check if your cluster is available and has Kyma installed.")
 	}
@@ -118,6 +179,13 @@ func (c *command) Run() error {
 			step.Failure()
 			return errors.Wrap(err, "Unable to read the Git repository from the provided configuration")
 		}
+		// the label allows "kyma delete function" to delete the GitRepository together with the Function
+		labels := gitRepository.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[serverless.CreatedByLabel] = serverless.CreatedByCLI
+		gitRepository.SetLabels(labels)
 		mgr.AddParent(operator.NewGenericOperator(client.Resource(operator.GVRGitRepository).Namespace(configuration.Namespace), gitRepository), nil)
 	}
 
@@ -139,32 +207,18 @@ func (c *command) Run() error {
 		SetOwnerReferences: true,
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(c.Context())
 	if c.opts.Timeout > 0 {
 		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
 	}
 	defer cancel()
 
-	step.Successf("Configuration loaded")
+	step.Successf("Resources prepared")
 
+	c.OnInterrupt(fmt.Sprintf("Applying Function '%s'", configuration.Name), "Run the same command again to apply the remaining resources.")
 	return mgr.Do(ctx, options)
 }
 
-func (c *command) kymaHostAddress() (string, error) {
-	var url string
-	vs, err := c.K8s.Istio().NetworkingV1alpha3().Gateways("kyma-system").Get(context.Background(), "kyma-gateway", v1.GetOptions{})
-	switch {
-	case err != nil:
-		err = errors.Wrapf(err, "Unable to read the Kyma host URL due to error")
-	case vs != nil && len(vs.Spec.GetServers()) > 0 && len(vs.Spec.Servers[0].Hosts) > 0:
-		url = strings.TrimPrefix(vs.Spec.Servers[0].Hosts[0], "*.")
-	default:
-		err = errors.New("kyma host URL could not be obtained")
-	}
-
-	return url, err
-}
-
 const (
 	operatingFormat     = "%s - %s operating... %s"
 	createdFormat       = "%s - %s created %s"
@@ -175,8 +229,7 @@ const (
 	deleteFailedFormat  = "%s - %s can't be removed %s"
 	unknownStatusFormat = "%s - %s can't resolve status %s"
 	dryRunSuffix        = "(dry run)"
-	yamlFormat          = "---\n%s\n"
-	jsonFormat          = "%s\n"
+	yamlSeparator       = "---\n"
 )
 
 func chooseOnError(onErr value) manager.OnError {
@@ -217,6 +270,13 @@ type logger struct {
 	*command
 }
 
+func (l *logger) output() io.Writer {
+	if l.out != nil {
+		return l.out
+	}
+	return os.Stdout
+}
+
 func callbacks(c *command) operator.Callbacks {
 	logger := logger{c}
 	return operator.Callbacks{
@@ -238,39 +298,19 @@ func (l *logger) pre(v interface{}, err error) error {
 		return errors.New("can't parse interface{} to the Unstructured")
 	}
 
-	switch l.opts.Output.String() {
-	case TextOutput:
-		info := fmt.Sprintf(operatingFormat, entry.GetKind(), entry.GetName(), l.formatSuffix())
-		l.NewStep(info)
+	info := fmt.Sprintf(operatingFormat, entry.GetKind(), entry.GetName(), l.formatSuffix())
+	l.NewStep(info)
+	if !l.StructuredOutput() {
 		return nil
-	case JSONOutput:
-		if err != nil {
-			return err
-		}
-		unstructured.RemoveNestedField(entry.Object, "metadata", "ownerReferences")
-		unstructured.RemoveNestedField(entry.Object, "metadata", "labels", "ownerID")
-		bytes, marshalError := json.MarshalIndent(entry.Object, "", "  ")
-		if marshalError != nil {
-			return marshalError
-		}
-		fmt.Printf(jsonFormat, string(bytes))
-		return nil
-	case YAMLOutput:
-		if err != nil {
-			return err
-		}
-		unstructured.RemoveNestedField(entry.Object, "metadata", "ownerReferences")
-		unstructured.RemoveNestedField(entry.Object, "metadata", "labels", "ownerID")
-		bytes, marshalError := yaml.Marshal(entry.Object)
-		if marshalError != nil {
-			return marshalError
-		}
-		fmt.Printf(yamlFormat, string(bytes))
-		return nil
-	case NoneOutput:
-		return err
 	}
-	return err
+
+	unstructured.RemoveNestedField(entry.Object, "metadata", "ownerReferences")
+	unstructured.RemoveNestedField(entry.Object, "metadata", "labels", "ownerID")
+	// the resources are printed one after another, so that YAML documents need separators
+	if l.OutputFormat == output.YAML {
+		fmt.Fprint(l.output(), yamlSeparator)
+	}
+	return output.Print(l.output(), l.OutputFormat, entry.Object)
 }
 
 func (l *logger) post(v interface{}, err error) error {
@@ -279,9 +319,10 @@ func (l *logger) post(v interface{}, err error) error {
 		return errors.New("can't parse interface{} to StatusEntry interface")
 	}
 
-	if l.opts.Output.String() != TextOutput {
-		return err
+	if entry.GetKind() == functionKind && (entry.StatusType == client.StatusTypeCreated || entry.StatusType == client.StatusTypeUpdated) {
+		l.functionChanged = true
 	}
+
 	format := l.chooseFormat(entry.StatusType)
 	info := fmt.Sprintf(format, entry.GetKind(), entry.GetName(), l.formatSuffix())
 	step := l.CurrentStep
@@ -1,10 +1,16 @@
 package function
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
 	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/output"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,9 +24,13 @@ func TestFunctionFlags(t *testing.T) {
 	require.Equal(t, false, o.DryRun, "Default value for the --dry-run flag not as expected.")
 	require.Equal(t, "", o.Filename, "Default value for the --filename flag not as expected.")
 	require.Equal(t, "nothing", o.OnError.String(), "The parsed value for the --onerror flag not as expected.")
-	require.Equal(t, "text", o.Output.String(), "The parsed value for the --output flag not as expected.")
+	require.Equal(t, output.Default, o.OutputFormat, "Default value for the --output flag not as expected.")
 	require.Equal(t, time.Duration(0), o.Timeout, "Default value for the --timeout flag not as expected.")
 	require.Equal(t, false, o.Watch, "Default value for the --watch flag not as expected.")
+	require.Equal(t, false, o.WatchSources, "Default value for the --watch-sources flag not as expected.")
+	require.Equal(t, "", o.Env, "Default value for the --env flag not as expected.")
+	require.Equal(t, false, o.All, "Default value for the --all flag not as expected.")
+	require.Equal(t, "", o.Dir, "Default value for the --dir flag not as expected.")
 
 	// test passing flags
 	err := c.ParseFlags([]string{
@@ -30,25 +40,127 @@ func TestFunctionFlags(t *testing.T) {
 		"--output", "json",
 		"--timeout", "15s",
 		"--watch",
+		"--watch-sources",
+		"--env", "stage",
+		"--all",
+		"--dir", "/fakepath",
 	})
 	require.NoError(t, err, "Parsing flags should not return an error")
 	require.Equal(t, "/fakepath/config.yaml", o.Filename, "The parsed value for the --filename flag not as expected.")
 	require.Equal(t, true, o.DryRun, "The parsed value for the --dry-run flag not as expected.")
 	require.Equal(t, "purge", o.OnError.String(), "The parsed value for the --onerror flag not as expected.")
-	require.Equal(t, "json", o.Output.String(), "The parsed value for the --output flag not as expected.")
+	require.Equal(t, output.JSON, o.OutputFormat, "The parsed value for the --output flag not as expected.")
 	require.Equal(t, time.Duration(15)*time.Second, o.Timeout, "The parsed value for the --timeout flag not as expected.")
 	require.Equal(t, true, o.Watch, "The parsed value for the --watch flag not as expected.")
+	require.Equal(t, true, o.WatchSources, "The parsed value for the --watch-sources flag not as expected.")
+	require.Equal(t, "stage", o.Env, "The parsed value for the --env flag not as expected.")
+	require.Equal(t, true, o.All, "The parsed value for the --all flag not as expected.")
+	require.Equal(t, "/fakepath", o.Dir, "The parsed value for the --dir flag not as expected.")
 
 	err = c.ParseFlags([]string{
 		"-f", "/config.yaml",
 		"-o", "yaml",
 		"-t", "5s",
 		"-w",
+		"-d", "/project",
 	})
 	require.NoError(t, err, "Parsing flags should not return an error")
 	require.Equal(t, "/config.yaml", o.Filename, "The parsed value for the -f flag not as expected.")
-	require.Equal(t, "yaml", o.Output.String(), "The parsed value for the -o flag not as expected.")
+	require.Equal(t, output.YAML, o.OutputFormat, "The parsed value for the -o flag not as expected.")
 	require.Equal(t, time.Duration(5)*time.Second, o.Timeout, "The parsed value for the --timeout flag not as expected.")
 	require.Equal(t, true, o.Watch, "The parsed value for the --watch flag not as expected.")
+	require.Equal(t, "/project", o.Dir, "The parsed value for the -d flag not as expected.")
 
 }
+
+func TestIsRelevant(t *testing.T) {
+	t.Parallel()
+	c := command{opts: &Options{Filename: "/project/config.yaml"}}
+	configuration := workspace.Cfg{Source: workspace.Source{SourceInline: workspace.SourceInline{SourcePath: "/project/src"}}}
+
+	tests := []struct {
+		name  string
+		event fsnotify.Event
+		want  bool
+	}{
+		{name: "config file", event: fsnotify.Event{Name: "/project/config.yaml", Op: fsnotify.Write}, want: true},
+		{name: "source file", event: fsnotify.Event{Name: "/project/src/handler.js", Op: fsnotify.Write}, want: true},
+		{name: "removed source file", event: fsnotify.Event{Name: "/project/src/lib.js", Op: fsnotify.Remove}, want: true},
+		{name: "permission change", event: fsnotify.Event{Name: "/project/src/handler.js", Op: fsnotify.Chmod}, want: false},
+		{name: "editor swap file", event: fsnotify.Event{Name: "/project/src/.handler.js.swp", Op: fsnotify.Create}, want: false},
+		{name: "other file next to config", event: fsnotify.Event{Name: "/project/README.md", Op: fsnotify.Write}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, c.isRelevant(tt.event, configuration))
+		})
+	}
+}
+
+func TestLoadProjectFunctions(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "project")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile := func(path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	}
+	writeFile(filepath.Join(dir, "orders", workspace.CfgFilename), "name: orders\nnamespace: dev\nruntime: nodejs12\nlabels:\n  app: orders\n")
+	writeFile(filepath.Join(dir, "payments", workspace.CfgFilename), "name: payments\nruntime: python38\n")
+
+	// without a project manifest the config files are discovered
+	results, err := loadProjectFunctions(dir, "")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "orders", results[0].cfg.Name)
+	require.Equal(t, "dev", results[0].cfg.Namespace)
+	require.Equal(t, filepath.Join(dir, "orders"), results[0].cfg.Source.SourcePath)
+	require.Equal(t, "payments", results[1].cfg.Name)
+
+	// the project manifest sets the Namespace and the labels of all Functions
+	writeFile(filepath.Join(dir, "functions.yaml"), "namespace: prod\nlabels:\n  app: shop\n  team: checkout\nfunctions:\n- orders\n- payments/config.yaml\n")
+	results, err = loadProjectFunctions(dir, "")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "prod", results[0].cfg.Namespace)
+	require.Equal(t, map[string]string{"app": "orders", "team": "checkout"}, results[0].cfg.Labels)
+	require.Equal(t, map[string]string{"app": "shop", "team": "checkout"}, results[1].cfg.Labels)
+
+	// the overlays of the environment take precedence over the project manifest
+	writeFile(filepath.Join(dir, "orders", "config.stage.yaml"), "namespace: stage\nlabels:\n  team: orders\n")
+	results, err = loadProjectFunctions(dir, "stage")
+	require.NoError(t, err)
+	require.Equal(t, "stage", results[0].cfg.Namespace)
+	require.Equal(t, map[string]string{"app": "orders", "team": "orders"}, results[0].cfg.Labels)
+	require.Equal(t, "prod", results[1].cfg.Namespace, "Functions without an overlay are not changed")
+	require.Empty(t, results[0].output.String())
+	require.Contains(t, results[1].output.String(), "no overlay", "Functions without an overlay get a warning")
+
+	// the configuration merged with the overlay is validated
+	writeFile(filepath.Join(dir, "orders", "config.stage.yaml"), "runtime: nodejs10\n")
+	_, err = loadProjectFunctions(dir, "stage")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "config.stage.yaml")
+	writeFile(filepath.Join(dir, "orders", "config.stage.yaml"), "namespace: stage\nlabels:\n  team: orders\n")
+
+	// an environment without any overlay is most likely a typo
+	_, err = loadProjectFunctions(dir, "stgae")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stgae")
+
+	// Functions with the same name in the same Namespace are rejected
+	writeFile(filepath.Join(dir, "payments", workspace.CfgFilename), "name: orders\nruntime: python38\n")
+	_, err = loadProjectFunctions(dir, "")
+	require.Error(t, err)
+}
+
+func TestRunAllFlags(t *testing.T) {
+	t.Parallel()
+	c := command{opts: &Options{All: true, Filename: "config.yaml"}}
+	require.Error(t, c.Run(), "--all cannot be used with --filename")
+
+	c = command{opts: &Options{All: true, WatchSources: true}}
+	require.Error(t, c.Run(), "--all cannot be used with --watch-sources")
+}
@@ -13,12 +13,15 @@ import (
 type Options struct {
 	*cli.Options
 
-	OnError  value
-	Output   value
-	Filename string
-	DryRun   bool
-	Watch    bool
-	Timeout  time.Duration
+	OnError      value
+	Filename     string
+	Env          string
+	DryRun       bool
+	Watch        bool
+	WatchSources bool
+	All          bool
+	Dir          string
+	Timeout      time.Duration
 }
 
 //NewOptions creates options with default values
@@ -26,7 +29,6 @@ func NewOptions(o *cli.Options) *Options {
 	return &Options{
 		Options: o,
 		OnError: newValue(NothingOnError, validOnError),
-		Output:  newValue(TextOutput, validOutput),
 	}
 }
 
@@ -35,12 +37,6 @@ var (
 		NothingOnError,
 		PurgeOnError,
 	}
-	validOutput = []string{
-		NoneOutput,
-		JSONOutput,
-		YAMLOutput,
-		TextOutput,
-	}
 )
 
 const (
@@ -48,13 +44,6 @@ const (
 	PurgeOnError   = "purge"
 )
 
-const (
-	NoneOutput = "none"
-	JSONOutput = "json"
-	YAMLOutput = "yaml"
-	TextOutput = "text"
-)
-
 type value struct {
 	value     string
 	available []string
@@ -0,0 +1,152 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/pkg/errors"
+)
+
+const (
+	functionKind = "Function"
+	// watchDebounce is the time without further changes after which the sources are applied
+	watchDebounce = 500 * time.Millisecond
+	// defaultRunningTimeout is used to wait for the Function to be running if no timeout is set
+	defaultRunningTimeout = 5 * time.Minute
+)
+
+// watchSources applies the configuration and applies it again after every change of the sources or the config file
+func (c *command) watchSources(configuration workspace.Cfg) error {
+	if configuration.Source.Type == workspace.SourceTypeGit {
+		return errors.New("The flag --watch-sources can only be used for Functions with inline sources")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "Could not watch the sources")
+	}
+	defer watcher.Close()
+
+	dirs := map[string]bool{
+		filepath.Dir(c.opts.Filename):   true,
+		configuration.Source.SourcePath: true,
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return errors.Wrapf(err, "Could not watch the directory '%s'", dir)
+		}
+	}
+
+	c.applyAndWait(configuration)
+	fmt.Fprintln(c.progress(), "\nWatching for changes. Press Ctrl+C to stop.")
+
+	var debounce <-chan time.Time
+	for {
+		c.OnInterrupt(fmt.Sprintf("Watching the sources of Function '%s'", configuration.Name), "")
+		select {
+		case <-c.Context().Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if c.isRelevant(event, configuration) {
+				debounce = time.After(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return errors.Wrap(err, "Could not watch the sources")
+		case <-debounce:
+			debounce = nil
+			fmt.Fprintln(c.progress())
+			newConfiguration, err := c.loadConfiguration()
+			if err != nil {
+				// keep watching, the user will most likely fix the configuration
+				fmt.Fprintf(c.progress(), "%s\n", err)
+				continue
+			}
+			if newConfiguration.Source.SourcePath != configuration.Source.SourcePath {
+				fmt.Fprintln(c.progress(), "The source path of the Function changed. Please restart the command to watch the new sources.")
+			}
+			configuration = newConfiguration
+			c.applyAndWait(configuration)
+		}
+	}
+}
+
+// applyAndWait applies the configuration and waits for the Function to be running.
+// Errors are printed instead of returned, so the sources are still watched.
+func (c *command) applyAndWait(configuration workspace.Cfg) {
+	c.functionChanged = false
+	started := time.Now()
+	if err := c.apply(configuration); err != nil {
+		fmt.Fprintf(c.progress(), "%s\n", err)
+		return
+	}
+
+	var since time.Time
+	if c.functionChanged {
+		since = started
+	}
+
+	timeout := c.opts.Timeout
+	if timeout == 0 {
+		timeout = defaultRunningTimeout
+	}
+	ctx, cancel := context.WithTimeout(c.Context(), timeout)
+	defer cancel()
+
+	step := c.NewStep(fmt.Sprintf("Waiting for Function '%s' to be running", configuration.Name))
+	_, err := serverless.WaitForRunning(ctx, c.K8s.Dynamic(), c.K8s.Static(), configuration.Namespace, configuration.Name, since)
+	if err == nil {
+		step.Successf("Function '%s' is running", configuration.Name)
+		return
+	}
+	step.Failuref("%s", err)
+
+	var failed *serverless.FailedError
+	if errors.As(err, &failed) && failed.Pod != nil {
+		fmt.Fprintf(c.progress(), "\nLogs of Pod '%s':\n", failed.Pod.Name)
+		if err := serverless.WriteLogs(c.Context(), c.K8s.Static(), *failed.Pod, false, c.progress()); err != nil {
+			fmt.Fprintf(c.progress(), "%s\n", err)
+		}
+	}
+}
+
+// progress returns the writer of the messages of the command, which is the standard error with the "--output" flag, so that the standard output only contains the resources
+func (c *command) progress() io.Writer {
+	if c.StructuredOutput() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// isRelevant returns true if the event changes the config file or a source file of the Function
+func (c *command) isRelevant(event fsnotify.Event, configuration workspace.Cfg) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+	if filepath.Clean(event.Name) == filepath.Clean(c.opts.Filename) {
+		return true
+	}
+	if c.opts.Env != "" && filepath.Clean(event.Name) == filepath.Clean(serverless.OverlayFilename(c.opts.Filename, c.opts.Env)) {
+		return true
+	}
+	if filepath.Dir(event.Name) != filepath.Clean(configuration.Source.SourcePath) {
+		return false
+	}
+
+	// ignore hidden and temporary files of editors
+	base := filepath.Base(event.Name)
+	return !strings.HasPrefix(base, ".") && !strings.HasSuffix(base, "~") && !strings.HasSuffix(base, ".swp")
+}
@@ -0,0 +1,19 @@
+package build
+
+import (
+	"github.com/kyma-project/cli/cmd/kyma/build/function"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new build command
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "Builds container images from local sources.",
+		Long:  "Use this command to build a container image from local sources with your local Docker. Currently, you can only use it for Functions.",
+	}
+
+	cmd.AddCommand(function.NewCmd(function.NewOptions(o)))
+	return cmd
+}
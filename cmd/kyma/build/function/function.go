@@ -0,0 +1,158 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/clusterinfo"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/kyma-project/cli/pkg/docker"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new build function command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		opts:    o,
+		Command: cli.Command{Options: o.Options},
+	}
+	cmd := &cobra.Command{
+		Use:   "function",
+		Short: "Builds the image of a Function from local sources.",
+		Long: `Use this command to build a container image of a Function from its config file and local sources with your local Docker.
+The image is built like on the cluster: the dependencies are installed on top of the runtime image of the Function, and the sources are added afterwards. Run the image with "kyma run function --image".
+Use the "--push" flag to push the image to a registry. If the image name doesn't contain a registry, the image is pushed to the registry of the Kyma cluster, if it has one.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", "", `Full path to the config file of the Function.`)
+	cmd.Flags().StringVarP(&o.Dir, "source-dir", "d", "", `Full path to the folder with the sources of the Function. By default, it is the source path of the config file for inline Functions, and the folder of the config file for Git Functions.`)
+	cmd.Flags().StringVar(&o.Tag, "tag", "", `Name and tag of the image. Defaults to "{FUNCTION_NAME}:latest".`)
+	cmd.Flags().BoolVar(&o.Push, "push", false, `Pushes the image after building it. If the image name doesn't contain a registry, it is pushed to the registry of the Kyma cluster.`)
+	cmd.Flags().DurationVarP(&o.Timeout, "timeout", "t", 0, `Maximum time during which the image is being built and pushed, where "0" means "infinite". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run() error {
+	if err := c.opts.defaultFilename(); err != nil {
+		return err
+	}
+	cfg, err := c.loadConfiguration()
+	if err != nil {
+		return err
+	}
+	c.opts.setDefaults(cfg)
+
+	// the image which is used by the cluster differs from the pushed image if the registry of the cluster is used
+	image, clusterImage := c.opts.Tag, c.opts.Tag
+	if c.opts.Push && !docker.HasRegistry(image) {
+		registry, err := c.clusterRegistry()
+		if err != nil {
+			return err
+		}
+		image, clusterImage = registry.PushAddress+"/"+c.opts.Tag, registry.Address+"/"+c.opts.Tag
+	}
+
+	ctx, cancel := context.WithCancel(c.Context())
+	if c.opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+	}
+	defer cancel()
+
+	client, err := docker.NewClient()
+	if err != nil {
+		return errors.Wrap(err, "Could not initialize the Docker client")
+	}
+
+	step := c.NewStep(fmt.Sprintf("Building image '%s' of Function '%s'", image, cfg.Name))
+	buildContext, err := serverless.BuildContext(cfg, c.opts.Dir)
+	if err != nil {
+		step.Failure()
+		return err
+	}
+	var out io.Writer
+	if c.opts.Verbose {
+		out = os.Stdout
+	}
+	if err := docker.BuildImage(ctx, client, buildContext, image, out); err != nil {
+		step.Failure()
+		return errors.Wrapf(err, "Could not build image '%s'", image)
+	}
+	step.Successf("Image '%s' built", image)
+
+	if !c.opts.Push {
+		step.LogInfof("Run the image with: kyma run function --image %s", image)
+		return nil
+	}
+
+	step = c.NewStep(fmt.Sprintf("Pushing image '%s'", image))
+	if err := docker.PushImage(ctx, client, image); err != nil {
+		step.Failure()
+		return errors.Wrapf(err, "Could not push image '%s'", image)
+	}
+	step.Successf("Image '%s' pushed", image)
+	if clusterImage != image {
+		step.LogInfof("The cluster pulls the image as '%s'", clusterImage)
+	}
+	return nil
+}
+
+func (c *command) loadConfiguration() (workspace.Cfg, error) {
+	content, err := ioutil.ReadFile(c.opts.Filename)
+	if err != nil {
+		return workspace.Cfg{}, errors.Wrap(err, "Could not read the configuration file")
+	}
+	warnings, err := serverless.ValidateConfigFile(c.opts.Filename, content)
+	if err != nil {
+		return workspace.Cfg{}, err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	var cfg workspace.Cfg
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return workspace.Cfg{}, errors.Wrap(err, "Could not decode the configuration file")
+	}
+	return cfg, nil
+}
+
+//clusterRegistry returns the container registry of the Kyma cluster
+func (c *command) clusterRegistry() (clusterinfo.Registry, error) {
+	var err error
+	if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
+		return clusterinfo.Registry{}, errors.Wrap(err, "Could not initialize the Kubernetes client to look up the registry of the cluster. Make sure your kubeconfig is valid or provide the registry in the --tag flag")
+	}
+	info := clusterinfo.New(c.K8s.Static())
+	if err := info.Read(); err != nil {
+		return clusterinfo.Registry{}, errors.Wrap(err, "Could not look up the registry of the cluster. Provide the registry in the --tag flag")
+	}
+	registry, err := info.Registry()
+	if err != nil {
+		return clusterinfo.Registry{}, err
+	}
+	if registry.Address == "" {
+		return clusterinfo.Registry{}, errors.New("The cluster has no registry. Provide the registry in the --tag flag")
+	}
+	if registry.PushAddress == "" {
+		registry.PushAddress = registry.Address
+	}
+	return registry, nil
+}
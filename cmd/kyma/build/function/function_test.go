@@ -0,0 +1,55 @@
+package function
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFunctionFlags ensures that the provided command flags are stored in the options.
+func TestFunctionFlags(t *testing.T) {
+	t.Parallel()
+	o := NewOptions(&cli.Options{})
+	c := NewCmd(o)
+
+	// test default flag values
+	require.Empty(t, o.Filename, "Default value for the --filename flag not as expected.")
+	require.Empty(t, o.Dir, "Default value for the --source-dir flag not as expected.")
+	require.Empty(t, o.Tag, "Default value for the --tag flag not as expected.")
+	require.False(t, o.Push, "Default value for the --push flag not as expected.")
+	require.Equal(t, time.Duration(0), o.Timeout, "Default value for the --timeout flag not as expected.")
+
+	// test passing flags
+	err := c.ParseFlags([]string{
+		"-f", "/fakepath/config.yaml",
+		"-d", "/fakepath/src",
+		"--tag", "localhost:5000/orders:1.0",
+		"--push",
+		"-t", "5m",
+	})
+	require.NoError(t, err, "Parsing flags should not return an error")
+	require.Equal(t, "/fakepath/config.yaml", o.Filename, "The parsed value for the --filename flag not as expected.")
+	require.Equal(t, "/fakepath/src", o.Dir, "The parsed value for the --source-dir flag not as expected.")
+	require.Equal(t, "localhost:5000/orders:1.0", o.Tag, "The parsed value for the --tag flag not as expected.")
+	require.True(t, o.Push, "The parsed value for the --push flag not as expected.")
+	require.Equal(t, 5*time.Minute, o.Timeout, "The parsed value for the --timeout flag not as expected.")
+}
+
+func TestSetDefaults(t *testing.T) {
+	t.Parallel()
+	filename := filepath.Join("project", "config.yaml")
+
+	o := Options{Filename: filename}
+	o.setDefaults(workspace.Cfg{Name: "orders", Source: workspace.Source{Type: workspace.SourceTypeInline, SourceInline: workspace.SourceInline{SourcePath: "src"}}})
+	require.Equal(t, filepath.Join("project", "src"), o.Dir)
+	require.Equal(t, "orders:latest", o.Tag)
+
+	o = Options{Filename: filename, Tag: "orders:1.0"}
+	o.setDefaults(workspace.Cfg{Name: "orders", Source: workspace.Source{Type: workspace.SourceTypeGit}})
+	require.Equal(t, "project", o.Dir)
+	require.Equal(t, "orders:1.0", o.Tag)
+}
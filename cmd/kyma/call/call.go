@@ -0,0 +1,19 @@
+package call
+
+import (
+	"github.com/kyma-project/cli/cmd/kyma/call/function"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new call command
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "call",
+		Short: "Sends requests to resources on the Kyma cluster or running locally.",
+		Long:  "Use this command to send an HTTP request to the given resource and print the response. Currently, you can only use it for Functions.",
+	}
+
+	cmd.AddCommand(function.NewCmd(function.NewOptions(o)))
+	return cmd
+}
@@ -0,0 +1,281 @@
+package function
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/docker/runtimes"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new call function command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		opts:    o,
+		Command: cli.Command{Options: o.Options},
+	}
+	cmd := &cobra.Command{
+		Use:   "function [name]",
+		Short: "Sends an HTTP request to a Function.",
+		Long: `Use this command to send an HTTP request to a Function deployed on the cluster or running locally, and to print the response.
+The request is sent to the host of the Function's APIRule. If the Function has no APIRule, the request is sent through a port forwarding to one of the Function's Pods.
+Use the "--local" flag to send the request to a Function started with the "kyma run function" command.
+If you don't provide the name of the Function, the name is read from the configuration file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return c.Run(name)
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", `Namespace of the Function.`)
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", "", `Full path to the config file of the Function. It is used to resolve the name and the event types of the Function.`)
+	cmd.Flags().StringVarP(&o.Method, "method", "X", "", `HTTP method of the request. Defaults to "POST" if a request body or a CloudEvent is sent, and to "GET" otherwise.`)
+	cmd.Flags().StringVar(&o.Path, "path", "/", `Path of the request.`)
+	cmd.Flags().StringArrayVarP(&o.Headers, "header", "H", []string{}, `Header of the request in the format "NAME: VALUE". You can use this flag multiple times.`)
+	cmd.Flags().StringVarP(&o.DataFile, "data-file", "d", "", `Path to the file with the request body. Use "-" to read the request body from stdin.`)
+	cmd.Flags().BoolVar(&o.Local, "local", false, `Sends the request to the Function started locally with the "kyma run function" command.`)
+	cmd.Flags().StringVarP(&o.Port, "port", "p", "8080", `The port on which the local Function is exposed. Used only with the "--local" flag.`)
+	cmd.Flags().StringToStringVar(&o.CloudEvent, "cloudevent", map[string]string{}, `Sends the request body as a CloudEvent with the given attributes, for example "type=order.created.v1,source=my-app". The event type must be declared in the subscriptions of the Function's config file.`)
+	cmd.Flags().BoolVar(&o.Insecure, "insecure", false, `Skips the verification of the TLS certificate of the APIRule host.`)
+	cmd.Flags().DurationVarP(&o.Timeout, "timeout", "t", 0, `Maximum time to wait for the response, where "0" means "infinite". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(name string) error {
+	headers, err := parseHeaders(c.opts.Headers)
+	if err != nil {
+		return err
+	}
+
+	if name == "" || len(c.opts.CloudEvent) > 0 {
+		cfg, err := c.loadConfiguration()
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			name = cfg.Name
+		} else if name != cfg.Name {
+			return fmt.Errorf("The configuration file '%s' belongs to Function '%s' and not to Function '%s'", c.opts.Filename, cfg.Name, name)
+		}
+		if c.opts.Namespace == "" {
+			c.opts.Namespace = cfg.Namespace
+		}
+		if len(c.opts.CloudEvent) > 0 {
+			if c.opts.CloudEvent["type"] == "" {
+				return errors.New("The flag --cloudevent requires the event type, for example: --cloudevent type=order.created.v1")
+			}
+			ceHeaders, err := serverless.CloudEventHeaders(c.opts.CloudEvent, cfg)
+			if err != nil {
+				return err
+			}
+			for key, values := range ceHeaders {
+				headers[key] = values
+			}
+			if headers.Get("Content-Type") == "" {
+				headers.Set("Content-Type", serverless.DefaultEventContentType)
+			}
+		}
+	}
+
+	body, err := c.requestBody()
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		defer body.Close()
+	}
+
+	ctx, cancel := context.WithCancel(c.Context())
+	if c.opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+	}
+	defer cancel()
+
+	// stops the port forwarding, if any
+	stop := make(chan struct{})
+	defer close(stop)
+
+	url, err := c.functionURL(ctx, name, stop)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, c.method(body != nil), url, body)
+	if err != nil {
+		return errors.Wrap(err, "Could not create the request")
+	}
+	req.Header = headers
+
+	if c.opts.Verbose {
+		fmt.Fprintf(os.Stderr, "%s %s\n", req.Method, url)
+	}
+
+	client := &http.Client{}
+	if c.opts.Insecure {
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "Could not call Function '%s'", name)
+	}
+	defer resp.Body.Close()
+
+	if c.opts.Verbose {
+		fmt.Fprintf(os.Stderr, "%s %s\n", resp.Proto, resp.Status)
+		for key, values := range resp.Header {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", key, strings.Join(values, ", "))
+		}
+	}
+	if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+		return errors.Wrap(err, "Could not read the response")
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("Function '%s' responded with status %s", name, resp.Status)
+	}
+	return nil
+}
+
+func (c *command) loadConfiguration() (workspace.Cfg, error) {
+	if err := c.opts.defaultFilename(); err != nil {
+		return workspace.Cfg{}, err
+	}
+
+	file, err := os.Open(c.opts.Filename)
+	if err != nil {
+		return workspace.Cfg{}, errors.Wrap(err, "Could not open the configuration file. Provide the name of the Function or the path to its configuration file")
+	}
+	defer file.Close()
+
+	var cfg workspace.Cfg
+	if err := yaml.NewDecoder(file).Decode(&cfg); err != nil {
+		return workspace.Cfg{}, errors.Wrap(err, "Could not decode the configuration file")
+	}
+	return cfg, nil
+}
+
+func (c *command) requestBody() (io.ReadCloser, error) {
+	switch c.opts.DataFile {
+	case "":
+		return nil, nil
+	case "-":
+		return ioutil.NopCloser(os.Stdin), nil
+	default:
+		file, err := os.Open(c.opts.DataFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "Could not open the file with the request body")
+		}
+		return file, nil
+	}
+}
+
+func (c *command) method(hasBody bool) string {
+	switch {
+	case c.opts.Method != "":
+		return strings.ToUpper(c.opts.Method)
+	case hasBody || len(c.opts.CloudEvent) > 0:
+		return http.MethodPost
+	default:
+		return http.MethodGet
+	}
+}
+
+//functionURL returns the URL of the Function, which is either the local container, the host of an APIRule or a port forwarding to a Pod of the Function
+func (c *command) functionURL(ctx context.Context, name string, stop <-chan struct{}) (string, error) {
+	path := "/" + strings.TrimPrefix(c.opts.Path, "/")
+	if c.opts.Local {
+		return fmt.Sprintf("http://localhost:%s%s", c.opts.Port, path), nil
+	}
+
+	var err error
+	if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
+		return "", errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
+	}
+	c.opts.setDefaults(c.K8s.DefaultNamespace())
+
+	// the APIRule CRD is missing if the API Gateway is not installed, so the Function can only be reached through a port forwarding
+	apiRules, _ := serverless.APIRules(ctx, c.K8s.Dynamic(), c.opts.Namespace, name)
+	if host := apiRuleHost(apiRules); host != "" {
+		if !strings.Contains(host, ".") {
+			domain, err := serverless.KymaHostAddress(ctx, c.K8s.Istio())
+			if err != nil {
+				return "", err
+			}
+			host = fmt.Sprintf("%s.%s", host, domain)
+		}
+		return fmt.Sprintf("https://%s%s", host, path), nil
+	}
+
+	pod, err := c.runningPod(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	port, err := kube.PortForward(c.K8s.RestConfig(), c.opts.Namespace, pod.Name, runtimes.ServerPort, stop)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("http://localhost:%d%s", port, path), nil
+}
+
+//runningPod returns a running Pod of the Function
+func (c *command) runningPod(ctx context.Context, name string) (corev1.Pod, error) {
+	pods, err := serverless.ResourcePods(ctx, c.K8s.Static(), c.opts.Namespace, name, serverless.ResourceDeployment)
+	if err != nil {
+		return corev1.Pod{}, errors.Wrapf(err, "Could not list the Pods of Function '%s'", name)
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			return pod, nil
+		}
+	}
+	return corev1.Pod{}, fmt.Errorf("Function '%s' has no APIRule and no running Pods in Namespace '%s'", name, c.opts.Namespace)
+}
+
+//apiRuleHost returns the host of the first ready APIRule, or of the first APIRule if none is ready
+func apiRuleHost(apiRules []serverless.ResourceStatus) string {
+	for _, r := range apiRules {
+		if r.Ready {
+			return r.Details
+		}
+	}
+	if len(apiRules) > 0 {
+		return apiRules[0].Details
+	}
+	return ""
+}
+
+func parseHeaders(values []string) (http.Header, error) {
+	headers := http.Header{}
+	for _, v := range values {
+		parts := strings.SplitN(v, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("Invalid header '%s'. Headers must be in the format 'NAME: VALUE'", v)
+		}
+		headers.Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+	return headers, nil
+}
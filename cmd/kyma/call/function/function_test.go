@@ -0,0 +1,74 @@
+package function
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFunctionFlags ensures that the provided command flags are stored in the options.
+func TestFunctionFlags(t *testing.T) {
+	t.Parallel()
+	o := NewOptions(&cli.Options{})
+	c := NewCmd(o)
+
+	// test default flag values
+	require.Empty(t, o.Namespace, "Default value for the --namespace flag not as expected.")
+	require.Empty(t, o.Method, "Default value for the --method flag not as expected.")
+	require.Equal(t, "/", o.Path, "Default value for the --path flag not as expected.")
+	require.Equal(t, "8080", o.Port, "Default value for the --port flag not as expected.")
+	require.False(t, o.Local, "Default value for the --local flag not as expected.")
+	require.Empty(t, o.CloudEvent, "Default value for the --cloudevent flag not as expected.")
+
+	// test passing flags
+	err := c.ParseFlags([]string{
+		"-n", "test-namespace",
+		"-X", "put",
+		"--path", "/orders",
+		"-H", "X-Test: 1",
+		"-H", "X-Other: 2",
+		"-d", "-",
+		"--local",
+		"-p", "9090",
+		"--cloudevent", "type=order.created.v1,source=test",
+		"-t", "10s",
+	})
+
+	require.NoError(t, err, "Parsing flags should not return an error")
+	require.Equal(t, "test-namespace", o.Namespace, "The parsed value for the --namespace flag not as expected.")
+	require.Equal(t, "put", o.Method, "The parsed value for the --method flag not as expected.")
+	require.Equal(t, "/orders", o.Path, "The parsed value for the --path flag not as expected.")
+	require.Equal(t, []string{"X-Test: 1", "X-Other: 2"}, o.Headers, "The parsed value for the --header flag not as expected.")
+	require.Equal(t, "-", o.DataFile, "The parsed value for the --data-file flag not as expected.")
+	require.True(t, o.Local, "The parsed value for the --local flag not as expected.")
+	require.Equal(t, "9090", o.Port, "The parsed value for the --port flag not as expected.")
+	require.Equal(t, map[string]string{"type": "order.created.v1", "source": "test"}, o.CloudEvent, "The parsed value for the --cloudevent flag not as expected.")
+	require.Equal(t, 10*time.Second, o.Timeout, "The parsed value for the --timeout flag not as expected.")
+}
+
+func TestParseHeaders(t *testing.T) {
+	t.Parallel()
+	headers, err := parseHeaders([]string{"Content-Type: text/plain", "X-Test:a:b"})
+	require.NoError(t, err)
+	require.Equal(t, "text/plain", headers.Get("Content-Type"))
+	require.Equal(t, "a:b", headers.Get("X-Test"))
+
+	_, err = parseHeaders([]string{"invalid"})
+	require.Error(t, err)
+}
+
+func TestMethod(t *testing.T) {
+	t.Parallel()
+	c := command{opts: &Options{}}
+	require.Equal(t, http.MethodGet, c.method(false))
+	require.Equal(t, http.MethodPost, c.method(true))
+
+	c.opts.CloudEvent = map[string]string{"type": "order.created.v1"}
+	require.Equal(t, http.MethodPost, c.method(false))
+
+	c.opts.Method = "put"
+	require.Equal(t, http.MethodPut, c.method(true))
+}
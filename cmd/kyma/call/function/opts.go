@@ -0,0 +1,50 @@
+package function
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/cli"
+)
+
+//Options defines available options for the command
+type Options struct {
+	*cli.Options
+
+	Namespace  string
+	Filename   string
+	Method     string
+	Path       string
+	Headers    []string
+	DataFile   string
+	Local      bool
+	Port       string
+	CloudEvent map[string]string
+	Insecure   bool
+	Timeout    time.Duration
+}
+
+//NewOptions creates options with default values
+func NewOptions(o *cli.Options) *Options {
+	options := &Options{Options: o}
+	return options
+}
+
+func (o *Options) defaultFilename() error {
+	if o.Filename == "" {
+		pwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		o.Filename = filepath.Join(pwd, workspace.CfgFilename)
+	}
+	return nil
+}
+
+func (o *Options) setDefaults(defaultNamespace string) {
+	if o.Namespace == "" {
+		o.Namespace = defaultNamespace
+	}
+}
@@ -0,0 +1,15 @@
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new cluster command
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Manages the clusters provisioned by Kyma CLI.",
+		Long:  "Use this command to manage the clusters that you provisioned with the `kyma provision` commands.",
+	}
+	return cmd
+}
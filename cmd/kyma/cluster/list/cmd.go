@@ -0,0 +1,81 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/clusters"
+	"github.com/kyma-project/cli/internal/files"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new cluster list command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the clusters provisioned by Kyma CLI.",
+		Long: `Use this command to list the clusters which were provisioned with the ` + "`kyma provision`" + ` commands and whose state is stored in the Kyma CLI home directory.
+To remove a cluster, run ` + "`kyma deprovision {CLUSTER_NAME}`" + `.`,
+		RunE:    func(_ *cobra.Command, _ []string) error { return c.Run() },
+		Aliases: []string{"l"},
+	}
+	output.Enable(cmd, &o.OutputFormat)
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run() error {
+	home, err := files.KymaHome()
+	if err != nil {
+		return err
+	}
+
+	records, err := clusters.List(home)
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 && !c.StructuredOutput() {
+		fmt.Println("No clusters found")
+		return nil
+	}
+	return c.PrintResult(append(clusterList{}, records...))
+}
+
+//clusterList is the result of the command
+type clusterList []*clusters.Record
+
+//TableRows returns the clusters as table rows
+func (l clusterList) TableRows(wide bool) ([]string, [][]string) {
+	rows := make([][]string, 0, len(l))
+	for _, r := range l {
+		rows = append(rows, []string{
+			r.Name(),
+			r.ProviderName,
+			r.Project(),
+			valueOrDash(r.Region()),
+			r.Created.Format(timeFormat),
+		})
+	}
+	return []string{"NAME", "PROVIDER", "PROJECT", "REGION", "CREATED"}, rows
+}
+
+func valueOrDash(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"github.com/kyma-project/cli/cmd/kyma/config/get"
+	"github.com/kyma-project/cli/cmd/kyma/config/set"
+	"github.com/kyma-project/cli/cmd/kyma/config/useprofile"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new config command
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manages the profiles of the Kyma CLI config file.",
+		Long: `Use this command to manage the config file of Kyma CLI, which is stored in the Kyma CLI home directory.
+The config file contains profiles, which set the default values of flags. The keys of a profile consist of the path of a command without "kyma" and the name of the flag, joined by dots, such as "alpha.deploy.concurrency" for the "--concurrency" flag of "kyma alpha deploy". Keys with a shorter path apply to all subcommands, for example "namespace" sets the "--namespace" flag of all commands, and "verbose" enables the verbose mode.
+Environment variables named after the keys, such as KYMA_ALPHA_DEPLOY_CONCURRENCY, override the config file, and flags override both. Keys without a command path only have an environment variable for the global flags, such as KYMA_CI or KYMA_VERBOSE. The KYMA_CONFIG_PROFILE environment variable selects the profile instead of the config file.`,
+		// the config file doesn't set the flags of the commands which manage it
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error { return o.SetupOutput(cmd) },
+	}
+
+	cmd.AddCommand(
+		get.NewCmd(get.NewOptions(o)),
+		set.NewCmd(set.NewOptions(o)),
+		useprofile.NewCmd(useprofile.NewOptions(o)),
+	)
+	return cmd
+}
@@ -0,0 +1,99 @@
+package get
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/config"
+	"github.com/kyma-project/cli/internal/files"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new config get command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "get [KEY]",
+		Short: "Shows the settings of a profile of the Kyma CLI config file.",
+		Long: `Use this command to show the value of a key in a profile of the Kyma CLI config file. Without a key, all keys of the profile are shown.
+By default, the active profile is used, which is selected with "kyma config use-profile" or with the KYMA_CONFIG_PROFILE environment variable.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error { return c.Run(args) },
+	}
+
+	cmd.Flags().StringVarP(&o.Profile, "profile", "p", "", `Name of the profile. Defaults to the active profile.`)
+	output.Enable(cmd, &o.OutputFormat, output.JSON, output.YAML, output.None, output.JSONPath, output.Template)
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(args []string) error {
+	home, err := files.KymaHome()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(home)
+	if err != nil {
+		return err
+	}
+
+	name := c.opts.Profile
+	if name == "" {
+		name = cfg.ActiveProfile()
+	}
+	if !cfg.HasProfile(name) {
+		return fmt.Errorf("Profile '%s' doesn't exist", name)
+	}
+	profile := cfg.Profile(name)
+
+	if len(args) == 1 {
+		value, ok := profile[args[0]]
+		if !ok {
+			return fmt.Errorf("Key '%s' is not set in profile '%s'", args[0], name)
+		}
+		if c.StructuredOutput() {
+			return c.PrintResult(value)
+		}
+		fmt.Println(value)
+		return nil
+	}
+	if c.StructuredOutput() {
+		if profile == nil {
+			profile = config.Profile{}
+		}
+		return c.PrintResult(profileResult{Name: name, Active: name == cfg.ActiveProfile(), Settings: profile})
+	}
+	printProfile(os.Stdout, name, name == cfg.ActiveProfile(), profile)
+	return nil
+}
+
+//profileResult is the result of the command without a key
+type profileResult struct {
+	Name     string         `json:"name"`
+	Active   bool           `json:"active"`
+	Settings config.Profile `json:"settings"`
+}
+
+//printProfile prints the keys of the profile in alphabetical order
+func printProfile(out io.Writer, name string, active bool, profile config.Profile) {
+	status := ""
+	if active {
+		status = " (active)"
+	}
+	fmt.Fprintf(out, "Profile: %s%s\n", name, status)
+	for _, key := range profile.Keys() {
+		fmt.Fprintf(out, "%s: %s\n", key, profile[key])
+	}
+}
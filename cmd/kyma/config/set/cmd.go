@@ -0,0 +1,86 @@
+package set
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/config"
+	"github.com/kyma-project/cli/internal/files"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new config set command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "set KEY [VALUE]",
+		Short: "Sets the default value of a flag in a profile of the Kyma CLI config file.",
+		Long: `Use this command to set the default value of a flag in a profile of the Kyma CLI config file. The profile is created if it doesn't exist.
+The key consists of the path of a command without "kyma" and the name of the flag, joined by dots, such as "alpha.deploy.concurrency". Keys with a shorter path apply to all subcommands, such as "namespace" or "verbose".
+Use the "--unset" flag to remove the key from the profile.`,
+		Example: `  kyma config set alpha.deploy.concurrency 8
+  kyma config set namespace dev --profile dev
+  kyma config set verbose --unset`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if o.Unset {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			if len(args) != 2 {
+				return errors.New("The key and the value are required")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error { return c.Run(cmd.Root(), args) },
+	}
+
+	cmd.Flags().StringVarP(&o.Profile, "profile", "p", "", `Name of the profile. Defaults to the active profile.`)
+	cmd.Flags().BoolVar(&o.Unset, "unset", false, `Removes the key from the profile.`)
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(root *cobra.Command, args []string) error {
+	key := args[0]
+	if err := config.ValidateKey(root, key); err != nil {
+		return err
+	}
+
+	home, err := files.KymaHome()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(home)
+	if err != nil {
+		return err
+	}
+	profile := c.opts.Profile
+	if profile == "" {
+		profile = cfg.ActiveProfile()
+	}
+
+	if c.opts.Unset {
+		cfg.Unset(profile, key)
+	} else {
+		cfg.Set(profile, key, args[1])
+	}
+	if err := cfg.Save(home); err != nil {
+		return err
+	}
+
+	if c.opts.Unset {
+		fmt.Printf("Key '%s' removed from profile '%s'\n", key, profile)
+	} else {
+		fmt.Printf("Key '%s' set in profile '%s'\n", key, profile)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package useprofile
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/config"
+	"github.com/kyma-project/cli/internal/files"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new config use-profile command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "use-profile PROFILE",
+		Short: "Activates a profile of the Kyma CLI config file.",
+		Long: `Use this command to select the profile of the Kyma CLI config file which sets the default values of flags.
+To create a profile, set a key in it with "kyma config set KEY VALUE --profile PROFILE". The KYMA_CONFIG_PROFILE environment variable takes precedence over the selected profile.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error { return c.Run(args[0]) },
+	}
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(profile string) error {
+	home, err := files.KymaHome()
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(home)
+	if err != nil {
+		return err
+	}
+	if !cfg.HasProfile(profile) {
+		return fmt.Errorf("Profile '%s' doesn't exist. Create it with: kyma config set KEY VALUE --profile %s", profile, profile)
+	}
+
+	cfg.CurrentProfile = profile
+	if err := cfg.Save(home); err != nil {
+		return err
+	}
+	fmt.Printf("Switched to profile '%s'\n", profile)
+	if env := os.Getenv(config.ProfileEnv); env != "" && env != profile {
+		fmt.Fprintf(os.Stderr, "Warning: the environment variable %s selects profile '%s' instead\n", config.ProfileEnv, env)
+	}
+	return nil
+}
@@ -9,10 +9,9 @@ import (
 //Options defines available options for the version command
 type Options struct {
 	*cli.Options
-	Namespace    string
-	Update       bool
-	OutputFormat string
-	Timeout      time.Duration
+	Namespace string
+	Update    bool
+	Timeout   time.Duration
 }
 
 //NewOptions creates options with default values
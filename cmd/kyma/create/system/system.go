@@ -2,18 +2,17 @@ package system
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
-	"gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/kyma-project/cli/internal/cli"
 	"github.com/kyma-project/cli/internal/kube"
 	"github.com/kyma-project/cli/internal/nice"
+	"github.com/kyma-project/cli/internal/output"
 	"github.com/spf13/cobra"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -53,14 +52,14 @@ To generate a new token, rerun the same command with the ` + "`--update`" + ` fl
 
 	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Namespace to bind the system to.")
 	cmd.Flags().BoolVarP(&o.Update, "update", "u", false, "Updates an existing system and/or generates a new token for it.")
-	cmd.Flags().StringVarP(&o.OutputFormat, "output", "o", "", "Specifies the format of the command output. Supported formats: YAML, JSON.")
 	cmd.Flags().DurationVarP(&o.Timeout, "timeout", "", 2*time.Minute, "Timeout after which CLI stops watching the installation progress.")
+	output.Enable(cmd, &o.OutputFormat, output.JSON, output.YAML, output.JSONPath, output.Template)
 
 	return cmd
 }
 
 func (c *command) Run(args []string) error {
-	if c.opts.OutputFormat == "" && !c.opts.NonInteractive {
+	if !c.StructuredOutput() && !c.opts.NonInteractive {
 		// TODO remove when out of alpha
 		np := nice.Nice{}
 		np.PrintImportant("WARNING: This command is experimental and might change in its final version.")
@@ -86,30 +85,30 @@ func (c *command) Run(args []string) error {
 	// create system
 	name := args[0]
 
-	c.newStep("Creating system")
+	step := c.NewStep("Creating system")
 	_, err = createSystem(name, c.opts.Update, c.K8s)
 	if err != nil {
-		c.failStep()
+		step.Failure()
 		return errors.Wrap(err, "Could not create System")
 	}
-	c.successStep("System created")
+	step.Successf("System created")
 
 	//bind NS
-	c.newStep("Binding namespace")
+	step = c.NewStep("Binding namespace")
 	if err := bindNamespace(name, c.opts.Namespace, c.K8s); err != nil {
-		c.failStep()
+		step.Failure()
 		return err
 	}
-	c.successStep("Namespace bound")
+	step.Successf("Namespace bound")
 
 	// create token
-	c.newStep("Generating access token")
+	step = c.NewStep("Generating access token")
 	token, err := createToken(name, c.opts.Namespace, c.K8s)
 	if err != nil {
-		c.failStep()
+		step.Failure()
 		return err
 	}
-	c.successStep("Token generated")
+	step.Successf("Token generated")
 
 	// print result
 	// remove fields that are irrelevant for the consumer:
@@ -120,20 +119,9 @@ func (c *command) Run(args []string) error {
 	unstructured.RemoveNestedField(token.Object, "metadata", "selfLink")
 	unstructured.RemoveNestedField(token.Object, "metadata", "uid")
 
-	switch c.opts.OutputFormat {
-	case "yaml":
-		b, err := yaml.Marshal(token.Object)
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(b))
-
-	case "json":
-		b, err := json.MarshalIndent(token.Object, "", " ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(b))
+	switch {
+	case c.StructuredOutput():
+		return c.PrintResult(token.Object)
 
 	default:
 
@@ -173,27 +161,6 @@ func (c *command) Run(args []string) error {
 	return nil
 }
 
-// newStep creates a step only if the output format supports it.
-func (c *command) newStep(msg string) {
-	if c.opts.OutputFormat == "" {
-		c.CurrentStep = c.NewStep(msg)
-	}
-}
-
-// successStep marks a step as successful only if the output format supports it.
-func (c *command) successStep(msg string) {
-	if c.opts.OutputFormat == "" {
-		c.CurrentStep.Successf(msg)
-	}
-}
-
-// failStep marks a step as failed only if the output format supports it.
-func (c *command) failStep() {
-	if c.opts.OutputFormat == "" {
-		c.CurrentStep.Failure()
-	}
-}
-
 func createSystem(name string, update bool, k8s kube.KymaKube) (*unstructured.Unstructured, error) {
 	sysRes := schema.GroupVersionResource{
 		Group:    "applicationconnector.kyma-project.io",
@@ -20,7 +20,7 @@ import (
 
 func TestValidateArgs(t *testing.T) {
 	t.Parallel()
-	c := NewCmd(NewOptions(nil))
+	c := NewCmd(NewOptions(cli.NewOptions()))
 
 	// no args
 	err := c.ValidateArgs(nil)
@@ -37,28 +37,20 @@ func TestValidateArgs(t *testing.T) {
 
 func TestSteps(t *testing.T) {
 	t.Parallel()
-	c := command{
-		Command: cli.Command{
-			Options: cli.NewOptions(),
-		},
-		opts: NewOptions(nil),
-	}
+	o := NewOptions(cli.NewOptions())
+	cmd := NewCmd(o)
+
+	// with default output steps print to StdOut
+	require.NoError(t, o.SetupOutput(cmd))
+	require.Nil(t, o.Factory.Output, "On default output steps should print to StdOut")
+
+	// with YAML output steps print to StdErr
+	require.NoError(t, cmd.ParseFlags([]string{"-o", "yaml"}))
+	require.NoError(t, o.SetupOutput(cmd))
+	require.Equal(t, os.Stderr, o.Factory.Output, "On yaml output steps should print to StdErr")
 
-	// with default output steps are created and print to StdOut
-	c.newStep("test msg")
-	require.NotNil(t, c.CurrentStep, "On default output steps should be used")
-	// success and fail should not crash
-	c.successStep("")
-	c.failStep()
-
-	// with YAML output steps are omitted
-	c.opts.OutputFormat = "yaml"
-	c.CurrentStep = nil
-	c.newStep("test msg")
-	require.Nil(t, c.CurrentStep, "On yaml output steps should be omitted")
-	// success and fail should not panic
-	c.successStep("")
-	c.failStep()
+	require.NoError(t, cmd.ParseFlags([]string{"-o", "wide"}))
+	require.Error(t, o.SetupOutput(cmd), "The wide output is not supported")
 }
 
 func TestCreateSystem(t *testing.T) {
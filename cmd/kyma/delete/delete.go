@@ -0,0 +1,19 @@
+package delete
+
+import (
+	"github.com/kyma-project/cli/cmd/kyma/delete/function"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new delete command
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Deletes resources from the Kyma cluster.",
+		Long:  "Use this command to delete the given resource and the resources it owns from the Kyma cluster. Currently, you can only use it for Functions.",
+	}
+
+	cmd.AddCommand(function.NewCmd(function.NewOptions(o)))
+	return cmd
+}
@@ -0,0 +1,284 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/client"
+	"github.com/kyma-incubator/hydroform/function/pkg/operator"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	deletingFormat     = "%s - %s deleting... %s"
+	deletedFormat      = "%s - %s deleted %s"
+	deleteFailedFormat = "%s - %s can't be removed %s"
+	dryRunSuffix       = "(dry run)"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+// resourceGroup contains the resources of one type which are deleted together
+type resourceGroup struct {
+	gvr   schema.GroupVersionResource
+	items []unstructured.Unstructured
+}
+
+//NewCmd creates a new delete function command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		opts:    o,
+		Command: cli.Command{Options: o.Options},
+	}
+	cmd := &cobra.Command{
+		Use:   "function [name]",
+		Short: "Deletes a Function and its resources from the Kyma cluster.",
+		Long: `Use this command to delete a Function together with the resources it owns, such as Subscriptions and APIRules.
+The GitRepository of a Git Function is deleted as well if Kyma CLI created it, unless other Functions use it or you add the "--keep-git-repository" flag.
+If you don't provide the name of the Function, the name and the Namespace are read from the configuration file.
+Use the "--dry-run" flag to list the resources to be deleted without deleting them.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return c.Run(name)
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", `Namespace of the Function.`)
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", "", `Full path to the config file of the Function. It is used if you don't provide the name of the Function.`)
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, `Lists the resources to be deleted without deleting them.`)
+	cmd.Flags().BoolVar(&o.KeepGitRepository, "keep-git-repository", false, `Keeps the GitRepository of a Git Function on the cluster.`)
+	cmd.Flags().DurationVarP(&o.Timeout, "timeout", "t", 0, `Maximum time during which the resources are being deleted, where "0" means "infinite". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(name string) error {
+	if name == "" {
+		cfg, err := c.loadConfiguration()
+		if err != nil {
+			return err
+		}
+		name = cfg.Name
+		if c.opts.Namespace == "" {
+			c.opts.Namespace = cfg.Namespace
+		}
+	}
+
+	var err error
+	if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
+		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
+	}
+	c.opts.setDefaults(c.K8s.DefaultNamespace())
+
+	ctx, cancel := context.WithCancel(c.Context())
+	if c.opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+	}
+	defer cancel()
+
+	step := c.NewStep(fmt.Sprintf("Looking up the resources of Function '%s'", name))
+	groups, note, err := c.resources(ctx, c.K8s.Dynamic(), name)
+	if err != nil {
+		step.Failure()
+		return err
+	}
+	step.Successf("Resources of Function '%s' found", name)
+	if note != "" {
+		step.LogInfo(note)
+	}
+
+	options := operator.DeleteOptions{
+		DeletionPropagation: metav1.DeletePropagationBackground,
+		Options: operator.Options{
+			Callbacks: callbacks(c),
+		},
+	}
+	if c.opts.DryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+
+	for _, group := range groups {
+		resourceClient := c.K8s.Dynamic().Resource(group.gvr).Namespace(c.opts.Namespace)
+		if err := operator.NewGenericOperator(resourceClient, group.items...).Delete(ctx, options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *command) loadConfiguration() (workspace.Cfg, error) {
+	if err := c.opts.defaultFilename(); err != nil {
+		return workspace.Cfg{}, err
+	}
+
+	file, err := os.Open(c.opts.Filename)
+	if err != nil {
+		return workspace.Cfg{}, errors.Wrap(err, "Could not open the configuration file. Provide the name of the Function or the path to its configuration file")
+	}
+	defer file.Close()
+
+	var cfg workspace.Cfg
+	if err := yaml.NewDecoder(file).Decode(&cfg); err != nil {
+		return workspace.Cfg{}, errors.Wrap(err, "Could not decode the configuration file")
+	}
+	return cfg, nil
+}
+
+//resources returns the resources of the Function in the order in which they are deleted: the resources owned by the Function, the Function, and its GitRepository.
+//It also returns a note if the GitRepository is kept because Kyma CLI didn't create it.
+func (c *command) resources(ctx context.Context, dyn dynamic.Interface, name string) ([]resourceGroup, string, error) {
+	function, err := dyn.Resource(operator.GVRFunction).Namespace(c.opts.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "", fmt.Errorf("Function '%s' not found in Namespace '%s'", name, c.opts.Namespace)
+		}
+		return nil, "", errors.Wrapf(err, "Could not get Function '%s'", name)
+	}
+
+	var groups []resourceGroup
+	for _, gvr := range []schema.GroupVersionResource{operator.GVRSubscription, operator.GVRApiRule} {
+		items, err := ownedResources(ctx, dyn.Resource(gvr).Namespace(c.opts.Namespace), function.GetUID())
+		if err != nil {
+			return nil, "", err
+		}
+		if len(items) > 0 {
+			groups = append(groups, resourceGroup{gvr: gvr, items: items})
+		}
+	}
+	groups = append(groups, resourceGroup{gvr: operator.GVRFunction, items: []unstructured.Unstructured{*function}})
+
+	if c.opts.KeepGitRepository {
+		return groups, "", nil
+	}
+	repository, err := gitRepository(ctx, dyn, *function)
+	if err != nil {
+		return nil, "", err
+	}
+	if repository == nil {
+		return groups, "", nil
+	}
+	if repository.GetLabels()[serverless.CreatedByLabel] != serverless.CreatedByCLI {
+		return groups, fmt.Sprintf("GitRepository '%s' was not created by Kyma CLI and is kept. Delete it with: kubectl delete gitrepositories.serverless.kyma-project.io %s -n %s", repository.GetName(), repository.GetName(), repository.GetNamespace()), nil
+	}
+	groups = append(groups, resourceGroup{gvr: operator.GVRGitRepository, items: []unstructured.Unstructured{*repository}})
+	return groups, "", nil
+}
+
+//ownedResources returns the resources which have an owner reference to the object with the given UID
+func ownedResources(ctx context.Context, resourceClient dynamic.ResourceInterface, owner types.UID) ([]unstructured.Unstructured, error) {
+	list, err := resourceClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		// the CRD is missing if the related Kyma component is not installed
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var items []unstructured.Unstructured
+	for _, item := range list.Items {
+		for _, ref := range item.GetOwnerReferences() {
+			if ref.UID == owner {
+				items = append(items, item)
+				break
+			}
+		}
+	}
+	return items, nil
+}
+
+//gitRepository returns the GitRepository of a Git Function, or nil if the Function has inline sources or other Functions use the same GitRepository
+func gitRepository(ctx context.Context, dyn dynamic.Interface, function unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	sourceType, _, _ := unstructured.NestedString(function.Object, "spec", "type")
+	repositoryName, _, _ := unstructured.NestedString(function.Object, "spec", "source")
+	if sourceType != string(workspace.SourceTypeGit) || repositoryName == "" {
+		return nil, nil
+	}
+
+	functions, err := dyn.Resource(operator.GVRFunction).Namespace(function.GetNamespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not list the Functions")
+	}
+	for _, other := range functions.Items {
+		otherType, _, _ := unstructured.NestedString(other.Object, "spec", "type")
+		otherRepository, _, _ := unstructured.NestedString(other.Object, "spec", "source")
+		if other.GetName() != function.GetName() && otherType == string(workspace.SourceTypeGit) && otherRepository == repositoryName {
+			return nil, nil
+		}
+	}
+
+	repository, err := dyn.Resource(operator.GVRGitRepository).Namespace(function.GetNamespace()).Get(ctx, repositoryName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not get GitRepository '%s'", repositoryName)
+	}
+	return repository, nil
+}
+
+type logger struct {
+	*command
+}
+
+func callbacks(c *command) operator.Callbacks {
+	logger := logger{c}
+	return operator.Callbacks{
+		Pre: []operator.Callback{
+			logger.pre,
+		},
+		Post: []operator.Callback{
+			logger.post,
+		},
+	}
+}
+
+func (l *logger) suffix() string {
+	if l.opts.DryRun {
+		return dryRunSuffix
+	}
+	return ""
+}
+
+func (l *logger) pre(v interface{}, err error) error {
+	entry, ok := v.(*unstructured.Unstructured)
+	if !ok {
+		return errors.New("can't parse interface{} to the Unstructured")
+	}
+	l.NewStep(fmt.Sprintf(deletingFormat, entry.GetKind(), entry.GetName(), l.suffix()))
+	return err
+}
+
+func (l *logger) post(v interface{}, err error) error {
+	entry, ok := v.(client.PostStatusEntry)
+	if !ok {
+		return errors.New("can't parse interface{} to StatusEntry interface")
+	}
+	if err != nil || entry.StatusType == client.StatusTypeDeleteFailed {
+		l.CurrentStep.Failuref(deleteFailedFormat, entry.GetKind(), entry.GetName(), l.suffix())
+		return err
+	}
+	l.CurrentStep.Successf(deletedFormat, entry.GetKind(), entry.GetName(), l.suffix())
+	return nil
+}
@@ -0,0 +1,130 @@
+package function
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynFake "k8s.io/client-go/dynamic/fake"
+)
+
+// TestFunctionFlags ensures that the provided command flags are stored in the options.
+func TestFunctionFlags(t *testing.T) {
+	t.Parallel()
+	o := NewOptions(&cli.Options{})
+	c := NewCmd(o)
+
+	// test default flag values
+	require.Empty(t, o.Namespace, "Default value for the --namespace flag not as expected.")
+	require.Empty(t, o.Filename, "Default value for the --filename flag not as expected.")
+	require.False(t, o.DryRun, "Default value for the --dry-run flag not as expected.")
+	require.False(t, o.KeepGitRepository, "Default value for the --keep-git-repository flag not as expected.")
+	require.Equal(t, time.Duration(0), o.Timeout, "Default value for the --timeout flag not as expected.")
+
+	// test passing flags
+	err := c.ParseFlags([]string{
+		"-n", "test-namespace",
+		"-f", "/fakepath/config.yaml",
+		"--dry-run",
+		"--keep-git-repository",
+		"-t", "1m",
+	})
+	require.NoError(t, err, "Parsing flags should not return an error")
+	require.Equal(t, "test-namespace", o.Namespace, "The parsed value for the --namespace flag not as expected.")
+	require.Equal(t, "/fakepath/config.yaml", o.Filename, "The parsed value for the --filename flag not as expected.")
+	require.True(t, o.DryRun, "The parsed value for the --dry-run flag not as expected.")
+	require.True(t, o.KeepGitRepository, "The parsed value for the --keep-git-repository flag not as expected.")
+	require.Equal(t, time.Minute, o.Timeout, "The parsed value for the --timeout flag not as expected.")
+}
+
+func newObject(apiVersion, kind, name string, uid string, owner string, spec map[string]interface{}) *unstructured.Unstructured {
+	metadata := map[string]interface{}{"name": name, "namespace": "default", "uid": uid}
+	if owner != "" {
+		metadata["ownerReferences"] = []interface{}{
+			map[string]interface{}{"apiVersion": "serverless.kyma-project.io/v1alpha1", "kind": "Function", "name": "owner", "uid": owner},
+		}
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   metadata,
+		"spec":       spec,
+	}}
+}
+
+func TestResources(t *testing.T) {
+	t.Parallel()
+	gitFunction := newObject("serverless.kyma-project.io/v1alpha1", "Function", "orders", "orders-uid", "", map[string]interface{}{"type": "git", "source": "orders-repo"})
+	sharedFunction := newObject("serverless.kyma-project.io/v1alpha1", "Function", "payments", "payments-uid", "", map[string]interface{}{"type": "git", "source": "shared-repo"})
+	otherFunction := newObject("serverless.kyma-project.io/v1alpha1", "Function", "invoices", "invoices-uid", "", map[string]interface{}{"type": "git", "source": "shared-repo"})
+	inlineFunction := newObject("serverless.kyma-project.io/v1alpha1", "Function", "inline", "inline-uid", "", map[string]interface{}{"source": "module.exports = {}"})
+	foreignFunction := newObject("serverless.kyma-project.io/v1alpha1", "Function", "legacy", "legacy-uid", "", map[string]interface{}{"type": "git", "source": "legacy-repo"})
+	createdByCLI := func(obj *unstructured.Unstructured) *unstructured.Unstructured {
+		obj.SetLabels(map[string]string{serverless.CreatedByLabel: serverless.CreatedByCLI})
+		return obj
+	}
+
+	scheme := runtime.NewScheme()
+	for _, gvk := range []schema.GroupVersionKind{
+		{Group: "serverless.kyma-project.io", Version: "v1alpha1", Kind: "FunctionList"},
+		{Group: "serverless.kyma-project.io", Version: "v1alpha1", Kind: "GitRepositoryList"},
+		{Group: "gateway.kyma-project.io", Version: "v1alpha1", Kind: "APIRuleList"},
+		{Group: "eventing.kyma-project.io", Version: "v1alpha1", Kind: "SubscriptionList"},
+	} {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.UnstructuredList{})
+	}
+	dyn := dynFake.NewSimpleDynamicClient(scheme,
+		gitFunction, sharedFunction, otherFunction, inlineFunction, foreignFunction,
+		createdByCLI(newObject("serverless.kyma-project.io/v1alpha1", "GitRepository", "orders-repo", "repo-uid", "", nil)),
+		createdByCLI(newObject("serverless.kyma-project.io/v1alpha1", "GitRepository", "shared-repo", "shared-uid", "", nil)),
+		newObject("serverless.kyma-project.io/v1alpha1", "GitRepository", "legacy-repo", "legacy-repo-uid", "", nil),
+		newObject("gateway.kyma-project.io/v1alpha1", "APIRule", "orders", "rule-uid", "orders-uid", nil),
+		newObject("gateway.kyma-project.io/v1alpha1", "APIRule", "payments", "other-rule-uid", "payments-uid", nil),
+		newObject("eventing.kyma-project.io/v1alpha1", "Subscription", "orders-created", "sub-uid", "orders-uid", nil),
+	)
+	c := command{opts: &Options{Namespace: "default"}}
+
+	names := func(groups []resourceGroup) []string {
+		var result []string
+		for _, group := range groups {
+			for _, item := range group.items {
+				result = append(result, item.GetKind()+"/"+item.GetName())
+			}
+		}
+		return result
+	}
+
+	groups, note, err := c.resources(context.Background(), dyn, "orders")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Subscription/orders-created", "APIRule/orders", "Function/orders", "GitRepository/orders-repo"}, names(groups))
+	require.Empty(t, note)
+
+	// the GitRepository is kept if other Functions use it
+	groups, _, err = c.resources(context.Background(), dyn, "payments")
+	require.NoError(t, err)
+	require.Equal(t, []string{"APIRule/payments", "Function/payments"}, names(groups))
+
+	// the GitRepository is kept if Kyma CLI didn't create it
+	groups, note, err = c.resources(context.Background(), dyn, "legacy")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Function/legacy"}, names(groups))
+	require.Contains(t, note, "legacy-repo")
+
+	groups, _, err = c.resources(context.Background(), dyn, "inline")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Function/inline"}, names(groups))
+
+	c.opts.KeepGitRepository = true
+	groups, _, err = c.resources(context.Background(), dyn, "orders")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Subscription/orders-created", "APIRule/orders", "Function/orders"}, names(groups))
+
+	_, _, err = c.resources(context.Background(), dyn, "missing")
+	require.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package deprovision
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/avast/retry-go"
+	hf "github.com/kyma-incubator/hydroform/provision"
+	"github.com/kyma-incubator/hydroform/provision/types"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/clusters"
+	"github.com/kyma-project/cli/internal/files"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new deprovision command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "deprovision <cluster-name>",
+		Short: "Removes a cluster provisioned by Kyma CLI.",
+		Long: `Use this command to remove a cluster that you provisioned with the ` + "`kyma provision`" + ` commands.
+The command uses the cluster state stored in the Kyma CLI home directory, removes the cluster from the cloud provider, and deletes the cluster's entries from your kubeconfig.
+To see the clusters you can deprovision, run ` + "`kyma cluster list`" + `.`,
+		RunE: func(_ *cobra.Command, args []string) error { return c.Run(args[0]) },
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing name of the cluster")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.ProviderType, "provider", "", "Type of the cluster provider (gcp, azure, or gardener). Only required if several clusters have the same name.")
+	cmd.Flags().StringVarP(&o.Project, "project", "p", "", "Name of the project (or resource group) of the cluster. Only required if several clusters have the same name.")
+	cmd.Flags().UintVar(&o.Attempts, "attempts", 3, "Maximum number of attempts to deprovision the cluster.")
+
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(name string) error {
+	s := c.NewStep(fmt.Sprintf("Looking up cluster '%s'", name))
+	home, err := files.KymaHome()
+	if err != nil {
+		s.Failure()
+		return err
+	}
+
+	record, err := clusters.Find(home, name, types.ProviderType(c.opts.ProviderType), c.opts.Project)
+	if err != nil {
+		s.Failure()
+		return err
+	}
+	if !record.Deprovisionable() {
+		s.Failure()
+		return fmt.Errorf("Cluster '%s' was provisioned by an older version of Kyma CLI and its settings are unknown. Please remove it using the tools of your cloud provider", name)
+	}
+	s.Successf("Found %s cluster '%s' in project '%s'", record.ProviderName, name, record.Project())
+
+	if !c.avoidUserInteraction() {
+		if !c.CurrentStep.PromptYesNo(fmt.Sprintf("Do you really want to deprovision the %s cluster '%s'? ", record.ProviderName, name)) {
+			return fmt.Errorf("Aborting deprovisioning")
+		}
+	}
+
+	if !c.opts.Verbose {
+		// discard all the noise from terraform logs if not verbose
+		log.SetOutput(ioutil.Discard)
+	}
+
+	s = c.NewStep(fmt.Sprintf("Deprovisioning %s cluster", record.ProviderName))
+	err = retry.Do(
+		func() error {
+			return hf.Deprovision(record.Cluster, record.Provider, types.WithDataDir(home), types.Persistent(), types.Verbose(c.opts.Verbose))
+		},
+		retry.Attempts(c.opts.Attempts), retry.LastErrorOnly(!c.opts.Verbose))
+	if err != nil {
+		s.Failure()
+		return err
+	}
+	s.Success()
+
+	s = c.NewStep("Removing kubeconfig")
+	kubeconfig, err := record.Kubeconfig()
+	switch {
+	case os.IsNotExist(err):
+		s.LogInfof("No kubeconfig stored for cluster '%s'", name)
+	case err != nil:
+		s.Failure()
+		return err
+	default:
+		if err := kube.RemoveConfig(kubeconfig, c.KubeconfigPath); err != nil {
+			s.Failure()
+			return err
+		}
+	}
+
+	if err := record.Remove(); err != nil {
+		s.Failure()
+		return errors.Wrap(err, "Could not remove the local cluster data")
+	}
+	s.Success()
+
+	fmt.Printf("\n%s cluster '%s' deprovisioned\n", record.ProviderName, name)
+	return nil
+}
+
+func (c *command) avoidUserInteraction() bool {
+	return c.opts.NonInteractive || c.opts.CI
+}
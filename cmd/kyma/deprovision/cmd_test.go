@@ -0,0 +1,39 @@
+package deprovision
+
+import (
+	"testing"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeprovisionFlags ensures that the provided command flags are stored in the options.
+func TestDeprovisionFlags(t *testing.T) {
+	t.Parallel()
+	o := NewOptions(&cli.Options{})
+	c := NewCmd(o)
+
+	// test default flag values
+	require.Equal(t, "", o.ProviderType, "Default value for the provider flag not as expected.")
+	require.Equal(t, "", o.Project, "Default value for the project flag not as expected.")
+	require.Equal(t, uint(3), o.Attempts, "Default value for the attempts flag not as expected.")
+
+	// test passing flags
+	err := c.ParseFlags([]string{
+		"--provider", "gardener",
+		"-p", "my-project",
+		"--attempts", "1",
+	})
+	require.NoError(t, err, "Parsing flags should not return an error")
+	require.Equal(t, "gardener", o.ProviderType, "The parsed value for the provider flag not as expected.")
+	require.Equal(t, "my-project", o.Project, "The parsed value for the project flag not as expected.")
+	require.Equal(t, uint(1), o.Attempts, "The parsed value for the attempts flag not as expected.")
+}
+
+func TestDeprovisionArgs(t *testing.T) {
+	t.Parallel()
+	c := NewCmd(NewOptions(&cli.Options{}))
+
+	require.Error(t, c.Args(c, []string{}), "Missing cluster name must fail")
+	require.NoError(t, c.Args(c, []string{"my-cluster"}))
+}
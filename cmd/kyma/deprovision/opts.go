@@ -0,0 +1,17 @@
+package deprovision
+
+import "github.com/kyma-project/cli/internal/cli"
+
+//Options defines available options for the command
+type Options struct {
+	*cli.Options
+
+	ProviderType string
+	Project      string
+	Attempts     uint
+}
+
+//NewOptions creates options with default values
+func NewOptions(o *cli.Options) *Options {
+	return &Options{Options: o}
+}
@@ -1,11 +1,17 @@
 package function
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
 	"github.com/kyma-project/cli/internal/cli"
 	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/kyma-project/cli/internal/serverless"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -31,7 +37,8 @@ func NewCmd(o *Options) *cobra.Command {
 		Use:   "function",
 		Short: "Creates local resources for your Function.",
 		Long: `Use this command to create the local workspace with the default structure of your Function's code and dependencies. Update this configuration to your references and apply it to a Kyma cluster. 
-Use the flags to specify the initial configuration for your Function or to choose the location for your project.`,
+Use the flags to specify the initial configuration for your Function or to choose the location for your project.
+Use the "--template" flag to scaffold the Function from a built-in template, a local folder, or a Git repository. Run the command with the "--list-templates" flag to list the built-in templates.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return c.Run()
 		},
@@ -52,10 +59,33 @@ Use the flags to specify the initial configuration for your Function or to choos
 	cmd.Flags().StringVar(&o.Reference, "reference", defaultReference, `Commit hash or branch name`)
 	cmd.Flags().StringVar(&o.BaseDir, "base-dir", defaultBaseDir, `A directory in the repository containing the Function's sources`)
 
+	cmd.Flags().StringVar(&o.Template, "template", "", `Scaffolds the Function from a template. Use the name of a built-in template, the path to a folder, or the URL of a Git repository, optionally followed by "#" and a branch or tag. The files of the template ending with ".tmpl" are rendered with the name, Namespace, and runtime of the Function.`)
+	cmd.Flags().BoolVar(&o.ListTemplates, "list-templates", false, `Lists the built-in templates instead of creating the project.`)
+	cmd.Flags().BoolVar(&o.Schema, "schema", false, `Prints the JSON Schema of the config file instead of creating the project. Use the schema in your IDE for completion and validation of the config file.`)
+	output.Enable(cmd, &o.OutputFormat)
+
 	return cmd
 }
 
 func (c *command) Run() error {
+	if c.opts.Schema {
+		schema, err := serverless.Schema()
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(schema))
+		return nil
+	}
+	if c.opts.ListTemplates {
+		return c.PrintResult(templates(serverless.Templates()))
+	}
+	if c.StructuredOutput() {
+		return errors.New("The --output flag can only be used with the --list-templates flag")
+	}
+	if c.opts.Template != "" && c.opts.URL != "" {
+		return errors.New("The --template flag can't be used for Git Functions. Use it without the --url flag")
+	}
+
 	s := c.NewStep("Generating project structure")
 
 	var err error
@@ -75,6 +105,18 @@ func (c *command) Run() error {
 		}
 	}
 
+	var template serverless.Template
+	if c.opts.Template != "" {
+		if template, err = serverless.LoadTemplate(c.Context(), c.opts.Template); err != nil {
+			s.Failure()
+			return err
+		}
+		if err := template.CheckRuntime(c.opts.Runtime); err != nil {
+			s.Failure()
+			return err
+		}
+	}
+
 	configuration := workspace.Cfg{
 		Runtime:   c.opts.Runtime,
 		Name:      c.opts.Name,
@@ -87,6 +129,51 @@ func (c *command) Run() error {
 		s.Failure()
 		return err
 	}
-	s.Successf("Project generated in %s", c.opts.Dir)
+	if c.opts.Template == "" {
+		s.Successf("Project generated in %s", c.opts.Dir)
+		return nil
+	}
+
+	if err := c.renderTemplate(template); err != nil {
+		s.Failure()
+		return err
+	}
+	s.Successf("Project generated in %s from template '%s'", c.opts.Dir, template.Name)
 	return nil
 }
+
+func (c *command) renderTemplate(template serverless.Template) error {
+	params := serverless.TemplateParams{
+		Name:      c.opts.Name,
+		Namespace: c.opts.Namespace,
+		Runtime:   c.opts.Runtime,
+	}
+	if err := template.Render(c.opts.Dir, params); err != nil {
+		return err
+	}
+
+	// templates from folders and Git repositories can contain an invalid configuration
+	filename := filepath.Join(c.opts.Dir, workspace.CfgFilename)
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	_, err = serverless.ValidateConfigFile(filename, content)
+	return err
+}
+
+//templates is the list of built-in templates printed with the "--list-templates" flag
+type templates []serverless.Template
+
+//TableRows returns the templates as table rows
+func (t templates) TableRows(wide bool) ([]string, [][]string) {
+	rows := make([][]string, 0, len(t))
+	for _, template := range t {
+		runtimes := "all"
+		if len(template.Runtimes) > 0 {
+			runtimes = strings.Join(template.Runtimes, ", ")
+		}
+		rows = append(rows, []string{template.Name, runtimes, template.Description})
+	}
+	return []string{"NAME", "RUNTIMES", "DESCRIPTION"}, rows
+}
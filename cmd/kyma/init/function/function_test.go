@@ -1,9 +1,13 @@
 package function
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/kyma-project/cli/internal/serverless"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,6 +26,9 @@ func TestFunctionFlags(t *testing.T) {
 	require.Equal(t, "", o.RepositoryName, "The parsed value for the --repository-name flag not as expected.")
 	require.Equal(t, "main", o.Reference, "The parsed value for the --reference flag not as expected.")
 	require.Equal(t, "/", o.BaseDir, "The parsed value for the --base-dir flag not as expected.")
+	require.Equal(t, false, o.Schema, "Default value for the --schema flag not as expected.")
+	require.Equal(t, "", o.Template, "Default value for the --template flag not as expected.")
+	require.Equal(t, false, o.ListTemplates, "Default value for the --list-templates flag not as expected.")
 
 	// test passing flags
 	err := c.ParseFlags([]string{
@@ -33,10 +40,16 @@ func TestFunctionFlags(t *testing.T) {
 		"--repository-name", "test-repository-name",
 		"--reference", "test-reference",
 		"--base-dir", "test-base-dir",
+		"--schema",
+		"--template", "event",
+		"--list-templates",
 	})
 	require.NoError(t, err, "Parsing flags should not return an error")
 	require.Equal(t, "/fakepath", o.Dir, "The parsed value for the --dir flag not as expected.")
 	require.Equal(t, "test-name", o.Name, "The parsed value for the --name flag not as expected.")
+	require.Equal(t, true, o.Schema, "The parsed value for the --schema flag not as expected.")
+	require.Equal(t, "event", o.Template, "The parsed value for the --template flag not as expected.")
+	require.Equal(t, true, o.ListTemplates, "The parsed value for the --list-templates flag not as expected.")
 	require.Equal(t, "test-namespace", o.Namespace, "The parsed value for the --namespace flag not as expected.")
 	require.Equal(t, "python38", o.Runtime, "The parsed value for the --runtime flag not as expected.")
 	require.Equal(t, "test-url", o.URL, "The parsed value for the --url flag not as expected.")
@@ -58,3 +71,15 @@ func TestFunctionFlags(t *testing.T) {
 	require.Equal(t, "test-reference", o.Reference, "The parsed value for the --reference flag not as expected.")
 	require.Equal(t, "test-base-dir", o.BaseDir, "The parsed value for the --base-dir flag not as expected.")
 }
+
+func TestListTemplates(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	require.NoError(t, output.Print(&out, output.Default, templates(serverless.Templates())))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, len(serverless.Templates())+1)
+	require.Contains(t, lines[0], "NAME")
+	require.Contains(t, out.String(), "http")
+	require.Contains(t, out.String(), "nodejs12, nodejs14")
+}
@@ -21,6 +21,9 @@ type Options struct {
 	Reference      string
 	BaseDir        string
 	SourcePath     string
+	Schema         bool
+	Template       string
+	ListTemplates  bool
 }
 
 //NewOptions creates options with default values
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new kubeconfig export command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export [CONTEXT]",
+		Short: "Exports a context of the kubeconfig as a standalone kubeconfig.",
+		Long: `Use this command to write a kubeconfig which only contains the given context, or the current context if you don't provide one.
+Certificates and tokens which the context references as files are embedded, so that you can share the kubeconfig, for example with a CI system. The kubeconfig is printed to the standard output unless you use the "--file" flag.
+WARNING: The exported kubeconfig contains the credentials of the context. Store it as securely as the original kubeconfig.`,
+		Example: `  kyma kubeconfig export my-cluster --file ci-kubeconfig.yaml`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    func(_ *cobra.Command, args []string) error { return c.Run(args) },
+	}
+
+	cmd.Flags().StringVarP(&o.File, "file", "f", "", "Path of the file to which the kubeconfig is written.")
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(args []string) error {
+	name, err := c.contextName(args)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := kube.ExportContext(c.opts.KubeconfigPath, name)
+	if err != nil {
+		return err
+	}
+	data, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return errors.Wrapf(err, "Could not export context '%s'", name)
+	}
+
+	if c.opts.File == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	// the kubeconfig contains credentials, so only the owner can read it
+	if err := ioutil.WriteFile(c.opts.File, data, 0600); err != nil {
+		return errors.Wrapf(err, "Could not write the kubeconfig to '%s'", c.opts.File)
+	}
+	fmt.Fprintf(os.Stderr, "Context '%s' exported to '%s'\n", name, c.opts.File)
+	return nil
+}
+
+//contextName returns the context given as argument, or the current context of the kubeconfig
+func (c *command) contextName(args []string) (string, error) {
+	if len(args) == 1 {
+		return args[0], nil
+	}
+	contexts, err := kube.ListContexts(c.opts.KubeconfigPath)
+	if err != nil {
+		return "", err
+	}
+	for _, ctx := range contexts {
+		if ctx.Current {
+			return ctx.Name, nil
+		}
+	}
+	return "", errors.New("The kubeconfig has no current context. Please provide the name of the context to export")
+}
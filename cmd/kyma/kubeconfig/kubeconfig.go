@@ -0,0 +1,30 @@
+package kubeconfig
+
+import (
+	"github.com/kyma-project/cli/cmd/kyma/kubeconfig/export"
+	"github.com/kyma-project/cli/cmd/kyma/kubeconfig/list"
+	"github.com/kyma-project/cli/cmd/kyma/kubeconfig/remove"
+	"github.com/kyma-project/cli/cmd/kyma/kubeconfig/rename"
+	"github.com/kyma-project/cli/cmd/kyma/kubeconfig/use"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new kubeconfig command
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kubeconfig",
+		Short: "Manages the kubeconfig contexts of the clusters created by Kyma CLI.",
+		Long: `Use this command to manage the contexts which Kyma CLI adds to your kubeconfig when it provisions a cluster.
+Kyma CLI tags these contexts with the provider and the name of the cluster, so that you can find them among the contexts created by other tools. To keep the current context when you provision a cluster, use the "--no-switch-context" flag of the provisioning commands.`,
+	}
+
+	cmd.AddCommand(
+		list.NewCmd(list.NewOptions(o)),
+		use.NewCmd(use.NewOptions(o)),
+		rename.NewCmd(rename.NewOptions(o)),
+		remove.NewCmd(remove.NewOptions(o)),
+		export.NewCmd(export.NewOptions(o)),
+	)
+	return cmd
+}
@@ -0,0 +1,95 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new kubeconfig list command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the kubeconfig contexts of the clusters created by Kyma CLI.",
+		Long: `Use this command to list the contexts of your kubeconfig which Kyma CLI added for the clusters it created, together with the provider and the name of each cluster.
+Use the "--all" flag to list the contexts created by other tools as well. The current context is marked with an asterisk.`,
+		RunE:    func(_ *cobra.Command, _ []string) error { return c.Run() },
+		Aliases: []string{"l"},
+	}
+
+	cmd.Flags().BoolVarP(&o.All, "all", "A", false, "Lists all contexts of the kubeconfig, not only the ones created by Kyma CLI.")
+	output.Enable(cmd, &o.OutputFormat)
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run() error {
+	contexts, err := kube.ListContexts(c.opts.KubeconfigPath)
+	if err != nil {
+		return err
+	}
+
+	result := contextList{}
+	for _, ctx := range contexts {
+		if ctx.Tag != nil || c.opts.All {
+			result = append(result, ctx)
+		}
+	}
+
+	if len(result) == 0 && !c.StructuredOutput() {
+		if c.opts.All {
+			fmt.Println("No contexts found")
+		} else {
+			fmt.Println("No contexts created by Kyma CLI found. To list all contexts, use the \"--all\" flag.")
+		}
+		return nil
+	}
+	return c.PrintResult(result)
+}
+
+//contextList is the result of the command
+type contextList []kube.Context
+
+//TableRows returns the contexts as table rows. The wide table adds the cluster entry and the user of the contexts.
+func (l contextList) TableRows(wide bool) ([]string, [][]string) {
+	header := []string{"CURRENT", "NAME", "PROVIDER", "CLUSTER", "NAMESPACE"}
+	if wide {
+		header = append(header, "KUBECONFIG CLUSTER", "USER")
+	}
+	rows := make([][]string, 0, len(l))
+	for _, ctx := range l {
+		current, provider, cluster := "", "-", "-"
+		if ctx.Current {
+			current = "*"
+		}
+		if ctx.Tag != nil {
+			provider, cluster = string(ctx.Tag.Provider), ctx.Tag.Cluster
+		}
+		row := []string{current, ctx.Name, provider, cluster, valueOrDash(ctx.Namespace)}
+		if wide {
+			row = append(row, ctx.Cluster, ctx.User)
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+func valueOrDash(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
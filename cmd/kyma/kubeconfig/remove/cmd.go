@@ -0,0 +1,56 @@
+package remove
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new kubeconfig remove command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "remove CONTEXT",
+		Short: "Removes a context created by Kyma CLI from the kubeconfig.",
+		Long: `Use this command to remove a context from your kubeconfig, together with its cluster and user entries if no other context refers to them. The cluster itself is not deleted. To delete a cluster provisioned by Kyma CLI, run "kyma deprovision".
+To protect the contexts created by other tools, the command only removes contexts created by Kyma CLI unless you use the "--force" flag.`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    func(_ *cobra.Command, args []string) error { return c.Run(args[0]) },
+		Aliases: []string{"rm"},
+	}
+
+	cmd.Flags().BoolVar(&o.Force, "force", false, "Removes the context even if it wasn't created by Kyma CLI.")
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(name string) error {
+	if !c.opts.Force {
+		contexts, err := kube.ListContexts(c.opts.KubeconfigPath)
+		if err != nil {
+			return err
+		}
+		for _, ctx := range contexts {
+			if ctx.Name == name && ctx.Tag == nil {
+				return fmt.Errorf("Context '%s' wasn't created by Kyma CLI. To remove it anyway, use the \"--force\" flag", name)
+			}
+		}
+	}
+
+	if err := kube.RemoveContext(c.opts.KubeconfigPath, name); err != nil {
+		return err
+	}
+	fmt.Printf("Context '%s' removed\n", name)
+	return nil
+}
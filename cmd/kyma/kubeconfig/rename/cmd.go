@@ -0,0 +1,41 @@
+package rename
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new kubeconfig rename command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rename CONTEXT NEW_NAME",
+		Short: "Renames a context of the kubeconfig.",
+		Long: `Use this command to give a context of your kubeconfig a name which is easier to remember than the one generated by the provider.
+The context keeps its Kyma CLI tag, so "kyma kubeconfig list" and "kyma deprovision" still find it.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error { return c.Run(args[0], args[1]) },
+	}
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(name, newName string) error {
+	if err := kube.RenameContext(c.opts.KubeconfigPath, name, newName); err != nil {
+		return err
+	}
+	fmt.Printf("Context '%s' renamed to '%s'\n", name, newName)
+	return nil
+}
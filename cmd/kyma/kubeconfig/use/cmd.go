@@ -0,0 +1,41 @@
+package use
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new kubeconfig use command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "use CONTEXT",
+		Short: "Switches the current context of the kubeconfig.",
+		Long: `Use this command to switch the current context of your kubeconfig, for example to a cluster which you provisioned with the "--no-switch-context" flag.
+To find the name of the context, run "kyma kubeconfig list".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error { return c.Run(args[0]) },
+	}
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(name string) error {
+	if err := kube.UseContext(c.opts.KubeconfigPath, name); err != nil {
+		return err
+	}
+	fmt.Printf("Switched to context '%s'\n", name)
+	return nil
+}
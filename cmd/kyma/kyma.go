@@ -1,18 +1,36 @@
 package kyma
 
 import (
+	"fmt"
+
 	"github.com/kyma-project/cli/cmd/kyma/alpha"
 	alphaDelete "github.com/kyma-project/cli/cmd/kyma/alpha/delete"
 	alphaInstall "github.com/kyma-project/cli/cmd/kyma/alpha/deploy"
+	alphaK3s "github.com/kyma-project/cli/cmd/kyma/alpha/k3s"
+	alphaK3sDelete "github.com/kyma-project/cli/cmd/kyma/alpha/k3s/delete"
+	alphaK3sList "github.com/kyma-project/cli/cmd/kyma/alpha/k3s/list"
+	alphaK3sStart "github.com/kyma-project/cli/cmd/kyma/alpha/k3s/start"
+	alphaK3sStatus "github.com/kyma-project/cli/cmd/kyma/alpha/k3s/status"
+	alphaK3sStop "github.com/kyma-project/cli/cmd/kyma/alpha/k3s/stop"
 	alphaProvision "github.com/kyma-project/cli/cmd/kyma/alpha/provision"
 	"github.com/kyma-project/cli/cmd/kyma/alpha/provision/k3s"
 	alphaVersion "github.com/kyma-project/cli/cmd/kyma/alpha/version"
 	"github.com/kyma-project/cli/cmd/kyma/apply"
+	"github.com/kyma-project/cli/cmd/kyma/build"
+	"github.com/kyma-project/cli/cmd/kyma/call"
+	"github.com/kyma-project/cli/cmd/kyma/cluster"
+	clusterlist "github.com/kyma-project/cli/cmd/kyma/cluster/list"
 	"github.com/kyma-project/cli/cmd/kyma/completion"
+	"github.com/kyma-project/cli/cmd/kyma/config"
 	"github.com/kyma-project/cli/cmd/kyma/console"
 	"github.com/kyma-project/cli/cmd/kyma/create"
+	"github.com/kyma-project/cli/cmd/kyma/delete"
+	"github.com/kyma-project/cli/cmd/kyma/deprovision"
 	initial "github.com/kyma-project/cli/cmd/kyma/init"
 	"github.com/kyma-project/cli/cmd/kyma/install"
+	"github.com/kyma-project/cli/cmd/kyma/kubeconfig"
+	"github.com/kyma-project/cli/cmd/kyma/logs"
+	"github.com/kyma-project/cli/cmd/kyma/plugin"
 	"github.com/kyma-project/cli/cmd/kyma/provision/aks"
 	"github.com/kyma-project/cli/cmd/kyma/provision/gardener"
 	"github.com/kyma-project/cli/cmd/kyma/provision/gardener/aws"
@@ -21,10 +39,12 @@ import (
 	"github.com/kyma-project/cli/cmd/kyma/provision/gke"
 	"github.com/kyma-project/cli/cmd/kyma/provision/minikube"
 	"github.com/kyma-project/cli/cmd/kyma/run"
+	"github.com/kyma-project/cli/cmd/kyma/status"
 	"github.com/kyma-project/cli/cmd/kyma/sync"
 	"github.com/kyma-project/cli/cmd/kyma/test"
 	testdefs "github.com/kyma-project/cli/cmd/kyma/test/definitions"
 	testdel "github.com/kyma-project/cli/cmd/kyma/test/delete"
+	testfunction "github.com/kyma-project/cli/cmd/kyma/test/function"
 	testlist "github.com/kyma-project/cli/cmd/kyma/test/list"
 	testlogs "github.com/kyma-project/cli/cmd/kyma/test/logs"
 	testrun "github.com/kyma-project/cli/cmd/kyma/test/run"
@@ -33,7 +53,11 @@ import (
 
 	"github.com/kyma-project/cli/cmd/kyma/provision"
 	"github.com/kyma-project/cli/cmd/kyma/upgrade"
+	"github.com/kyma-project/cli/cmd/kyma/validate"
 	"github.com/kyma-project/cli/internal/cli"
+	cliconfig "github.com/kyma-project/cli/internal/config"
+	"github.com/kyma-project/cli/internal/files"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -49,6 +73,13 @@ Kyma CLI allows you to install, test, and manage Kyma.
 		// Affects children as well
 		SilenceErrors: false,
 		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := applyConfig(cmd); err != nil {
+				return err
+			}
+			o.Finalizers.OnInterrupt(fmt.Sprintf("'%s'", cmd.CommandPath()), "")
+			return o.SetupOutput(cmd)
+		},
 	}
 
 	cmd.PersistentFlags().BoolVarP(&o.Verbose, "verbose", "v", false, "Displays details of actions triggered by the command.")
@@ -56,6 +87,11 @@ Kyma CLI allows you to install, test, and manage Kyma.
 	cmd.PersistentFlags().BoolVar(&o.CI, "ci", false, "Enables the CI mode to run on CI/CD systems. It avoids any user interaction (such as no dialog prompts) and ensures that logs are formatted properly in log files (such as no spinners for CLI steps).")
 	// Kubeconfig env var and default paths are resolved by the kyma k8s client using the k8s defined resolution strategy.
 	cmd.PersistentFlags().StringVar(&o.KubeconfigPath, "kubeconfig", "", `Path to the kubeconfig file. If undefined, Kyma CLI uses the KUBECONFIG environment variable, or falls back "/$HOME/.kube/config".`)
+	if o.Finalizers == nil {
+		// options without signal handling, the grace period has no effect
+		o.Finalizers = &cli.Finalizers{}
+	}
+	cmd.PersistentFlags().DurationVar(&o.Finalizers.GracePeriod, "grace-period", cli.DefaultGracePeriod, "Time to wait for the cleanup of a command interrupted with Ctrl+C before Kyma CLI exits.")
 	cmd.PersistentFlags().BoolP("help", "h", false, "Command help")
 
 	//Alpha commands
@@ -68,6 +104,16 @@ Kyma CLI allows you to install, test, and manage Kyma.
 	alphaProvisionCmd.AddCommand(k3s.NewCmd(k3s.NewOptions(o)))
 	alphaCmd.AddCommand(alphaProvisionCmd)
 
+	alphaK3sCmd := alphaK3s.NewCmd()
+	alphaK3sCmd.AddCommand(
+		alphaK3sList.NewCmd(alphaK3sList.NewOptions(o)),
+		alphaK3sStatus.NewCmd(alphaK3sStatus.NewOptions(o)),
+		alphaK3sStart.NewCmd(alphaK3sStart.NewOptions(o)),
+		alphaK3sStop.NewCmd(alphaK3sStop.NewOptions(o)),
+		alphaK3sDelete.NewCmd(alphaK3sDelete.NewOptions(o)),
+	)
+	alphaCmd.AddCommand(alphaK3sCmd)
+
 	//Stable commands
 	provisionCmd := provision.NewCmd()
 	provisionCmd.AddCommand(minikube.NewCmd(minikube.NewOptions(o)))
@@ -79,15 +125,23 @@ Kyma CLI allows you to install, test, and manage Kyma.
 	gardenerCmd.AddCommand(aws.NewCmd(aws.NewOptions(o)))
 	provisionCmd.AddCommand(gardenerCmd)
 
+	clusterCmd := cluster.NewCmd()
+	clusterCmd.AddCommand(clusterlist.NewCmd(clusterlist.NewOptions(o)))
+
 	cmd.AddCommand(
 		alphaCmd,
 		version.NewCmd(version.NewOptions(o)),
 		completion.NewCmd(),
 		install.NewCmd(install.NewOptions(o)),
 		provisionCmd,
+		deprovision.NewCmd(deprovision.NewOptions(o)),
+		clusterCmd,
+		kubeconfig.NewCmd(o),
 		console.NewCmd(console.NewOptions(o)),
 		upgrade.NewCmd(upgrade.NewOptions(o)),
 		create.NewCmd(o),
+		config.NewCmd(o),
+		plugin.NewCmd(o),
 	)
 
 	testCmd := test.NewCmd()
@@ -97,7 +151,8 @@ Kyma CLI allows you to install, test, and manage Kyma.
 	testListCmd := testlist.NewCmd(testlist.NewOptions(o))
 	testDefsCmd := testdefs.NewCmd(testdefs.NewOptions(o))
 	testLogsCmd := testlogs.NewCmd(testlogs.NewOptions(o))
-	testCmd.AddCommand(testRunCmd, testStatusCmd, testDeleteCmd, testListCmd, testDefsCmd, testLogsCmd)
+	testFunctionCmd := testfunction.NewCmd(testfunction.NewOptions(o))
+	testCmd.AddCommand(testRunCmd, testStatusCmd, testDeleteCmd, testListCmd, testDefsCmd, testLogsCmd, testFunctionCmd)
 	cmd.AddCommand(testCmd)
 
 	cmd.AddCommand(
@@ -105,7 +160,26 @@ Kyma CLI allows you to install, test, and manage Kyma.
 		apply.NewCmd(o),
 		sync.NewCmd(o),
 		run.NewCmd(o),
+		status.NewCmd(o),
+		logs.NewCmd(o),
+		call.NewCmd(o),
+		delete.NewCmd(o),
+		validate.NewCmd(o),
+		build.NewCmd(o),
 	)
 
 	return cmd
 }
+
+//applyConfig sets the flags which were not set on the command line to the defaults of the environment and the active profile of the config file
+func applyConfig(cmd *cobra.Command) error {
+	home, err := files.KymaHome()
+	if err != nil {
+		return errors.Wrap(err, "Could not find the Kyma CLI home directory to read the config file")
+	}
+	cfg, err := cliconfig.Load(home)
+	if err != nil {
+		return err
+	}
+	return cliconfig.Apply(cmd, cfg.Profile(cfg.ActiveProfile()))
+}
@@ -2,9 +2,11 @@ package kyma
 
 import (
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/kyma-project/cli/internal/cli"
+	cliconfig "github.com/kyma-project/cli/internal/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,5 +40,24 @@ func TestKymaSubcommands(t *testing.T) {
 
 	sub := c.Commands()
 
-	require.Equal(t, 14, len(sub), "Number of Kyma subcommands not as expected")
+	require.Equal(t, 25, len(sub), "Number of Kyma subcommands not as expected")
+}
+
+// TestKymaEnvConfig ensures that generic environment variables don't set the flags of every command.
+func TestKymaEnvConfig(t *testing.T) {
+	c := NewCmd(&cli.Options{})
+	gke, _, err := c.Find([]string{"provision", "gke"})
+	require.NoError(t, err)
+
+	require.NoError(t, os.Setenv("KYMA_NAME", "unrelated"))
+	defer os.Unsetenv("KYMA_NAME")
+	require.NoError(t, cliconfig.Apply(gke, cliconfig.Profile{}))
+	name, _ := gke.Flags().GetString("name")
+	require.Empty(t, name, "KYMA_NAME must not set the --name flag")
+
+	require.NoError(t, os.Setenv("KYMA_PROVISION_GKE_NAME", "my-cluster"))
+	defer os.Unsetenv("KYMA_PROVISION_GKE_NAME")
+	require.NoError(t, cliconfig.Apply(gke, cliconfig.Profile{}))
+	name, _ = gke.Flags().GetString("name")
+	require.Equal(t, "my-cluster", name, "The environment variable of the command path must set the --name flag")
 }
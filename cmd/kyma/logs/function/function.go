@@ -0,0 +1,182 @@
+package function
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new logs function command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		opts:    o,
+		Command: cli.Command{Options: o.Options},
+	}
+	cmd := &cobra.Command{
+		Use:   "function <name>",
+		Short: "Shows the logs of a Function.",
+		Long: `Use this command to print the logs of the Pods running the given Function.
+Use the "--build" flag to print the logs of the Pod that builds the Function's image instead. If the Function has more than one Pod, each line is prefixed with the name of the Pod.
+With the "--output" flag, each line is printed as a separate result with the name of the Pod and the message, so that the logs can be processed while they are streamed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.Run(args[0])
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing name of the function")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", `Namespace of the Function.`)
+	cmd.Flags().BoolVarP(&o.Follow, "follow", "f", false, `Streams the logs until the command is stopped.`)
+	cmd.Flags().BoolVar(&o.Build, "build", false, `Prints the logs of the latest build of the Function instead of the logs of its runtime Pods.`)
+	output.Enable(cmd, &o.OutputFormat, output.JSON, output.None, output.JSONPath, output.Template)
+
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(name string) error {
+	var err error
+	if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
+		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
+	}
+	c.opts.setDefaults(c.K8s.DefaultNamespace())
+
+	ctx := c.Context()
+	if _, err := serverless.GetStatus(ctx, c.K8s.Dynamic(), c.opts.Namespace, name); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("Function '%s' not found in Namespace '%s'", name, c.opts.Namespace)
+		}
+		return errors.Wrapf(err, "Could not get Function '%s'", name)
+	}
+
+	pods, err := c.pods(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 1 && !c.StructuredOutput() {
+		return serverless.WriteLogs(ctx, c.K8s.Static(), pods[0], c.opts.Follow, os.Stdout)
+	}
+
+	// the logs of several Pods are streamed concurrently, otherwise following the first Pod would block the others
+	var mu sync.Mutex
+	errs := make(chan error, len(pods))
+	var wg sync.WaitGroup
+	for _, pod := range pods {
+		wg.Add(1)
+		go func(pod corev1.Pod) {
+			defer wg.Done()
+			w := &prefixWriter{prefix: fmt.Sprintf("[%s] ", pod.Name), pod: pod.Name, format: c.OutputFormat, out: os.Stdout, mu: &mu}
+			err := serverless.WriteLogs(ctx, c.K8s.Static(), pod, c.opts.Follow, w)
+			w.Flush()
+			errs <- err
+		}(pod)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//pods returns the Pods whose logs are printed, which are either the Pod of the latest build or all runtime Pods of the Function
+func (c *command) pods(ctx context.Context, name string) ([]corev1.Pod, error) {
+	if c.opts.Build {
+		pods, err := serverless.ResourcePods(ctx, c.K8s.Static(), c.opts.Namespace, name, serverless.ResourceBuild)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not list the build Pods of Function '%s'", name)
+		}
+		if len(pods) == 0 {
+			return nil, fmt.Errorf("Function '%s' has no build Pods. The build Pods are removed after a successful build", name)
+		}
+		return pods[:1], nil
+	}
+
+	pods, err := serverless.ResourcePods(ctx, c.K8s.Static(), c.opts.Namespace, name, serverless.ResourceDeployment)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not list the Pods of Function '%s'", name)
+	}
+	var result []corev1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil {
+			result = append(result, pod)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("Function '%s' has no running Pods. Use the --build flag to check the logs of its build", name)
+	}
+	return result, nil
+}
+
+//prefixWriter prefixes every line with the given prefix and writes only complete lines,
+//so the lines of concurrent writers sharing the same output are not mixed up.
+//If an output format is set, every line is printed as a logLine in that format instead.
+type prefixWriter struct {
+	prefix string
+	pod    string
+	format output.Format
+	out    io.Writer
+	mu     *sync.Mutex
+	buf    bytes.Buffer
+}
+
+//logLine is a line of the logs printed with the "--output" flag
+type logLine struct {
+	Pod     string `json:"pod"`
+	Message string `json:"message"`
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.buf.Next(i + 1)); err != nil {
+			return len(p), err
+		}
+	}
+}
+
+//Flush writes the remaining incomplete line
+func (w *prefixWriter) Flush() {
+	if w.buf.Len() > 0 {
+		_ = w.writeLine(append(w.buf.Bytes(), '\n'))
+		w.buf.Reset()
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.format != output.Default {
+		return output.Print(w.out, w.format, logLine{Pod: w.pod, Message: string(bytes.TrimSuffix(line, []byte("\n")))})
+	}
+	_, err := fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+	return err
+}
@@ -0,0 +1,60 @@
+package function
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFunctionFlags ensures that the provided command flags are stored in the options.
+func TestFunctionFlags(t *testing.T) {
+	t.Parallel()
+	o := NewOptions(&cli.Options{})
+	c := NewCmd(o)
+
+	// test default flag values
+	require.Empty(t, o.Namespace, "Default value for the --namespace flag not as expected.")
+	require.False(t, o.Follow, "Default value for the --follow flag not as expected.")
+	require.False(t, o.Build, "Default value for the --build flag not as expected.")
+
+	// test passing flags
+	err := c.ParseFlags([]string{
+		"--namespace", "test-namespace",
+		"-f",
+		"--build",
+	})
+
+	require.NoError(t, err, "Parsing flags should not return an error")
+	require.Equal(t, "test-namespace", o.Namespace, "The parsed value for the --namespace flag not as expected.")
+	require.True(t, o.Follow, "The parsed value for the --follow flag not as expected.")
+	require.True(t, o.Build, "The parsed value for the --build flag not as expected.")
+}
+
+func TestPrefixWriter(t *testing.T) {
+	t.Parallel()
+	out := &bytes.Buffer{}
+	w := &prefixWriter{prefix: "[pod] ", out: out, mu: &sync.Mutex{}}
+
+	_, err := w.Write([]byte("first line\nsecond "))
+	require.NoError(t, err)
+	require.Equal(t, "[pod] first line\n", out.String(), "Incomplete lines must not be written")
+
+	_, err = w.Write([]byte("line\nlast"))
+	require.NoError(t, err)
+	w.Flush()
+	require.Equal(t, "[pod] first line\n[pod] second line\n[pod] last\n", out.String())
+}
+
+func TestPrefixWriterOutputFormat(t *testing.T) {
+	t.Parallel()
+	out := &bytes.Buffer{}
+	w := &prefixWriter{prefix: "[pod] ", pod: "pod", format: output.Format("jsonpath={.pod}: {.message}"), out: out, mu: &sync.Mutex{}}
+	_, err := w.Write([]byte("first line\nlast"))
+	require.NoError(t, err)
+	w.Flush()
+	require.Equal(t, "pod: first line\npod: last\n", out.String())
+}
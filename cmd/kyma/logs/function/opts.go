@@ -0,0 +1,26 @@
+package function
+
+import (
+	"github.com/kyma-project/cli/internal/cli"
+)
+
+//Options defines available options for the command
+type Options struct {
+	*cli.Options
+
+	Namespace string
+	Follow    bool
+	Build     bool
+}
+
+//NewOptions creates options with default values
+func NewOptions(o *cli.Options) *Options {
+	options := &Options{Options: o}
+	return options
+}
+
+func (o *Options) setDefaults(defaultNamespace string) {
+	if o.Namespace == "" {
+		o.Namespace = defaultNamespace
+	}
+}
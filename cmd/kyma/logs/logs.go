@@ -0,0 +1,19 @@
+package logs
+
+import (
+	"github.com/kyma-project/cli/cmd/kyma/logs/function"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new logs command
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Shows the logs of resources on the Kyma cluster.",
+		Long:  "Use this command to print the logs of the given resource's Pods. Currently, you can only use it for Functions.",
+	}
+
+	cmd.AddCommand(function.NewCmd(function.NewOptions(o)))
+	return cmd
+}
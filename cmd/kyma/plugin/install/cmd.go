@@ -0,0 +1,66 @@
+package install
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/files"
+	"github.com/kyma-project/cli/internal/plugins"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new plugin install command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "install URL",
+		Short: "Installs a plugin of Kyma CLI.",
+		Long: `Use this command to download the executable of a plugin from an HTTP(S) URL, or to copy it from a local file, into the "plugins" folder of the Kyma CLI home directory.
+By default, the name of the plugin is derived from the file name of the executable, which must start with "kyma-". Use the "--name" flag to set a different name.
+Plugins are only downloaded over plain HTTP if their checksum is set with the "--sha256" flag.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error { return c.Run(cmd.Root(), args[0]) },
+	}
+
+	cmd.Flags().StringVar(&o.Name, "name", "", `Name of the plugin, which is run with "kyma {NAME}". Defaults to the file name of the executable without the "kyma-" prefix.`)
+	cmd.Flags().BoolVar(&o.Force, "force", false, `Replaces the installed plugin with the same name.`)
+	cmd.Flags().StringVar(&o.SHA256, "sha256", "", `SHA-256 checksum of the executable. If it is set, the plugin is only installed if the checksum of the downloaded or copied executable matches.`)
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(root *cobra.Command, source string) error {
+	name := c.opts.Name
+	if name == "" {
+		var err error
+		if name, err = plugins.NameFromSource(source); err != nil {
+			return err
+		}
+	}
+	// plugins are run by their first subcommand, so "kyma-version-check" would be shadowed by "kyma version"
+	if first := plugins.FirstCommand(name); plugins.IsBuiltIn(root, first) {
+		return fmt.Errorf("Plugin '%s' can't be installed, because it would be shadowed by the built-in command 'kyma %s'", name, first)
+	}
+
+	home, err := files.KymaHome()
+	if err != nil {
+		return err
+	}
+	step := c.NewStep(fmt.Sprintf("Installing plugin '%s'", name))
+	path, err := plugins.Install(source, plugins.Dir(home), name, c.opts.SHA256, c.opts.Force)
+	if err != nil {
+		step.Failure()
+		return err
+	}
+	step.Successf("Plugin '%s' installed in '%s'. Run it with: kyma %s", name, path, name)
+	return nil
+}
@@ -0,0 +1,78 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/files"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/kyma-project/cli/internal/plugins"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new plugin list command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the plugins of Kyma CLI.",
+		Long: `Use this command to list the plugins found in the "plugins" folder of the Kyma CLI home directory and in the folders of the PATH.
+A plugin is shadowed and never runs if a built-in command or a plugin found before has the same name.`,
+		RunE:    func(cmd *cobra.Command, _ []string) error { return c.Run(cmd.Root()) },
+		Aliases: []string{"l"},
+	}
+	output.Enable(cmd, &o.OutputFormat)
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(root *cobra.Command) error {
+	home, err := files.KymaHome()
+	if err != nil {
+		return err
+	}
+	result, err := plugins.List(plugins.Dirs(home))
+	if err != nil {
+		return err
+	}
+	plugins.MarkBuiltIns(root, result)
+
+	if len(result) == 0 && !c.StructuredOutput() {
+		fmt.Println("No plugins found")
+		return nil
+	}
+	return c.PrintResult(append(pluginList{}, result...))
+}
+
+//pluginList is the result of the command
+type pluginList []plugins.Plugin
+
+//TableRows returns the plugins as table rows. The wide table contains the plugins which shadow the listed ones.
+func (l pluginList) TableRows(wide bool) ([]string, [][]string) {
+	header := []string{"NAME", "PATH", "STATUS"}
+	if wide {
+		header = append(header, "SHADOWED BY")
+	}
+	rows := make([][]string, 0, len(l))
+	for _, p := range l {
+		status := "active"
+		if p.ShadowedBy != "" {
+			status = "shadowed"
+		}
+		row := []string{p.Name, p.Path, status}
+		if wide {
+			row = append(row, p.ShadowedBy)
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
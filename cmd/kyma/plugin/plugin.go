@@ -0,0 +1,30 @@
+package plugin
+
+import (
+	"github.com/kyma-project/cli/cmd/kyma/plugin/install"
+	"github.com/kyma-project/cli/cmd/kyma/plugin/list"
+	"github.com/kyma-project/cli/cmd/kyma/plugin/remove"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new plugin command
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manages the plugins of Kyma CLI.",
+		Long: `Use this command to manage the plugins which extend Kyma CLI with additional commands.
+A plugin is an executable named "kyma-{NAME}" in the "plugins" folder of the Kyma CLI home directory or in a folder of the PATH. Kyma CLI runs the plugin for "kyma {NAME}" if there is no built-in command with this name, and passes the remaining arguments to it. Dashes in the name of a plugin separate its subcommands, so the "kyma-company-login" plugin runs for "kyma company login".
+The plugins receive the settings of Kyma CLI in these environment variables:
+- KYMA_KUBECONFIG: the path of the kubeconfig. If the "--kubeconfig" flag is set, KUBECONFIG is set as well.
+- KYMA_CI, KYMA_VERBOSE, and KYMA_NON_INTERACTIVE: "true" if the "--ci", "--verbose", or "--non-interactive" flag is set, or "false" otherwise.
+- KYMA_CLUSTER_DOMAIN: the domain of the Kyma cluster, if the cluster is available.`,
+	}
+
+	cmd.AddCommand(
+		list.NewCmd(list.NewOptions(o)),
+		install.NewCmd(install.NewOptions(o)),
+		remove.NewCmd(remove.NewOptions(o)),
+	)
+	return cmd
+}
@@ -0,0 +1,48 @@
+package remove
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/files"
+	"github.com/kyma-project/cli/internal/plugins"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new plugin remove command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		Command: cli.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "remove PLUGIN",
+		Short: "Removes a plugin of Kyma CLI.",
+		Long: `Use this command to remove a plugin from the "plugins" folder of the Kyma CLI home directory.
+Plugins found in the folders of the PATH are not removed. To remove them, delete their executables.`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    func(_ *cobra.Command, args []string) error { return c.Run(args[0]) },
+		Aliases: []string{"rm"},
+	}
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(name string) error {
+	home, err := files.KymaHome()
+	if err != nil {
+		return err
+	}
+	path, err := plugins.Remove(plugins.Dir(home), name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Plugin '%s' removed from '%s'\n", name, path)
+	return nil
+}
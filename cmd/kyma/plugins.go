@@ -0,0 +1,120 @@
+package kyma
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/files"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/plugins"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/spf13/cobra"
+)
+
+// domainTimeout limits the time spent on reading the domain of the cluster before a plugin is run
+const domainTimeout = 2 * time.Second
+
+//RunPlugin runs the plugin for the arguments if they don't start with a built-in command. It returns false if no plugin was run.
+//The flags of Kyma CLI before the name of the plugin are passed to the plugin in environment variables, the other arguments are passed to the plugin.
+func RunPlugin(cmd *cobra.Command, o *cli.Options, args []string) (int, bool) {
+	globalArgs, pluginArgs, ok := splitPluginArgs(cmd, args)
+	if !ok || plugins.IsBuiltIn(cmd, pluginArgs[0]) {
+		return 0, false
+	}
+	home, err := files.KymaHome()
+	if err != nil {
+		return 0, false
+	}
+	plugin, pluginArgs, ok := findPlugin(plugins.Dirs(home), pluginArgs)
+	if !ok {
+		return 0, false
+	}
+
+	if err := cmd.ParseFlags(globalArgs); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1, true
+	}
+	if err := applyConfig(cmd); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		return 1, true
+	}
+
+	// the plugin handles interrupts itself, Kyma CLI only waits for it and exits with its exit code
+	o.Finalizers.Stop()
+	code, err := plugins.Run(plugin, pluginArgs, pluginSettings(o))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+	}
+	return code, true
+}
+
+//splitPluginArgs splits the arguments into the flags of Kyma CLI and the arguments starting with the name of a command or plugin
+func splitPluginArgs(cmd *cobra.Command, args []string) ([]string, []string, bool) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return nil, nil, false
+		}
+		if !strings.HasPrefix(arg, "-") {
+			return args[:i], args[i:], true
+		}
+		name := strings.SplitN(strings.TrimLeft(arg, "-"), "=", 2)[0]
+		flag := cmd.PersistentFlags().Lookup(name)
+		if !strings.HasPrefix(arg, "--") {
+			flag = cmd.PersistentFlags().ShorthandLookup(name)
+		}
+		if flag == nil {
+			// unknown flags are reported by the built-in commands
+			return nil, nil, false
+		}
+		if !strings.Contains(arg, "=") && flag.NoOptDefVal == "" {
+			// the next argument is the value of the flag
+			i++
+		}
+	}
+	return nil, nil, false
+}
+
+//findPlugin returns the plugin with the longest name matching the leading arguments, so that "kyma company login" runs "kyma-company-login" before "kyma-company"
+func findPlugin(dirs []string, args []string) (plugins.Plugin, []string, bool) {
+	var names []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		names = append(names, arg)
+	}
+	for n := len(names); n > 0; n-- {
+		plugin, ok, err := plugins.Find(dirs, strings.Join(names[:n], "-"))
+		if err == nil && ok {
+			return plugin, args[n:], true
+		}
+	}
+	return plugins.Plugin{}, nil, false
+}
+
+//pluginSettings returns the settings of Kyma CLI passed to the plugins. The domain is empty if the cluster is not available.
+func pluginSettings(o *cli.Options) plugins.Settings {
+	s := plugins.Settings{
+		Kubeconfig:     kube.KubeconfigPath(o.KubeconfigPath),
+		KubeconfigFlag: o.KubeconfigPath != "",
+		CI:             o.CI,
+		Verbose:        o.Verbose,
+		NonInteractive: o.NonInteractive,
+	}
+	if _, err := os.Stat(s.Kubeconfig); err != nil {
+		return s
+	}
+	k8s, err := kube.NewFromConfigWithTimeout("", s.Kubeconfig, domainTimeout)
+	if err != nil {
+		return s
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), domainTimeout)
+	defer cancel()
+	s.Domain, _ = serverless.KymaHostAddress(ctx, k8s.Istio())
+	return s
+}
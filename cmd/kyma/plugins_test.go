@@ -0,0 +1,60 @@
+package kyma
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPluginArgs(t *testing.T) {
+	t.Parallel()
+	c := NewCmd(cli.NewOptions())
+	tests := []struct {
+		args   []string
+		global []string
+		plugin []string
+		ok     bool
+	}{
+		{args: []string{"audit", "--all"}, global: []string{}, plugin: []string{"audit", "--all"}, ok: true},
+		{args: []string{"--kubeconfig", "/kubeconfig", "-v", "--ci=true", "audit"}, global: []string{"--kubeconfig", "/kubeconfig", "-v", "--ci=true"}, plugin: []string{"audit"}, ok: true},
+		{args: []string{"--kubeconfig=/kubeconfig", "audit"}, global: []string{"--kubeconfig=/kubeconfig"}, plugin: []string{"audit"}, ok: true},
+		{args: []string{"--unknown", "audit"}},
+		{args: []string{"--", "audit"}},
+		{args: []string{"-v"}},
+	}
+	for _, test := range tests {
+		global, plugin, ok := splitPluginArgs(c, test.args)
+		require.Equal(t, test.ok, ok, test.args)
+		if test.ok {
+			require.Equal(t, test.global, global, test.args)
+			require.Equal(t, test.plugin, plugin, test.args)
+		}
+	}
+}
+
+func TestFindPlugin(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "plugins")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"kyma-company", "kyma-company-login"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), 0755))
+	}
+
+	plugin, args, ok := findPlugin([]string{dir}, []string{"company", "login", "admin", "--sso"})
+	require.True(t, ok)
+	require.Equal(t, "company-login", plugin.Name, "The plugin with the longest name is used")
+	require.Equal(t, []string{"admin", "--sso"}, args)
+
+	plugin, args, ok = findPlugin([]string{dir}, []string{"company", "--help", "login"})
+	require.True(t, ok)
+	require.Equal(t, "company", plugin.Name, "Arguments after flags are not part of the name")
+	require.Equal(t, []string{"--help", "login"}, args)
+
+	_, _, ok = findPlugin([]string{dir}, []string{"audit"})
+	require.False(t, ok)
+}
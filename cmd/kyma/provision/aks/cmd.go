@@ -1,6 +1,7 @@
 package aks
 
 import (
+	"github.com/kyma-project/cli/internal/clusterspec"
 	"github.com/spf13/cobra"
 )
 
@@ -29,6 +30,8 @@ func NewCmd(o *Options) *cobra.Command {
 	// Temporary disabled flag. To be enabled when hydroform supports TF modules
 	//cmd.Flags().StringSliceVarP(&o.Extra, "extra", "e", nil, "Provide one or more arguments of the form NAME=VALUE to add extra configurations.")
 	cmd.Flags().UintVar(&o.Attempts, "attempts", 3, "Maximum number of attempts to provision the cluster.")
+	cmd.Flags().BoolVar(&o.NoSwitchContext, "no-switch-context", false, "Keeps the current context of the kubeconfig instead of switching to the context of the provisioned cluster.")
 
+	clusterspec.Bind(cmd, clusterspec.ProviderAKS)
 	return cmd
 }
@@ -15,6 +15,7 @@ type Options struct {
 	NodeCount         int
 	Extra             []string
 	Attempts          uint
+	NoSwitchContext   bool
 }
 
 //NewOptions creates options with default values
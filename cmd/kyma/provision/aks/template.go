@@ -62,6 +62,8 @@ func (c *aksCmd) Attempts() uint { return c.opts.Attempts }
 
 func (c *aksCmd) KubeconfigPath() string { return c.opts.KubeconfigPath }
 
+func (c *aksCmd) KeepCurrentContext() bool { return c.opts.NoSwitchContext }
+
 func (c *aksCmd) ValidateFlags() error {
 	var errMessage strings.Builder
 	// mandatory flags]
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"github.com/kyma-project/cli/internal/clusterspec"
 	"github.com/spf13/cobra"
 )
 
@@ -32,6 +33,9 @@ Use service account details to create a Secret and store it in Gardener.`,
 	cmd.Flags().IntVar(&o.ScalerMax, "scaler-max", 3, "Maximum autoscale value of the cluster.")
 	cmd.Flags().StringSliceVarP(&o.Extra, "extra", "e", nil, "One or more arguments provided as the `NAME=VALUE` key-value pairs to configure additional cluster settings. You can use this flag multiple times or enter the key-value pairs as a comma-separated list.")
 	cmd.Flags().UintVar(&o.Attempts, "attempts", 3, "Maximum number of attempts to provision the cluster.")
+	cmd.Flags().BoolVar(&o.NoSwitchContext, "no-switch-context", false, "Keeps the current context of the kubeconfig instead of switching to the context of the provisioned cluster.")
+	o.ShootOptions.AddFlags(cmd.Flags())
 
+	clusterspec.Bind(cmd, clusterspec.ProviderGardenerAWS)
 	return cmd
 }
@@ -1,9 +1,13 @@
 package aws
 
-import "github.com/kyma-project/cli/internal/cli"
+import (
+	"github.com/kyma-project/cli/cmd/kyma/provision/gardener"
+	"github.com/kyma-project/cli/internal/cli"
+)
 
 type Options struct {
 	*cli.Options
+	gardener.ShootOptions
 
 	Name              string
 	Project           string
@@ -19,6 +23,7 @@ type Options struct {
 	ScalerMax         int
 	Extra             []string
 	Attempts          uint
+	NoSwitchContext   bool
 }
 
 //NewOptions creates options with default values
@@ -0,0 +1,47 @@
+package gardener
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+)
+
+var shootGVR = schema.GroupVersionResource{Group: "core.gardener.cloud", Version: "v1beta1", Resource: "shoots"}
+
+// CustomizeShoot changes the Shoot of the cluster in the Gardener project with the settings which Hydroform doesn't support,
+// such as additional worker pools, node labels and taints, the maintenance window, and the hibernation schedule.
+// It runs after every provisioning, so that the settings are applied again if Terraform reverted them when it resumed the provisioning.
+func CustomizeShoot(ctx context.Context, o *ShootOptions, credentialsFile, project, name string) error {
+	if !o.CustomizesShoot() {
+		return nil
+	}
+
+	cfg, err := clientcmd.BuildConfigFromFlags("", credentialsFile)
+	if err != nil {
+		return errors.Wrap(err, "Could not load the Gardener kubeconfig")
+	}
+	client, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	shoots := client.Resource(shootGVR).Namespace(fmt.Sprintf("garden-%s", project))
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		shoot, err := shoots.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := o.ApplyToShoot(shoot); err != nil {
+			return err
+		}
+		_, err = shoots.Update(ctx, shoot, metav1.UpdateOptions{})
+		return err
+	})
+	return errors.Wrapf(err, "Could not configure the worker pools, maintenance, and hibernation of cluster '%s'", name)
+}
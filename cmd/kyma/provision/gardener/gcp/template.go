@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyma-incubator/hydroform/provision/types"
 	prov "github.com/kyma-project/cli/cmd/kyma/provision"
+	"github.com/kyma-project/cli/cmd/kyma/provision/gardener"
 	"github.com/kyma-project/cli/internal/cli"
 )
 
@@ -54,13 +55,7 @@ func (c *gcpCmd) NewProvider() (*types.Provider, error) {
 	p.CustomConfigurations["disk_type"] = c.opts.DiskType
 	p.CustomConfigurations["worker_minimum"] = c.opts.ScalerMin
 	p.CustomConfigurations["worker_maximum"] = c.opts.ScalerMax
-	p.CustomConfigurations["worker_max_surge"] = 1
-	p.CustomConfigurations["worker_max_unavailable"] = 1
-	p.CustomConfigurations["vnetcidr"] = "10.250.0.0/16"
-	p.CustomConfigurations["workercidr"] = "10.250.0.0/16"
-	p.CustomConfigurations["networking_type"] = "calico"
-	p.CustomConfigurations["machine_image_name"] = "gardenlinux"
-	p.CustomConfigurations["machine_image_version"] = "184.0.0"
+	c.opts.ShootOptions.Configure(p.CustomConfigurations)
 	p.CustomConfigurations["zones"] = c.opts.Zones
 
 	for _, e := range c.opts.Extra {
@@ -81,6 +76,14 @@ func (c *gcpCmd) Attempts() uint { return c.opts.Attempts }
 
 func (c *gcpCmd) KubeconfigPath() string { return c.opts.KubeconfigPath }
 
+func (c *gcpCmd) KeepCurrentContext() bool { return c.opts.NoSwitchContext }
+
+func (c *gcpCmd) CustomizesCluster() bool { return c.opts.ShootOptions.CustomizesShoot() }
+
+func (c *gcpCmd) CustomizeCluster(cluster *types.Cluster, _ *types.Provider) error {
+	return gardener.CustomizeShoot(c.Context(), &c.opts.ShootOptions, c.opts.CredentialsFile, c.opts.Project, cluster.Name)
+}
+
 func (c *gcpCmd) ValidateFlags() error {
 	var errMessage strings.Builder
 	// mandatory flags
@@ -109,6 +112,14 @@ func (c *gcpCmd) ValidateFlags() error {
 		}
 	}
 
+	c.opts.ShootOptions.ValidateFlags(&errMessage)
+	c.opts.ShootOptions.ValidateZones(c.opts.Region, nil, &errMessage)
+	c.opts.ShootOptions.ValidateCapacity("gcp", &errMessage)
+	gardener.ValidateExtra(c.opts.Extra, &errMessage)
+	for _, warning := range gardener.ExtraWarnings(c.opts.Extra) {
+		c.CurrentStep.LogError(warning)
+	}
+
 	if errMessage.Len() != 0 {
 		return errors.New(errMessage.String())
 	}
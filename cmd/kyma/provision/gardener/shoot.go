@@ -0,0 +1,424 @@
+package gardener
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	defaultMaxSurge            = "1"
+	defaultMaxUnavailable      = "1"
+	defaultNetworkingType      = "calico"
+	defaultVnetCIDR            = "10.250.0.0/16"
+	defaultWorkerCIDR          = "10.250.0.0/16"
+	defaultMachineImage        = "gardenlinux"
+	defaultMachineImageVersion = "184.0.0"
+	// defaultMaintenanceWindow is the maintenance window which Hydroform sets for Gardener clusters
+	defaultMaintenanceWindow = "03:00-04:00"
+	defaultHibernationZone   = "UTC"
+	maintenanceTimeFormat    = "15:04"
+)
+
+var networkingTypes = []string{"calico", "cilium"}
+
+// typedSettings maps the custom configurations that are set by typed flags to the name of the flag.
+// Setting them with the --extra flag overrides the typed flag, which is still supported for existing scripts but prints a warning.
+var typedSettings = map[string]string{
+	"target_secret":          "secret",
+	"disk_type":              "disk-type",
+	"worker_minimum":         "scaler-min",
+	"worker_maximum":         "scaler-max",
+	"zones":                  "zones",
+	"worker_max_surge":       "max-surge",
+	"worker_max_unavailable": "max-unavailable",
+	"networking_type":        "networking-type",
+	"vnetcidr":               "vnet-cidr",
+	"workercidr":             "worker-cidr",
+	"networking_nodes":       "nodes-cidr",
+	"networking_pods":        "pods-cidr",
+	"networking_services":    "services-cidr",
+	"machine_image_name":     "machine-image",
+	"machine_image_version":  "machine-image-version",
+}
+
+// ShootOptions contains the worker pool, networking, maintenance, and hibernation settings which are common to all Gardener providers
+type ShootOptions struct {
+	WorkerLabels        []string
+	WorkerTaints        []string
+	WorkerPools         []string
+	MaintenanceWindow   string
+	HibernationStart    string
+	HibernationEnd      string
+	HibernationLocation string
+	MaxSurge            string
+	MaxUnavailable      string
+	NetworkingType      string
+	VnetCIDR            string
+	WorkerCIDR          string
+	NodesCIDR           string
+	PodsCIDR            string
+	ServicesCIDR        string
+	MachineImage        string
+	MachineImageVersion string
+}
+
+// AddFlags adds the flags of the worker pool, networking, maintenance, and hibernation settings to a Gardener provision command
+func (o *ShootOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(&o.WorkerLabels, "worker-labels", nil, "Labels of the nodes of the worker pool, provided as `KEY=VALUE` pairs (e.g. --worker-labels=\"team=dev,tier=web\").")
+	flags.StringSliceVar(&o.WorkerTaints, "worker-taints", nil, "Taints of the nodes of the worker pool, provided as `KEY=VALUE:EFFECT` or `KEY:EFFECT` (e.g. --worker-taints=\"dedicated=web:NoSchedule\").")
+	flags.StringArrayVar(&o.WorkerPools, "worker-pool", nil, `Additional worker pool with its own machine type, provided as comma-separated KEY=VALUE settings. Supported settings are: name, machine-type, min, max, max-surge, max-unavailable, zones, disk-size, disk-type, labels, taints, and spot=true (on AWS and Azure) or preemptible=true (on GCP) for virtual machines which the provider can reclaim at any time. Separate the items of zones, labels, and taints with semicolons (e.g. --worker-pool="name=gpu,machine-type=n1-highmem-8,min=0,max=2,labels=gpu=true,taints=nvidia.com/gpu=present:NoSchedule"). Settings which are not provided are taken over from the worker pool configured with the other flags. You can use this flag multiple times.`)
+	flags.StringVar(&o.MaintenanceWindow, "maintenance-window", defaultMaintenanceWindow, "Daily time window (in UTC) in which Gardener updates the cluster, provided as `HH:MM-HH:MM`. It must be between 30 minutes and 6 hours long.")
+	flags.StringVar(&o.HibernationStart, "hibernation-start", "", `Cron schedule at which the cluster is hibernated (e.g. "00 18 * * 1,2,3,4,5"). If not set, the cluster is not hibernated automatically.`)
+	flags.StringVar(&o.HibernationEnd, "hibernation-end", "", `Cron schedule at which the cluster wakes up from hibernation (e.g. "00 08 * * 1,2,3,4,5").`)
+	flags.StringVar(&o.HibernationLocation, "hibernation-location", defaultHibernationZone, `Time zone of the hibernation schedules (e.g. "Europe/Berlin").`)
+	flags.StringVar(&o.MaxSurge, "max-surge", defaultMaxSurge, "Maximum number (or percentage, e.g. 25%) of nodes that are created in addition to the desired number of nodes during a rolling update of the worker pool.")
+	flags.StringVar(&o.MaxUnavailable, "max-unavailable", defaultMaxUnavailable, "Maximum number (or percentage, e.g. 25%) of nodes that can be unavailable during a rolling update of the worker pool.")
+	flags.StringVar(&o.NetworkingType, "networking-type", defaultNetworkingType, fmt.Sprintf("Networking plugin of the cluster (%s).", strings.Join(networkingTypes, ", ")))
+	flags.StringVar(&o.VnetCIDR, "vnet-cidr", defaultVnetCIDR, "CIDR of the virtual network (VPC) of the cluster. Used on AWS and Azure.")
+	flags.StringVar(&o.WorkerCIDR, "worker-cidr", defaultWorkerCIDR, "CIDR of the worker subnet. Used on GCP and Azure. On Azure, it must be within the virtual network CIDR.")
+	flags.StringVar(&o.NodesCIDR, "nodes-cidr", "", "CIDR of the cluster nodes. If not set, Gardener uses the CIDR of the worker subnet.")
+	flags.StringVar(&o.PodsCIDR, "pods-cidr", "", "CIDR of the Pods in the cluster. If not set, Gardener uses its default CIDR.")
+	flags.StringVar(&o.ServicesCIDR, "services-cidr", "", "CIDR of the Services in the cluster. If not set, Gardener uses its default CIDR.")
+	flags.StringVar(&o.MachineImage, "machine-image", defaultMachineImage, "Name of the operating system image of the worker nodes.")
+	flags.StringVar(&o.MachineImageVersion, "machine-image-version", defaultMachineImageVersion, "Version of the operating system image of the worker nodes.")
+}
+
+// ValidateFlags writes a message for each invalid worker pool or networking setting to the given builder
+func (o *ShootOptions) ValidateFlags(errMessage *strings.Builder) {
+	surge, surgeOK := validateRollingUpdateValue("max-surge", o.MaxSurge, errMessage)
+	unavailable, unavailableOK := validateRollingUpdateValue("max-unavailable", o.MaxUnavailable, errMessage)
+	if surgeOK && unavailableOK && surge == 0 && unavailable == 0 {
+		errMessage.WriteString("\n Flags `max-surge` and `max-unavailable` cannot both be 0.")
+	}
+
+	if o.NetworkingType != "" && !contains(networkingTypes, o.NetworkingType) {
+		errMessage.WriteString(fmt.Sprintf("\n Networking type %s is not supported. Supported types are: %s.", o.NetworkingType, strings.Join(networkingTypes, ", ")))
+	}
+
+	parseCIDR("vnet-cidr", o.VnetCIDR, errMessage)
+	worker := parseCIDR("worker-cidr", o.WorkerCIDR, errMessage)
+	nodes := parseCIDR("nodes-cidr", o.NodesCIDR, errMessage)
+	pods := parseCIDR("pods-cidr", o.PodsCIDR, errMessage)
+	services := parseCIDR("services-cidr", o.ServicesCIDR, errMessage)
+
+	if nodes != nil && worker != nil && !contained(nodes, worker) {
+		errMessage.WriteString(fmt.Sprintf("\n Nodes CIDR %s is not within the worker CIDR %s.", o.NodesCIDR, o.WorkerCIDR))
+	}
+
+	// Pods and Services must not share addresses with each other or with the node network
+	networks := []struct {
+		flag string
+		cidr *net.IPNet
+	}{
+		{"worker-cidr", worker}, {"pods-cidr", pods}, {"services-cidr", services},
+	}
+	for i := range networks {
+		for j := i + 1; j < len(networks); j++ {
+			a, b := networks[i], networks[j]
+			if a.cidr != nil && b.cidr != nil && overlap(a.cidr, b.cidr) {
+				errMessage.WriteString(fmt.Sprintf("\n The CIDRs of flags `%s` (%s) and `%s` (%s) overlap.", a.flag, a.cidr, b.flag, b.cidr))
+			}
+		}
+	}
+
+	if o.MachineImageVersion != "" && o.MachineImage == "" {
+		errMessage.WriteString("\n Flag `machine-image-version` requires the flag `machine-image`.")
+	}
+
+	if _, err := ParseLabels(o.WorkerLabels); err != nil {
+		errMessage.WriteString(fmt.Sprintf("\n Flag `worker-labels` is invalid: %s.", err))
+	}
+	if _, err := ParseTaints(o.WorkerTaints); err != nil {
+		errMessage.WriteString(fmt.Sprintf("\n Flag `worker-taints` is invalid: %s.", err))
+	}
+	names := map[string]bool{}
+	for _, spec := range o.WorkerPools {
+		pool, err := ParseWorkerPool(spec)
+		if err != nil {
+			errMessage.WriteString(fmt.Sprintf("\n %s.", err))
+			continue
+		}
+		pool.validate(errMessage)
+		if names[pool.Name] {
+			errMessage.WriteString(fmt.Sprintf("\n Worker pool %s is defined more than once.", pool.Name))
+		}
+		names[pool.Name] = true
+	}
+
+	if _, _, err := parseMaintenanceWindow(valueOrDefault(o.MaintenanceWindow, defaultMaintenanceWindow)); err != nil {
+		errMessage.WriteString(fmt.Sprintf("\n Flag `maintenance-window` is invalid: %s.", err))
+	}
+
+	if o.HibernationStart == "" && o.HibernationEnd != "" {
+		errMessage.WriteString("\n Flag `hibernation-end` requires the flag `hibernation-start`.")
+	}
+	validateCron("hibernation-start", o.HibernationStart, errMessage)
+	validateCron("hibernation-end", o.HibernationEnd, errMessage)
+	if _, err := time.LoadLocation(valueOrDefault(o.HibernationLocation, defaultHibernationZone)); err != nil {
+		errMessage.WriteString(fmt.Sprintf("\n Value %s of flag `hibernation-location` is not a valid time zone.", o.HibernationLocation))
+	}
+}
+
+// ValidateZones writes a message to the given builder for each zone of the additional worker pools which is not in the region.
+// Only providers whose zone names start with the region (GCP and AWS) need this check.
+// If networkZones is not empty, the zones must also be among them, because the provider (AWS) only creates subnets in these zones.
+func (o *ShootOptions) ValidateZones(region string, networkZones []string, errMessage *strings.Builder) {
+	for _, spec := range o.WorkerPools {
+		pool, err := ParseWorkerPool(spec)
+		if err != nil {
+			continue // reported by ValidateFlags
+		}
+		for _, zone := range pool.Zones {
+			if !strings.HasPrefix(zone, region) {
+				errMessage.WriteString(fmt.Sprintf("\n Zone %s of worker pool %s and region %s do not match. Please provide the right region for the zone.", zone, pool.Name, region))
+			} else if len(networkZones) > 0 && !contains(networkZones, zone) {
+				errMessage.WriteString(fmt.Sprintf("\n Zone %s of worker pool %s is not one of the zones of the flag `zones`.", zone, pool.Name))
+			}
+		}
+	}
+}
+
+// ValidateCapacity writes a message to the given builder for each additional worker pool whose capacity the Gardener provider type doesn't offer.
+// GCP offers preemptible virtual machines, AWS and Azure offer spot instances.
+func (o *ShootOptions) ValidateCapacity(providerType string, errMessage *strings.Builder) {
+	for _, spec := range o.WorkerPools {
+		pool, err := ParseWorkerPool(spec)
+		if err != nil {
+			continue // reported by ValidateFlags
+		}
+		pool.validateCapacity(providerType, errMessage)
+	}
+}
+
+// ValidateVnet writes a message to the given builder if the worker subnet is not within the virtual network.
+// Only providers which place the workers in the virtual network (Azure) need this check.
+func (o *ShootOptions) ValidateVnet(errMessage *strings.Builder) {
+	vnet := parseCIDR("vnet-cidr", o.VnetCIDR, &strings.Builder{})
+	worker := parseCIDR("worker-cidr", o.WorkerCIDR, &strings.Builder{})
+	if vnet != nil && worker != nil && !contained(worker, vnet) {
+		errMessage.WriteString(fmt.Sprintf("\n Worker CIDR %s is not within the virtual network CIDR %s.", o.WorkerCIDR, o.VnetCIDR))
+	}
+}
+
+// Configure sets the worker pool and networking settings in the custom configurations of a Gardener provider.
+// Empty settings fall back to the defaults of the flags.
+func (o *ShootOptions) Configure(cfg map[string]interface{}) {
+	cfg["worker_max_surge"] = rollingUpdateValue(valueOrDefault(o.MaxSurge, defaultMaxSurge))
+	cfg["worker_max_unavailable"] = rollingUpdateValue(valueOrDefault(o.MaxUnavailable, defaultMaxUnavailable))
+	cfg["vnetcidr"] = valueOrDefault(o.VnetCIDR, defaultVnetCIDR)
+	cfg["workercidr"] = valueOrDefault(o.WorkerCIDR, defaultWorkerCIDR)
+	cfg["networking_type"] = valueOrDefault(o.NetworkingType, defaultNetworkingType)
+	cfg["machine_image_name"] = valueOrDefault(o.MachineImage, defaultMachineImage)
+	cfg["machine_image_version"] = valueOrDefault(o.MachineImageVersion, defaultMachineImageVersion)
+
+	if o.NodesCIDR != "" {
+		cfg["networking_nodes"] = o.NodesCIDR
+	}
+	if o.PodsCIDR != "" {
+		cfg["networking_pods"] = o.PodsCIDR
+	}
+	if o.ServicesCIDR != "" {
+		cfg["networking_services"] = o.ServicesCIDR
+	}
+}
+
+// CustomizesShoot tells whether settings are set which Hydroform doesn't support, so that the Shoot must be changed with ApplyToShoot after it was created
+func (o *ShootOptions) CustomizesShoot() bool {
+	return len(o.WorkerLabels) > 0 || len(o.WorkerTaints) > 0 || len(o.WorkerPools) > 0 ||
+		valueOrDefault(o.MaintenanceWindow, defaultMaintenanceWindow) != defaultMaintenanceWindow || o.HibernationStart != ""
+}
+
+// ApplyToShoot sets the worker pools, maintenance window, and hibernation schedule in the Shoot created by Hydroform.
+// The settings must be valid, see ValidateFlags.
+func (o *ShootOptions) ApplyToShoot(shoot *unstructured.Unstructured) error {
+	workers, _, err := unstructured.NestedSlice(shoot.Object, "spec", "provider", "workers")
+	if err != nil {
+		return err
+	}
+	if len(workers) == 0 {
+		return fmt.Errorf("Shoot %s has no worker pool", shoot.GetName())
+	}
+
+	primary, ok := workers[0].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Shoot %s has an invalid worker pool", shoot.GetName())
+	}
+	for _, w := range workers {
+		if m, ok := w.(map[string]interface{}); ok && m["name"] == primaryPool {
+			primary = m
+		}
+	}
+	labels, _ := ParseLabels(o.WorkerLabels)
+	taints, _ := ParseTaints(o.WorkerTaints)
+	setLabelsAndTaints(primary, labels, taints)
+
+	providerType, _, _ := unstructured.NestedString(shoot.Object, "spec", "provider", "type")
+
+	for _, spec := range o.WorkerPools {
+		pool, err := ParseWorkerPool(spec)
+		if err != nil {
+			return err
+		}
+		worker, err := pool.worker(primary, providerType)
+		if err != nil {
+			return err
+		}
+		workers = setWorker(workers, worker)
+	}
+	if err := unstructured.SetNestedSlice(shoot.Object, workers, "spec", "provider", "workers"); err != nil {
+		return err
+	}
+
+	begin, end, _ := parseMaintenanceWindow(valueOrDefault(o.MaintenanceWindow, defaultMaintenanceWindow))
+	if err := unstructured.SetNestedField(shoot.Object, begin, "spec", "maintenance", "timeWindow", "begin"); err != nil {
+		return err
+	}
+	if err := unstructured.SetNestedField(shoot.Object, end, "spec", "maintenance", "timeWindow", "end"); err != nil {
+		return err
+	}
+
+	if o.HibernationStart != "" {
+		schedule := map[string]interface{}{
+			"start":    o.HibernationStart,
+			"location": valueOrDefault(o.HibernationLocation, defaultHibernationZone),
+		}
+		if o.HibernationEnd != "" {
+			schedule["end"] = o.HibernationEnd
+		}
+		if err := unstructured.SetNestedSlice(shoot.Object, []interface{}{schedule}, "spec", "hibernation", "schedules"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateExtra writes a message for each extra configuration that is malformed to the given builder
+func ValidateExtra(extra []string, errMessage *strings.Builder) {
+	for _, e := range extra {
+		v := strings.Split(e, "=")
+		if len(v) != 2 {
+			errMessage.WriteString(fmt.Sprintf("\n Wrong format for extra configuration %s. Please provide NAME=VALUE pairs.", e))
+		}
+	}
+}
+
+// ExtraWarnings returns a warning for each extra configuration that overrides a typed flag
+func ExtraWarnings(extra []string) []string {
+	var warnings []string
+	for _, e := range extra {
+		v := strings.Split(e, "=")
+		if flag, ok := typedSettings[v[0]]; ok && len(v) == 2 {
+			warnings = append(warnings, fmt.Sprintf("Extra configuration %s overrides the flag `%s`. Please use the flag instead.", v[0], flag))
+		}
+	}
+	return warnings
+}
+
+// validateRollingUpdateValue checks that the value is a non-negative number or a percentage between 0% and 100%.
+// It returns the numeric part of the value.
+func validateRollingUpdateValue(flag, value string, errMessage *strings.Builder) (int, bool) {
+	if value == "" {
+		return 1, true
+	}
+	percentage := strings.HasSuffix(value, "%")
+	n, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+	if err != nil || n < 0 || (percentage && n > 100) {
+		errMessage.WriteString(fmt.Sprintf("\n Value %s of flag `%s` is invalid. Please provide a non-negative number or a percentage (e.g. 25%%).", value, flag))
+		return 0, false
+	}
+	return n, true
+}
+
+// setWorker replaces the worker with the same name, or adds the worker if there is none
+func setWorker(workers []interface{}, worker map[string]interface{}) []interface{} {
+	for i, w := range workers {
+		if m, ok := w.(map[string]interface{}); ok && m["name"] == worker["name"] {
+			workers[i] = worker
+			return workers
+		}
+	}
+	return append(workers, worker)
+}
+
+// parseMaintenanceWindow converts a window given as HH:MM-HH:MM in UTC to the begin and end times of Gardener (HHMMSS+0000)
+func parseMaintenanceWindow(window string) (string, string, error) {
+	times := strings.Split(window, "-")
+	if len(times) != 2 {
+		return "", "", fmt.Errorf("window %s must have the format HH:MM-HH:MM", window)
+	}
+	begin, err := time.Parse(maintenanceTimeFormat, times[0])
+	if err != nil {
+		return "", "", fmt.Errorf("begin %s must have the format HH:MM", times[0])
+	}
+	end, err := time.Parse(maintenanceTimeFormat, times[1])
+	if err != nil {
+		return "", "", fmt.Errorf("end %s must have the format HH:MM", times[1])
+	}
+	duration := end.Sub(begin)
+	if duration < 0 {
+		duration += 24 * time.Hour
+	}
+	if duration < 30*time.Minute || duration > 6*time.Hour {
+		return "", "", fmt.Errorf("window %s must be between 30 minutes and 6 hours long", window)
+	}
+	return begin.Format("150405") + "+0000", end.Format("150405") + "+0000", nil
+}
+
+// validateCron checks that the value is a cron schedule with 5 fields
+func validateCron(flag, value string, errMessage *strings.Builder) {
+	if value != "" && len(strings.Fields(value)) != 5 {
+		errMessage.WriteString(fmt.Sprintf("\n Value %s of flag `%s` is not a valid cron schedule. Please provide 5 fields: minute, hour, day of month, month, and day of week.", value, flag))
+	}
+}
+
+func rollingUpdateValue(value string) interface{} {
+	if n, err := strconv.Atoi(value); err == nil {
+		return n
+	}
+	return value
+}
+
+func parseCIDR(flag, value string, errMessage *strings.Builder) *net.IPNet {
+	if value == "" {
+		return nil
+	}
+	_, cidr, err := net.ParseCIDR(value)
+	if err != nil {
+		errMessage.WriteString(fmt.Sprintf("\n Value %s of flag `%s` is not a valid CIDR.", value, flag))
+		return nil
+	}
+	return cidr
+}
+
+// contained returns true if the network inner is fully within the network outer
+func contained(inner, outer *net.IPNet) bool {
+	innerSize, _ := inner.Mask.Size()
+	outerSize, _ := outer.Mask.Size()
+	return outer.Contains(inner.IP) && innerSize >= outerSize
+}
+
+func overlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func valueOrDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,357 @@
+package gardener
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestShootOptionsFlags(t *testing.T) {
+	t.Parallel()
+	o := &ShootOptions{}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	o.AddFlags(flags)
+
+	// test default flag values
+	require.Equal(t, "1", o.MaxSurge, "Default value for the max-surge flag not as expected.")
+	require.Equal(t, "1", o.MaxUnavailable, "Default value for the max-unavailable flag not as expected.")
+	require.Equal(t, "calico", o.NetworkingType, "Default value for the networking-type flag not as expected.")
+	require.Equal(t, "10.250.0.0/16", o.VnetCIDR, "Default value for the vnet-cidr flag not as expected.")
+	require.Equal(t, "10.250.0.0/16", o.WorkerCIDR, "Default value for the worker-cidr flag not as expected.")
+	require.Equal(t, "", o.PodsCIDR, "Default value for the pods-cidr flag not as expected.")
+	require.Equal(t, "03:00-04:00", o.MaintenanceWindow, "Default value for the maintenance-window flag not as expected.")
+	require.Equal(t, "UTC", o.HibernationLocation, "Default value for the hibernation-location flag not as expected.")
+
+	// test passing flags
+	err := flags.Parse([]string{
+		"--max-surge", "25%",
+		"--max-unavailable", "0",
+		"--networking-type", "cilium",
+		"--pods-cidr", "100.96.0.0/11",
+		"--machine-image", "ubuntu",
+		"--worker-labels", "team=dev,tier=web",
+		"--worker-pool", "name=gpu,machine-type=n1-highmem-8",
+		"--worker-pool", "name=batch,machine-type=n1-standard-16,zones=europe-west3-a;europe-west3-b",
+		"--maintenance-window", "22:00-02:00",
+	})
+	require.NoError(t, err, "Parsing flags should not return an error")
+	require.Equal(t, "25%", o.MaxSurge, "The parsed value for the max-surge flag not as expected.")
+	require.Equal(t, "0", o.MaxUnavailable, "The parsed value for the max-unavailable flag not as expected.")
+	require.Equal(t, "cilium", o.NetworkingType, "The parsed value for the networking-type flag not as expected.")
+	require.Equal(t, "100.96.0.0/11", o.PodsCIDR, "The parsed value for the pods-cidr flag not as expected.")
+	require.Equal(t, "ubuntu", o.MachineImage, "The parsed value for the machine-image flag not as expected.")
+	require.Equal(t, []string{"team=dev", "tier=web"}, o.WorkerLabels, "The parsed value for the worker-labels flag not as expected.")
+	require.Equal(t, []string{"name=gpu,machine-type=n1-highmem-8", "name=batch,machine-type=n1-standard-16,zones=europe-west3-a;europe-west3-b"}, o.WorkerPools, "The parsed value for the worker-pool flag not as expected.")
+	require.Equal(t, "22:00-02:00", o.MaintenanceWindow, "The parsed value for the maintenance-window flag not as expected.")
+}
+
+func TestShootOptionsValidateFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		opts    ShootOptions
+		wantErr string
+	}{
+		{name: "defaults", opts: ShootOptions{}},
+		{
+			name: "valid settings",
+			opts: ShootOptions{MaxSurge: "25%", MaxUnavailable: "0", NetworkingType: "cilium", WorkerCIDR: "10.250.0.0/16", NodesCIDR: "10.250.0.0/19", PodsCIDR: "100.96.0.0/11", ServicesCIDR: "100.64.0.0/13"},
+		},
+		{name: "invalid max surge", opts: ShootOptions{MaxSurge: "-1"}, wantErr: "max-surge"},
+		{name: "percentage too high", opts: ShootOptions{MaxUnavailable: "120%"}, wantErr: "max-unavailable"},
+		{name: "no rolling update possible", opts: ShootOptions{MaxSurge: "0", MaxUnavailable: "0%"}, wantErr: "cannot both be 0"},
+		{name: "unsupported networking type", opts: ShootOptions{NetworkingType: "flannel"}, wantErr: "flannel"},
+		{name: "invalid CIDR", opts: ShootOptions{PodsCIDR: "100.96.0.0"}, wantErr: "pods-cidr"},
+		{name: "nodes outside of workers", opts: ShootOptions{WorkerCIDR: "10.250.0.0/16", NodesCIDR: "10.0.0.0/8"}, wantErr: "not within the worker CIDR"},
+		{name: "overlapping CIDRs", opts: ShootOptions{PodsCIDR: "100.64.0.0/10", ServicesCIDR: "100.64.0.0/13"}, wantErr: "overlap"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errMessage strings.Builder
+			tt.opts.ValidateFlags(&errMessage)
+			if tt.wantErr == "" {
+				require.Empty(t, errMessage.String())
+			} else {
+				require.Contains(t, errMessage.String(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShootOptionsValidateVnet(t *testing.T) {
+	t.Parallel()
+	var errMessage strings.Builder
+	o := &ShootOptions{VnetCIDR: "10.250.0.0/16", WorkerCIDR: "10.250.0.0/19"}
+	o.ValidateVnet(&errMessage)
+	require.Empty(t, errMessage.String())
+
+	o.WorkerCIDR = "10.0.0.0/8"
+	o.ValidateVnet(&errMessage)
+	require.Contains(t, errMessage.String(), "not within the virtual network CIDR")
+}
+
+func TestShootOptionsConfigure(t *testing.T) {
+	t.Parallel()
+	o := &ShootOptions{
+		MaxSurge:     "25%",
+		NodesCIDR:    "10.250.0.0/19",
+		ServicesCIDR: "100.64.0.0/13",
+	}
+	cfg := make(map[string]interface{})
+	o.Configure(cfg)
+
+	require.Equal(t, map[string]interface{}{
+		"worker_max_surge":       "25%",
+		"worker_max_unavailable": 1,
+		"vnetcidr":               "10.250.0.0/16",
+		"workercidr":             "10.250.0.0/16",
+		"networking_type":        "calico",
+		"networking_nodes":       "10.250.0.0/19",
+		"networking_services":    "100.64.0.0/13",
+		"machine_image_name":     "gardenlinux",
+		"machine_image_version":  "184.0.0",
+	}, cfg)
+}
+
+func TestValidateExtra(t *testing.T) {
+	t.Parallel()
+	var errMessage strings.Builder
+	ValidateExtra([]string{"VAR1=VALUE1", "privileged_containers=true", "zones=europe-west3-b"}, &errMessage)
+	require.Empty(t, errMessage.String(), "extra configurations of typed flags must still be accepted")
+
+	ValidateExtra([]string{"VAR1"}, &errMessage)
+	require.Contains(t, errMessage.String(), "NAME=VALUE")
+}
+
+func TestExtraWarnings(t *testing.T) {
+	t.Parallel()
+	require.Empty(t, ExtraWarnings([]string{"VAR1=VALUE1", "privileged_containers=true"}))
+
+	warnings := ExtraWarnings([]string{"networking_type=cilium", "disk_type=pd-ssd"})
+	require.Len(t, warnings, 2)
+	require.Contains(t, warnings[0], "networking-type")
+	require.Contains(t, warnings[1], "disk-type")
+}
+
+func TestParseWorkerPool(t *testing.T) {
+	t.Parallel()
+	pool, err := ParseWorkerPool("name=gpu,machine-type=n1-highmem-8,min=0,max=2,zones=europe-west3-a;europe-west3-b,disk-size=100,labels=gpu=true;team=ml,taints=nvidia.com/gpu=present:NoSchedule;spot:PreferNoSchedule")
+	require.NoError(t, err)
+	require.Equal(t, WorkerPool{
+		Name:        "gpu",
+		MachineType: "n1-highmem-8",
+		Minimum:     0,
+		Maximum:     2,
+		Zones:       []string{"europe-west3-a", "europe-west3-b"},
+		DiskSizeGB:  100,
+		Labels:      map[string]string{"gpu": "true", "team": "ml"},
+		Taints: []Taint{
+			{Key: "nvidia.com/gpu", Value: "present", Effect: "NoSchedule"},
+			{Key: "spot", Effect: "PreferNoSchedule"},
+		},
+	}, pool)
+
+	_, err = ParseWorkerPool("name=gpu,gpus=2")
+	require.Error(t, err, "unknown settings must be rejected")
+	_, err = ParseWorkerPool("name=gpu,max=two")
+	require.Error(t, err, "invalid numbers must be rejected")
+	_, err = ParseWorkerPool("name=gpu,taints=gpu=true:Sometimes")
+	require.Error(t, err, "invalid taint effects must be rejected")
+
+	pool, err = ParseWorkerPool("name=batch,machine-type=m5.xlarge,spot=true")
+	require.NoError(t, err)
+	require.Equal(t, CapacitySpot, pool.Capacity)
+	pool, err = ParseWorkerPool("name=batch,machine-type=n1-standard-4,preemptible=false")
+	require.NoError(t, err)
+	require.Equal(t, CapacityRegular, pool.Capacity)
+	_, err = ParseWorkerPool("name=batch,spot=yes")
+	require.Error(t, err, "capacity settings must be boolean")
+	_, err = ParseWorkerPool("name=batch,spot=true,preemptible=true")
+	require.Error(t, err, "a pool can only have one capacity")
+}
+
+func TestShootOptionsValidateCapacity(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		providerType string
+		pool         string
+		wantErr      string
+	}{
+		{name: "preemptible on GCP", providerType: "gcp", pool: "name=batch,machine-type=a,preemptible=true"},
+		{name: "spot on AWS", providerType: "aws", pool: "name=batch,machine-type=a,spot=true"},
+		{name: "spot on Azure", providerType: "azure", pool: "name=batch,machine-type=a,spot=true"},
+		{name: "regular pool", providerType: "gcp", pool: "name=batch,machine-type=a"},
+		{name: "spot on GCP", providerType: "gcp", pool: "name=batch,machine-type=a,spot=true", wantErr: "preemptible=true instead"},
+		{name: "preemptible on AWS", providerType: "aws", pool: "name=batch,machine-type=a,preemptible=true", wantErr: "spot=true instead"},
+		{name: "unknown provider", providerType: "openstack", pool: "name=batch,machine-type=a,spot=true", wantErr: "can't use spot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errMessage strings.Builder
+			o := &ShootOptions{WorkerPools: []string{tt.pool}}
+			o.ValidateCapacity(tt.providerType, &errMessage)
+			if tt.wantErr == "" {
+				require.Empty(t, errMessage.String())
+			} else {
+				require.Contains(t, errMessage.String(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShootOptionsValidateWorkerPools(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		opts    ShootOptions
+		wantErr string
+	}{
+		{
+			name: "valid settings",
+			opts: ShootOptions{
+				WorkerLabels:        []string{"tier=web"},
+				WorkerTaints:        []string{"dedicated=web:NoSchedule"},
+				WorkerPools:         []string{"name=gpu,machine-type=n1-highmem-8,max=2", "name=batch,machine-type=n1-standard-16"},
+				MaintenanceWindow:   "22:00-02:00",
+				HibernationStart:    "00 18 * * 1,2,3,4,5",
+				HibernationEnd:      "00 08 * * 1,2,3,4,5",
+				HibernationLocation: "Europe/Berlin",
+			},
+		},
+		{name: "invalid label", opts: ShootOptions{WorkerLabels: []string{"web"}}, wantErr: "worker-labels"},
+		{name: "invalid taint", opts: ShootOptions{WorkerTaints: []string{"dedicated=web"}}, wantErr: "worker-taints"},
+		{name: "pool without machine type", opts: ShootOptions{WorkerPools: []string{"name=gpu"}}, wantErr: "needs a machine type"},
+		{name: "reserved pool name", opts: ShootOptions{WorkerPools: []string{"name=cpu-worker,machine-type=n1-standard-4"}}, wantErr: "reserved"},
+		{name: "pool name too long", opts: ShootOptions{WorkerPools: []string{"name=a-very-long-pool-name,machine-type=n1-standard-4"}}, wantErr: "invalid"},
+		{name: "duplicate pools", opts: ShootOptions{WorkerPools: []string{"name=gpu,machine-type=a", "name=gpu,machine-type=b"}}, wantErr: "more than once"},
+		{name: "minimum above maximum", opts: ShootOptions{WorkerPools: []string{"name=gpu,machine-type=a,min=3,max=2"}}, wantErr: "minimum"},
+		{name: "no rolling update possible", opts: ShootOptions{WorkerPools: []string{"name=gpu,machine-type=a,max-surge=0,max-unavailable=0"}}, wantErr: "cannot both be 0"},
+		{name: "invalid maintenance window", opts: ShootOptions{MaintenanceWindow: "3:00"}, wantErr: "maintenance-window"},
+		{name: "maintenance window too short", opts: ShootOptions{MaintenanceWindow: "03:00-03:15"}, wantErr: "between 30 minutes and 6 hours"},
+		{name: "hibernation end without start", opts: ShootOptions{HibernationEnd: "00 08 * * *"}, wantErr: "requires the flag `hibernation-start`"},
+		{name: "invalid cron schedule", opts: ShootOptions{HibernationStart: "18:00"}, wantErr: "not a valid cron schedule"},
+		{name: "invalid time zone", opts: ShootOptions{HibernationStart: "00 18 * * *", HibernationLocation: "Mars/Olympus"}, wantErr: "not a valid time zone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errMessage strings.Builder
+			tt.opts.ValidateFlags(&errMessage)
+			if tt.wantErr == "" {
+				require.Empty(t, errMessage.String())
+			} else {
+				require.Contains(t, errMessage.String(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShootOptionsValidateZones(t *testing.T) {
+	t.Parallel()
+	o := &ShootOptions{WorkerPools: []string{"name=gpu,machine-type=a,zones=eu-west-1a;eu-west-1b"}}
+
+	var errMessage strings.Builder
+	o.ValidateZones("eu-west-1", nil, &errMessage)
+	require.Empty(t, errMessage.String())
+
+	o.ValidateZones("eu-central-1", nil, &errMessage)
+	require.Contains(t, errMessage.String(), "do not match")
+
+	errMessage.Reset()
+	o.ValidateZones("eu-west-1", []string{"eu-west-1a"}, &errMessage)
+	require.Contains(t, errMessage.String(), "eu-west-1b of worker pool gpu is not one of the zones")
+}
+
+func TestShootOptionsApplyToShoot(t *testing.T) {
+	t.Parallel()
+	shoot := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "my-cluster"},
+		"spec": map[string]interface{}{
+			"provider": map[string]interface{}{
+				"type": "gcp",
+				"workers": []interface{}{
+					map[string]interface{}{
+						"name":    "cpu-worker",
+						"minimum": int64(2),
+						"maximum": int64(3),
+						"zones":   []interface{}{"europe-west3-a"},
+						"machine": map[string]interface{}{"type": "n1-standard-4", "image": map[string]interface{}{"name": "gardenlinux"}},
+						"volume":  map[string]interface{}{"size": "50Gi", "type": "pd-standard"},
+					},
+				},
+			},
+			"maintenance": map[string]interface{}{
+				"timeWindow": map[string]interface{}{"begin": "030000+0000", "end": "040000+0000"},
+			},
+		},
+	}}
+	o := &ShootOptions{
+		WorkerLabels:      []string{"tier=web"},
+		WorkerPools:       []string{"name=gpu,machine-type=n1-highmem-8,min=0,max=2,disk-size=100,taints=gpu:NoSchedule", "name=batch,machine-type=n1-standard-16,preemptible=true"},
+		MaintenanceWindow: "22:00-02:00",
+		HibernationStart:  "00 18 * * 1,2,3,4,5",
+	}
+	require.True(t, o.CustomizesShoot())
+	require.NoError(t, o.ApplyToShoot(shoot))
+
+	workers, _, err := unstructured.NestedSlice(shoot.Object, "spec", "provider", "workers")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":    "cpu-worker",
+			"minimum": int64(2),
+			"maximum": int64(3),
+			"zones":   []interface{}{"europe-west3-a"},
+			"machine": map[string]interface{}{"type": "n1-standard-4", "image": map[string]interface{}{"name": "gardenlinux"}},
+			"volume":  map[string]interface{}{"size": "50Gi", "type": "pd-standard"},
+			"labels":  map[string]interface{}{"tier": "web"},
+		},
+		map[string]interface{}{
+			"name":    "gpu",
+			"minimum": int64(0),
+			"maximum": int64(2),
+			"zones":   []interface{}{"europe-west3-a"},
+			"machine": map[string]interface{}{"type": "n1-highmem-8", "image": map[string]interface{}{"name": "gardenlinux"}},
+			"volume":  map[string]interface{}{"size": "100Gi", "type": "pd-standard"},
+			"taints":  []interface{}{map[string]interface{}{"key": "gpu", "effect": "NoSchedule"}},
+		},
+		map[string]interface{}{
+			"name":    "batch",
+			"minimum": int64(1),
+			"maximum": int64(1),
+			"zones":   []interface{}{"europe-west3-a"},
+			"machine": map[string]interface{}{"type": "n1-standard-16", "image": map[string]interface{}{"name": "gardenlinux"}},
+			"volume":  map[string]interface{}{"size": "50Gi", "type": "pd-standard"},
+			"providerConfig": map[string]interface{}{
+				"apiVersion":  "gcp.provider.extensions.gardener.cloud/v1alpha1",
+				"kind":        "WorkerConfig",
+				"preemptible": true,
+			},
+		},
+	}, workers)
+
+	window, _, err := unstructured.NestedStringMap(shoot.Object, "spec", "maintenance", "timeWindow")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"begin": "220000+0000", "end": "020000+0000"}, window)
+
+	schedules, _, err := unstructured.NestedSlice(shoot.Object, "spec", "hibernation", "schedules")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{map[string]interface{}{"start": "00 18 * * 1,2,3,4,5", "location": "UTC"}}, schedules)
+
+	// applying the settings again, as on a resumed provisioning, must not add the pool twice
+	require.NoError(t, o.ApplyToShoot(shoot))
+	workers, _, _ = unstructured.NestedSlice(shoot.Object, "spec", "provider", "workers")
+	require.Len(t, workers, 3)
+
+	// the capacity must be offered by the provider of the Shoot
+	spot := &ShootOptions{WorkerPools: []string{"name=batch,machine-type=n1-standard-16,spot=true"}}
+	require.Error(t, spot.ApplyToShoot(shoot))
+
+	require.False(t, (&ShootOptions{MaintenanceWindow: defaultMaintenanceWindow}).CustomizesShoot(), "defaults must not change the Shoot")
+}
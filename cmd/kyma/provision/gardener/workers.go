@@ -0,0 +1,293 @@
+package gardener
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// primaryPool is the name of the worker pool which Hydroform creates with the machine type, disk, and autoscaler flags
+	primaryPool = "cpu-worker"
+	// maxPoolNameLength is the maximum length of a worker pool name accepted by Gardener
+	maxPoolNameLength = 15
+)
+
+var (
+	poolNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	taintEffects   = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+)
+
+// Capacity is the kind of the virtual machines of a worker pool, such as spot instances which the provider can reclaim at any time
+type Capacity string
+
+const (
+	// CapacityRegular uses regular virtual machines
+	CapacityRegular Capacity = ""
+	// CapacitySpot uses spot instances, which AWS and Azure offer
+	CapacitySpot Capacity = "spot"
+	// CapacityPreemptible uses preemptible virtual machines, which GCP offers
+	CapacityPreemptible Capacity = "preemptible"
+)
+
+// workerConfig is the provider-specific configuration of a Gardener worker which enables a capacity other than regular virtual machines
+type workerConfig struct {
+	capacity   Capacity
+	apiVersion string
+}
+
+// workerConfigs maps the Gardener provider types to their worker configuration
+var workerConfigs = map[string]workerConfig{
+	"gcp":   {capacity: CapacityPreemptible, apiVersion: "gcp.provider.extensions.gardener.cloud/v1alpha1"},
+	"aws":   {capacity: CapacitySpot, apiVersion: "aws.provider.extensions.gardener.cloud/v1alpha1"},
+	"azure": {capacity: CapacitySpot, apiVersion: "azure.provider.extensions.gardener.cloud/v1alpha1"},
+}
+
+// Taint is a Kubernetes taint which is added to all nodes of a worker pool
+type Taint struct {
+	Key    string
+	Value  string
+	Effect string
+}
+
+// WorkerPool is an additional worker pool of a Gardener cluster.
+// Settings which are not set are taken over from the primary worker pool.
+type WorkerPool struct {
+	Name           string
+	MachineType    string
+	Minimum        int
+	Maximum        int
+	MaxSurge       string
+	MaxUnavailable string
+	Zones          []string
+	DiskSizeGB     int
+	DiskType       string
+	Labels         map[string]string
+	Taints         []Taint
+	Capacity       Capacity
+}
+
+// ParseWorkerPool parses a worker pool given as comma-separated KEY=VALUE settings.
+// Lists, such as zones, labels, and taints, separate their items with semicolons.
+func ParseWorkerPool(spec string) (WorkerPool, error) {
+	pool := WorkerPool{Minimum: 1, Maximum: 1}
+	for _, setting := range strings.Split(spec, ",") {
+		kv := strings.SplitN(setting, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return pool, fmt.Errorf("Wrong format of setting '%s' of worker pool '%s'. Please provide KEY=VALUE pairs", setting, spec)
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+
+		var err error
+		switch key {
+		case "name":
+			pool.Name = value
+		case "machine-type":
+			pool.MachineType = value
+		case "min":
+			pool.Minimum, err = strconv.Atoi(value)
+		case "max":
+			pool.Maximum, err = strconv.Atoi(value)
+		case "max-surge":
+			pool.MaxSurge = value
+		case "max-unavailable":
+			pool.MaxUnavailable = value
+		case "zones":
+			pool.Zones = strings.Split(value, ";")
+		case "disk-size":
+			pool.DiskSizeGB, err = strconv.Atoi(value)
+		case "disk-type":
+			pool.DiskType = value
+		case "labels":
+			pool.Labels, err = ParseLabels(strings.Split(value, ";"))
+		case "taints":
+			pool.Taints, err = ParseTaints(strings.Split(value, ";"))
+		case string(CapacitySpot), string(CapacityPreemptible):
+			err = pool.setCapacity(Capacity(key), value)
+		default:
+			return pool, fmt.Errorf("Unknown setting '%s' of worker pool '%s'. Supported settings are: name, machine-type, min, max, max-surge, max-unavailable, zones, disk-size, disk-type, labels, taints, spot, preemptible", key, spec)
+		}
+		if err != nil {
+			return pool, fmt.Errorf("Invalid setting '%s' of worker pool '%s': %s", key, spec, err)
+		}
+	}
+	return pool, nil
+}
+
+// setCapacity sets the capacity of the pool if the value is true. A pool can only have one capacity.
+func (p *WorkerPool) setCapacity(capacity Capacity, value string) error {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("value '%s' must be true or false", value)
+	}
+	if !enabled {
+		return nil
+	}
+	if p.Capacity != CapacityRegular && p.Capacity != capacity {
+		return fmt.Errorf("a worker pool can't use both %s and %s virtual machines", p.Capacity, capacity)
+	}
+	p.Capacity = capacity
+	return nil
+}
+
+// ParseLabels parses node labels given as KEY=VALUE
+func ParseLabels(labels []string) (map[string]string, error) {
+	result := make(map[string]string, len(labels))
+	for _, l := range labels {
+		kv := strings.SplitN(l, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("label '%s' must have the format KEY=VALUE", l)
+		}
+		result[kv[0]] = kv[1]
+	}
+	return result, nil
+}
+
+// ParseTaints parses node taints given as KEY=VALUE:EFFECT or KEY:EFFECT
+func ParseTaints(taints []string) ([]Taint, error) {
+	result := make([]Taint, 0, len(taints))
+	for _, t := range taints {
+		i := strings.LastIndex(t, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("taint '%s' must have the format KEY=VALUE:EFFECT or KEY:EFFECT", t)
+		}
+		taint := Taint{Key: t[:i], Effect: t[i+1:]}
+		if kv := strings.SplitN(taint.Key, "=", 2); len(kv) == 2 {
+			taint.Key, taint.Value = kv[0], kv[1]
+		}
+		if taint.Key == "" {
+			return nil, fmt.Errorf("taint '%s' has no key", t)
+		}
+		if !contains(taintEffects, taint.Effect) {
+			return nil, fmt.Errorf("effect of taint '%s' must be one of: %s", t, strings.Join(taintEffects, ", "))
+		}
+		result = append(result, taint)
+	}
+	return result, nil
+}
+
+// validate writes a message for each invalid setting of the worker pool to the given builder
+func (p *WorkerPool) validate(errMessage *strings.Builder) {
+	switch {
+	case p.Name == "":
+		errMessage.WriteString("\n Each worker pool needs a name.")
+	case p.Name == primaryPool:
+		errMessage.WriteString(fmt.Sprintf("\n Worker pool name %s is reserved for the pool configured with the machine type and autoscaler flags.", primaryPool))
+	case len(p.Name) > maxPoolNameLength || !poolNameRegexp.MatchString(p.Name):
+		errMessage.WriteString(fmt.Sprintf("\n Worker pool name %s is invalid. Please use up to %d lowercase letters, numbers, or hyphens.", p.Name, maxPoolNameLength))
+	}
+	if p.MachineType == "" {
+		errMessage.WriteString(fmt.Sprintf("\n Worker pool %s needs a machine type.", p.Name))
+	}
+	if p.Minimum < 0 || p.Maximum < 1 || p.Minimum > p.Maximum {
+		errMessage.WriteString(fmt.Sprintf("\n Worker pool %s needs a minimum of at least 0 nodes and a maximum of at least 1 node which is not less than the minimum.", p.Name))
+	}
+	if p.DiskSizeGB < 0 {
+		errMessage.WriteString(fmt.Sprintf("\n Disk size of worker pool %s cannot be negative.", p.Name))
+	}
+	surge, surgeOK := validateRollingUpdateValue(fmt.Sprintf("max-surge of worker pool %s", p.Name), p.MaxSurge, errMessage)
+	unavailable, unavailableOK := validateRollingUpdateValue(fmt.Sprintf("max-unavailable of worker pool %s", p.Name), p.MaxUnavailable, errMessage)
+	if surgeOK && unavailableOK && surge == 0 && unavailable == 0 {
+		errMessage.WriteString(fmt.Sprintf("\n Settings max-surge and max-unavailable of worker pool %s cannot both be 0.", p.Name))
+	}
+}
+
+// validateCapacity writes a message to the given builder if the Gardener provider type doesn't offer the capacity of the worker pool
+func (p *WorkerPool) validateCapacity(providerType string, errMessage *strings.Builder) {
+	if p.Capacity == CapacityRegular {
+		return
+	}
+	cfg, ok := workerConfigs[providerType]
+	switch {
+	case !ok:
+		errMessage.WriteString(fmt.Sprintf("\n Worker pool %s can't use %s virtual machines on %s.", p.Name, p.Capacity, providerType))
+	case cfg.capacity != p.Capacity:
+		errMessage.WriteString(fmt.Sprintf("\n Worker pool %s can't use %s virtual machines on %s. Please use the setting %s=true instead.", p.Name, p.Capacity, providerType, cfg.capacity))
+	}
+}
+
+// worker returns the Gardener worker of the pool, based on the worker of the primary pool.
+// The capacity of the pool is set in the worker configuration of the provider type.
+func (p *WorkerPool) worker(primary map[string]interface{}, providerType string) (map[string]interface{}, error) {
+	w := runtime.DeepCopyJSON(primary)
+	delete(w, "labels")
+	delete(w, "taints")
+	w["name"] = p.Name
+	w["minimum"] = int64(p.Minimum)
+	w["maximum"] = int64(p.Maximum)
+	_ = unstructured.SetNestedField(w, p.MachineType, "machine", "type")
+	if p.MaxSurge != "" {
+		w["maxSurge"] = intOrString(p.MaxSurge)
+	}
+	if p.MaxUnavailable != "" {
+		w["maxUnavailable"] = intOrString(p.MaxUnavailable)
+	}
+	if len(p.Zones) > 0 {
+		w["zones"] = stringsToList(p.Zones)
+	}
+	if p.DiskSizeGB > 0 {
+		_ = unstructured.SetNestedField(w, fmt.Sprintf("%dGi", p.DiskSizeGB), "volume", "size")
+	}
+	if p.DiskType != "" {
+		_ = unstructured.SetNestedField(w, p.DiskType, "volume", "type")
+	}
+	setLabelsAndTaints(w, p.Labels, p.Taints)
+
+	if p.Capacity != CapacityRegular {
+		cfg, ok := workerConfigs[providerType]
+		if !ok || cfg.capacity != p.Capacity {
+			return nil, fmt.Errorf("Worker pool %s can't use %s virtual machines on %s", p.Name, p.Capacity, providerType)
+		}
+		// the worker configuration taken over from the primary pool is kept
+		providerConfig, _, _ := unstructured.NestedMap(w, "providerConfig")
+		if providerConfig == nil {
+			providerConfig = map[string]interface{}{}
+		}
+		providerConfig["apiVersion"] = cfg.apiVersion
+		providerConfig["kind"] = "WorkerConfig"
+		providerConfig[string(p.Capacity)] = true
+		w["providerConfig"] = providerConfig
+	}
+	return w, nil
+}
+
+// setLabelsAndTaints sets the node labels and taints of a Gardener worker
+func setLabelsAndTaints(w map[string]interface{}, labels map[string]string, taints []Taint) {
+	if len(labels) > 0 {
+		l := make(map[string]interface{}, len(labels))
+		for k, v := range labels {
+			l[k] = v
+		}
+		w["labels"] = l
+	}
+	if len(taints) > 0 {
+		t := make([]interface{}, 0, len(taints))
+		for _, taint := range taints {
+			entry := map[string]interface{}{"key": taint.Key, "effect": taint.Effect}
+			if taint.Value != "" {
+				entry["value"] = taint.Value
+			}
+			t = append(t, entry)
+		}
+		w["taints"] = t
+	}
+}
+
+func intOrString(value string) interface{} {
+	if n, err := strconv.Atoi(value); err == nil {
+		return int64(n)
+	}
+	return value
+}
+
+func stringsToList(values []string) []interface{} {
+	list := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		list = append(list, v)
+	}
+	return list
+}
@@ -1,6 +1,7 @@
 package gke
 
 import (
+	"github.com/kyma-project/cli/internal/clusterspec"
 	"github.com/spf13/cobra"
 )
 
@@ -28,6 +29,8 @@ NOTE: To access the provisioned cluster, make sure you get authenticated by Goog
 	// Temporary disabled flag. To be enabled when hydroform supports TF modules
 	//cmd.Flags().StringSliceVarP(&o.Extra, "extra", "e", nil, "Provide one or more arguments of the form NAME=VALUE to add extra configurations.")
 	cmd.Flags().UintVar(&o.Attempts, "attempts", 3, "Maximum number of attempts to provision the cluster.")
+	cmd.Flags().BoolVar(&o.NoSwitchContext, "no-switch-context", false, "Keeps the current context of the kubeconfig instead of switching to the context of the provisioned cluster.")
 
+	clusterspec.Bind(cmd, clusterspec.ProviderGKE)
 	return cmd
 }
@@ -62,6 +62,8 @@ func (c *gkeCmd) Attempts() uint { return c.opts.Attempts }
 
 func (c *gkeCmd) KubeconfigPath() string { return c.opts.KubeconfigPath }
 
+func (c *gkeCmd) KeepCurrentContext() bool { return c.opts.NoSwitchContext }
+
 func (c *gkeCmd) ValidateFlags() error {
 	var errMessage strings.Builder
 	// mandatory flags
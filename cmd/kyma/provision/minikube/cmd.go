@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/clusterspec"
 	"github.com/kyma-project/cli/internal/kube"
 	"github.com/kyma-project/cli/internal/minikube"
 	"github.com/kyma-project/cli/pkg/step"
@@ -75,6 +76,9 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().DurationVar(&o.Timeout, "timeout", 5*time.Minute, `Maximum time during which the provisioning takes place, where "0" means "infinite". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
 	cmd.Flags().BoolVar(&o.UseVPNKitSock, "use-hyperkit-vpnkit-sock", false, `Uses vpnkit sock provided by Docker. This is useful when DNS Port (53) is being used by some other program like dns-proxy (eg. provided by Cisco Umbrella. This flag works only on Mac OS).`)
 	cmd.Flags().StringVarP(&o.KubernetesVersion, "kube-version", "k", "1.16.15", "Kubernetes version of the cluster.")
+	cmd.Flags().BoolVar(&o.NoSwitchContext, "no-switch-context", false, "Keeps the current context of the kubeconfig instead of switching to the context of Minikube.")
+
+	clusterspec.Bind(cmd, clusterspec.ProviderMinikube)
 	return cmd
 }
 
@@ -109,15 +113,13 @@ func (c *command) Run() error {
 	s.Successf("Minikube config initialized")
 
 	s = c.NewStep("Create Minikube instance")
-	s.Status("Start Minikube")
-	err = c.startMinikube()
+	previousContext, err := kube.CurrentContext(c.KubeconfigPath)
 	if err != nil {
 		s.Failure()
 		return err
 	}
-
-	s.Status("Wait for Minikube to be up and running")
-	err = c.waitForMinikubeToBeUp(s)
+	s.Status("Start Minikube")
+	err = c.startMinikube()
 	if err != nil {
 		s.Failure()
 		return err
@@ -128,6 +130,21 @@ func (c *command) Run() error {
 		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
 	}
 
+	// Minikube always switches to its context, the client keeps using it after the previous context is restored
+	if c.opts.NoSwitchContext && previousContext != "" {
+		if err := kube.UseContext(c.KubeconfigPath, previousContext); err != nil {
+			s.Failure()
+			return errors.Wrapf(err, "Could not restore the current context '%s'", previousContext)
+		}
+	}
+
+	s.Status("Wait for Minikube to be up and running")
+	err = c.waitForMinikubeToBeUp(s)
+	if err != nil {
+		s.Failure()
+		return err
+	}
+
 	s.Status("Create default cluster role")
 	err = c.createClusterRoleBinding()
 	if err != nil {
@@ -261,11 +278,10 @@ func (c *command) startMinikube() error {
 	if err != nil {
 		return err
 	}
-	_, err = minikube.RunCmd(c.opts.Verbose, c.opts.Profile, c.opts.Timeout, startCmd...)
-	if err != nil {
-		return err
-	}
-	return nil
+	// minikube is stopped when the command is interrupted
+	c.OnInterrupt("Start of Minikube", "Run the same command again to delete the partly started Minikube instance and start a new one.")
+	_, err = minikube.RunCmdContext(c.Context(), c.opts.Verbose, c.opts.Profile, c.opts.Timeout, startCmd...)
+	return err
 }
 
 // fixes https://github.com/kyma-project/kyma/issues/1986
@@ -357,6 +373,10 @@ func (c *command) printSummary() error {
 		fmt.Println(clusterInfo)
 	}
 
+	if c.opts.NoSwitchContext {
+		fmt.Println("Kubectl context of Minikube added without switching to it. To find and use it, run: kyma kubeconfig list")
+		fmt.Println()
+	}
 	fmt.Println("Happy Minikube-ing! :)")
 	return nil
 }
@@ -19,6 +19,7 @@ type Options struct {
 	UseVPNKitSock       bool
 	Timeout             time.Duration
 	KubernetesVersion   string
+	NoSwitchContext     bool
 }
 
 //NewOptions creates options with default values
@@ -8,6 +8,8 @@ import (
 	"github.com/avast/retry-go"
 	hf "github.com/kyma-incubator/hydroform/provision"
 	"github.com/kyma-incubator/hydroform/provision/types"
+	"github.com/kyma-project/cli/internal/clusterinfo"
+	"github.com/kyma-project/cli/internal/clusters"
 	"github.com/kyma-project/cli/internal/files"
 	"github.com/kyma-project/cli/internal/kube"
 	"github.com/kyma-project/cli/pkg/step"
@@ -17,17 +19,26 @@ type Command interface {
 	// getters
 	IsVerbose() bool
 	KubeconfigPath() string
+	KeepCurrentContext() bool
 	Attempts() uint
 	ProviderName() string
 
 	ValidateFlags() error
 	NewStep(msg string) step.Step
+	OnInterrupt(operation, resume string)
+	RunInterruptible(function func() error) error
 	NewCluster() *types.Cluster
 	NewProvider() (*types.Provider, error)
 
 	Run() error
 }
 
+// ClusterCustomizer is implemented by commands which change the cluster after it was provisioned, for settings which Hydroform doesn't support
+type ClusterCustomizer interface {
+	CustomizesCluster() bool
+	CustomizeCluster(cluster *types.Cluster, provider *types.Provider) error
+}
+
 func RunTemplate(c Command) error {
 	s := c.NewStep("Validating flags")
 	if err := c.ValidateFlags(); err != nil {
@@ -52,19 +63,36 @@ func RunTemplate(c Command) error {
 		return err
 	}
 
-	err = retry.Do(
-		func() error {
-			cluster, err = hf.Provision(cluster, provider, types.WithDataDir(home), types.Persistent(), types.Verbose(c.IsVerbose()))
-			return err
-		},
-		retry.Attempts(c.Attempts()), retry.LastErrorOnly(!c.IsVerbose()))
+	// Hydroform doesn't accept a context, so an interrupted provisioning is abandoned rather than cancelled.
+	// The state of the cluster is persisted in the Kyma CLI home directory, so that the provisioning continues where it stopped.
+	c.OnInterrupt(fmt.Sprintf("Provisioning of %s cluster '%s'", c.ProviderName(), cluster.Name), "Run the same command again to resume the provisioning.")
+	requested := cluster
+	err = c.RunInterruptible(func() error {
+		return retry.Do(
+			func() error {
+				var err error
+				requested, err = hf.Provision(requested, provider, types.WithDataDir(home), types.Persistent(), types.Verbose(c.IsVerbose()))
+				return err
+			},
+			retry.Attempts(c.Attempts()), retry.LastErrorOnly(!c.IsVerbose()))
+	})
 
 	if err != nil {
 		s.Failure()
 		return err
 	}
+	cluster = requested
 	s.Success()
 
+	if customizer, ok := c.(ClusterCustomizer); ok && customizer.CustomizesCluster() {
+		s = c.NewStep(fmt.Sprintf("Configuring %s cluster", c.ProviderName()))
+		if err := customizer.CustomizeCluster(cluster, provider); err != nil {
+			s.Failure()
+			return err
+		}
+		s.Success()
+	}
+
 	s = c.NewStep("Importing kubeconfig")
 	kubeconfig, err := hf.Credentials(cluster, provider, types.WithDataDir(home), types.Persistent(), types.Verbose(c.IsVerbose()))
 	if err != nil {
@@ -72,12 +100,24 @@ func RunTemplate(c Command) error {
 		return err
 	}
 
-	if err := kube.AppendConfig(kubeconfig, c.KubeconfigPath()); err != nil {
+	// the context is tagged with the provider and the cluster, so that it can be managed with the kyma kubeconfig commands
+	tag := kube.ClusterTag{Provider: clusterinfo.ClusterProvider(provider.Type), Cluster: cluster.Name}
+	if err := kube.AppendConfig(kubeconfig, c.KubeconfigPath(), kube.KeepCurrentContext(c.KeepCurrentContext()), kube.WithClusterTag(tag)); err != nil {
+		s.Failure()
+		return err
+	}
+
+	// remember the cluster to be able to list and deprovision it later on
+	if err := clusters.Save(home, c.ProviderName(), cluster, provider, kubeconfig); err != nil {
 		s.Failure()
 		return err
 	}
 	s.Success()
 
+	if c.KeepCurrentContext() {
+		fmt.Printf("\n%s cluster installed\nKubectl context of %s added without switching to it. To find and use it, run: kyma kubeconfig list\n\nHappy %s-ing! :)\n", c.ProviderName(), cluster.Name, c.ProviderName())
+		return nil
+	}
 	fmt.Printf("\n%s cluster installed\nKubectl correctly configured: pointing to %s\n\nHappy %s-ing! :)\n", c.ProviderName(), cluster.Name, c.ProviderName())
 	return nil
 }
@@ -0,0 +1,298 @@
+package function
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/docker/runtimes"
+	"github.com/kyma-incubator/hydroform/function/pkg/operator"
+	"github.com/kyma-incubator/hydroform/function/pkg/resources/types"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+)
+
+// vsCodeConfiguration is a launch configuration of VS Code which attaches the debugger to a running Function
+type vsCodeConfiguration struct {
+	Name         string          `json:"name"`
+	Type         string          `json:"type"`
+	Request      string          `json:"request"`
+	Address      string          `json:"address,omitempty"`
+	Port         int             `json:"port,omitempty"`
+	LocalRoot    string          `json:"localRoot,omitempty"`
+	RemoteRoot   string          `json:"remoteRoot,omitempty"`
+	Restart      bool            `json:"restart,omitempty"`
+	Connect      *vsCodeConnect  `json:"connect,omitempty"`
+	PathMappings []vsCodeMapping `json:"pathMappings,omitempty"`
+}
+
+type vsCodeConnect struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type vsCodeMapping struct {
+	LocalRoot  string `json:"localRoot"`
+	RemoteRoot string `json:"remoteRoot"`
+}
+
+const goLandNodeConfiguration = `<component name="ProjectRunConfigurationManager">
+  <configuration default="false" name="%s" type="ChromiumRemoteDebugType" factoryName="Chromium Remote" port="%s" restartOnDisconnect="true">
+    <method v="2" />
+  </configuration>
+</component>`
+
+// debugCommands changes the commands which start the Function in debug mode so that the Function waits for a debugger to attach
+func debugCommands(commands []string, wait bool) []string {
+	if !wait {
+		return commands
+	}
+	result := make([]string, len(commands))
+	for i, command := range commands {
+		command = strings.Replace(command, "--inspect=", "--inspect-brk=", 1)
+		if strings.Contains(command, "-m debugpy") {
+			// the options of debugpy follow the address to listen on
+			fields := strings.Fields(command)
+			for j := range fields {
+				if fields[j] == "--listen" && j+1 < len(fields) {
+					fields = append(fields[:j+2], append([]string{"--wait-for-client"}, fields[j+2:]...)...)
+					break
+				}
+			}
+			command = strings.Join(fields, " ")
+		}
+		result[i] = command
+	}
+	return result
+}
+
+// printLaunchConfigurations prints the configurations of VS Code and GoLand which attach the debugger to the port on the local machine
+func printLaunchConfigurations(out io.Writer, cfg workspace.Cfg, port, localRoot string, hotDeploy bool) error {
+	name := fmt.Sprintf("Attach to Function %s", cfg.Name)
+	portNumber, err := strconv.Atoi(port)
+	if err != nil {
+		return errors.Wrapf(err, "Invalid debug port '%s'", port)
+	}
+
+	configuration := vsCodeConfiguration{Name: name, Request: "attach"}
+	switch cfg.Runtime {
+	case types.Python38, types.Python39:
+		configuration.Type = "python"
+		configuration.Connect = &vsCodeConnect{Host: "localhost", Port: portNumber}
+		configuration.PathMappings = []vsCodeMapping{{LocalRoot: localRoot, RemoteRoot: runtimes.KubelessPath}}
+	default:
+		configuration.Type = "node"
+		configuration.Address = "localhost"
+		configuration.Port = portNumber
+		configuration.LocalRoot = localRoot
+		configuration.RemoteRoot = runtimes.KubelessPath
+		configuration.Restart = hotDeploy
+	}
+	launch, err := json.MarshalIndent(map[string]interface{}{
+		"version":        "0.2.0",
+		"configurations": []vsCodeConfiguration{configuration},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nVS Code configuration (.vscode/launch.json):\n%s\n", launch)
+	if configuration.Type == "node" {
+		fmt.Fprintf(out, "\nGoLand configuration (.run/%s.run.xml), requires the Node.js plugin:\n", cfg.Name)
+		fmt.Fprintf(out, goLandNodeConfiguration+"\n\n", name, port)
+	} else {
+		fmt.Fprintf(out, "\nGoLand can't debug Python. Use VS Code or another client of the Debug Adapter Protocol to attach to debugpy.\n\n")
+	}
+	return nil
+}
+
+const (
+	// remoteDebugTimeout is the maximum time to wait for a Pod of the Function which runs with the debugger enabled
+	remoteDebugTimeout = 5 * time.Minute
+	// nodeOptions is the environment variable with the command-line options of Node.js
+	nodeOptions = "NODE_OPTIONS"
+)
+
+// debugRemote enables the Node.js inspector of the Function deployed on the cluster and forwards its debug port until the command is interrupted.
+// The environment variables of the Function are restored when the command exits.
+func (c *command) debugRemote(ctx context.Context, cfg workspace.Cfg) error {
+	switch cfg.Runtime {
+	case types.Python38, types.Python39:
+		return fmt.Errorf("Function '%s' can't be debugged on the cluster, because the image of runtime %s does not contain debugpy. Debug the Function locally instead", cfg.Name, cfg.Runtime)
+	}
+	if c.opts.DebugWait {
+		return errors.New("The --debug-wait flag can't be used with the --remote flag, because a Function on the cluster which waits for a debugger never becomes ready")
+	}
+
+	var err error
+	if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
+		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
+	}
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = c.K8s.DefaultNamespace()
+	}
+	port := runtimes.RuntimeDebugPort(cfg.Runtime)
+
+	step := c.NewStep(fmt.Sprintf("Enabling the debugger of Function '%s'", cfg.Name))
+	restore, err := c.enableInspector(ctx, namespace, cfg.Name, port)
+	if err != nil {
+		step.Failure()
+		return err
+	}
+	defer restore()
+	c.Finalizers.Add(restore)
+	step.Successf("Debugger of Function '%s' enabled", cfg.Name)
+
+	step = c.NewStep(fmt.Sprintf("Waiting for a Pod of Function '%s' with the debugger enabled", cfg.Name))
+	pod, err := c.inspectedPod(c.Context(), namespace, cfg.Name, port)
+	if err != nil {
+		step.Failure()
+		return err
+	}
+
+	stop := make(chan struct{})
+	var once sync.Once
+	c.Finalizers.Add(func() { once.Do(func() { close(stop) }) })
+	if _, err := kube.PortForwardTo(c.K8s.RestConfig(), namespace, pod.Name, port, port, stop); err != nil {
+		step.Failure()
+		return err
+	}
+	step.Successf("Debug port %s of Pod '%s' forwarded to localhost:%s", port, pod.Name, port)
+
+	if err := printLaunchConfigurations(os.Stdout, cfg, port, c.opts.Dir, false); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, "Press Ctrl+C to stop debugging and restore the Function.")
+	<-stop
+	return nil
+}
+
+// enableInspector sets the NODE_OPTIONS environment variable of the Function on the cluster so that it starts the Node.js inspector.
+// The returned function restores the previous environment variables and can be called several times.
+func (c *command) enableInspector(ctx context.Context, namespace, name, port string) (func(), error) {
+	functions := c.K8s.Dynamic().Resource(operator.GVRFunction).Namespace(namespace)
+	function, err := functions.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not get Function '%s' in Namespace '%s'", name, namespace)
+	}
+	env, _, err := unstructured.NestedSlice(function.Object, "spec", "env")
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not read the environment variables of Function '%s'", name)
+	}
+	if err := setFunctionEnv(ctx, functions, name, inspectorEnv(env, port)); err != nil {
+		return nil, errors.Wrapf(err, "Could not enable the debugger of Function '%s'", name)
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			// the command context may already be cancelled when the Function is restored
+			if err := setFunctionEnv(context.Background(), functions, name, env); err != nil {
+				fmt.Fprintf(os.Stderr, "Could not restore the environment variables of Function '%s': %s\n", name, err)
+			}
+		})
+	}, nil
+}
+
+// setFunctionEnv replaces the environment variables of the Function, retrying on conflicts with changes of the Function controller
+func setFunctionEnv(ctx context.Context, functions dynamic.ResourceInterface, name string, env []interface{}) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		function, err := functions.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if len(env) == 0 {
+			unstructured.RemoveNestedField(function.Object, "spec", "env")
+		} else if err := unstructured.SetNestedSlice(function.Object, env, "spec", "env"); err != nil {
+			return err
+		}
+		_, err = functions.Update(ctx, function, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// inspectorEnv returns a copy of the environment variables in which NODE_OPTIONS starts the Node.js inspector on the port.
+// Other options in NODE_OPTIONS are kept.
+func inspectorEnv(env []interface{}, port string) []interface{} {
+	inspect := inspectOption(port)
+	result := make([]interface{}, 0, len(env)+1)
+	found := false
+	for _, e := range env {
+		variable, ok := e.(map[string]interface{})
+		if !ok || variable["name"] != nodeOptions {
+			result = append(result, e)
+			continue
+		}
+		found = true
+		var options []string
+		if value, ok := variable["value"].(string); ok {
+			for _, option := range strings.Fields(value) {
+				if !strings.HasPrefix(option, "--inspect") {
+					options = append(options, option)
+				}
+			}
+		}
+		result = append(result, map[string]interface{}{
+			"name":  nodeOptions,
+			"value": strings.Join(append(options, inspect), " "),
+		})
+	}
+	if !found {
+		result = append(result, map[string]interface{}{"name": nodeOptions, "value": inspect})
+	}
+	return result
+}
+
+func inspectOption(port string) string {
+	return fmt.Sprintf("--inspect=0.0.0.0:%s", port)
+}
+
+// inspectedPod waits for a running Pod of the Function whose NODE_OPTIONS start the Node.js inspector
+func (c *command) inspectedPod(ctx context.Context, namespace, name, port string) (corev1.Pod, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteDebugTimeout)
+	defer cancel()
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	for {
+		pods, err := serverless.ResourcePods(ctx, c.K8s.Static(), namespace, name, serverless.ResourceDeployment)
+		if err != nil {
+			return corev1.Pod{}, errors.Wrapf(err, "Could not list the Pods of Function '%s'", name)
+		}
+		for _, pod := range pods {
+			if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil && inspects(pod, port) {
+				return pod, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return corev1.Pod{}, fmt.Errorf("Function '%s' has no running Pods with the debugger enabled in Namespace '%s' after %s", name, namespace, remoteDebugTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// inspects tells whether a container of the Pod starts the Node.js inspector on the port
+func inspects(pod corev1.Pod, port string) bool {
+	for _, container := range pod.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name == nodeOptions && strings.Contains(env.Value, inspectOption(port)) {
+				return true
+			}
+		}
+	}
+	return false
+}
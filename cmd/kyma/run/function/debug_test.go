@@ -0,0 +1,105 @@
+package function
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/docker/runtimes"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugCommands(t *testing.T) {
+	t.Parallel()
+	commands := runtimes.ContainerCommands("nodejs14", true, false)
+	require.Equal(t, commands, debugCommands(commands, false))
+	require.Equal(t, []string{"/kubeless-npm-install.sh", "node --inspect-brk=0.0.0.0 kubeless.js "}, debugCommands(commands, true))
+
+	commands = runtimes.ContainerCommands("nodejs12", true, true)
+	require.Contains(t, debugCommands(commands, true)[1], "--inspect-brk=0.0.0.0 --exitcrash")
+
+	commands = runtimes.ContainerCommands("python39", true, false)
+	require.Equal(t, []string{
+		"pip install -r $KUBELESS_INSTALL_VOLUME/requirements.txt",
+		"pip install debugpy",
+		"python -m debugpy --listen 0.0.0.0:5678 --wait-for-client kubeless.py",
+	}, debugCommands(commands, true))
+}
+
+func TestPrintLaunchConfigurations(t *testing.T) {
+	t.Parallel()
+	t.Run("Node.js", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, printLaunchConfigurations(&out, workspace.Cfg{Name: "orders", Runtime: "nodejs14"}, "9229", "/project", true))
+
+		launch := decodeLaunch(t, out.String())
+		require.Equal(t, "node", launch["type"])
+		require.Equal(t, "attach", launch["request"])
+		require.Equal(t, float64(9229), launch["port"])
+		require.Equal(t, "/project", launch["localRoot"])
+		require.Equal(t, "/kubeless", launch["remoteRoot"])
+		require.Equal(t, true, launch["restart"])
+		require.Contains(t, out.String(), `type="ChromiumRemoteDebugType"`)
+		require.Contains(t, out.String(), `port="9229"`)
+	})
+
+	t.Run("Python", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, printLaunchConfigurations(&out, workspace.Cfg{Name: "orders", Runtime: "python38"}, "5678", "/project", false))
+
+		launch := decodeLaunch(t, out.String())
+		require.Equal(t, "python", launch["type"])
+		require.Equal(t, map[string]interface{}{"host": "localhost", "port": float64(5678)}, launch["connect"])
+		require.Equal(t, []interface{}{map[string]interface{}{"localRoot": "/project", "remoteRoot": "/kubeless"}}, launch["pathMappings"])
+		require.NotContains(t, out.String(), "ChromiumRemoteDebugType")
+	})
+
+	require.Error(t, printLaunchConfigurations(&bytes.Buffer{}, workspace.Cfg{Runtime: "nodejs14"}, "debug", "/project", false))
+}
+
+func TestRemoteRequiresDebug(t *testing.T) {
+	t.Parallel()
+	c := command{opts: &Options{Remote: true}}
+	require.Error(t, c.Run())
+}
+
+func TestInspectorEnv(t *testing.T) {
+	t.Parallel()
+
+	t.Run("add NODE_OPTIONS", func(t *testing.T) {
+		env := []interface{}{map[string]interface{}{"name": "FOO", "value": "bar"}}
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"name": "FOO", "value": "bar"},
+			map[string]interface{}{"name": "NODE_OPTIONS", "value": "--inspect=0.0.0.0:9229"},
+		}, inspectorEnv(env, "9229"))
+		require.Len(t, env, 1, "The environment variables of the Function must not be changed")
+	})
+
+	t.Run("keep other options", func(t *testing.T) {
+		env := []interface{}{map[string]interface{}{"name": "NODE_OPTIONS", "value": "--max-old-space-size=128 --inspect=9000"}}
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"name": "NODE_OPTIONS", "value": "--max-old-space-size=128 --inspect=0.0.0.0:9229"},
+		}, inspectorEnv(env, "9229"))
+	})
+}
+
+func TestRemoteRejectsPython(t *testing.T) {
+	t.Parallel()
+	c := command{opts: &Options{}}
+	require.Error(t, c.debugRemote(context.Background(), workspace.Cfg{Name: "fn", Runtime: "python39"}))
+}
+
+// decodeLaunch returns the first configuration of the VS Code launch.json in the output
+func decodeLaunch(t *testing.T, out string) map[string]interface{} {
+	start := strings.Index(out, "{")
+	end := strings.Index(out, "\n}") + 2
+	var launch struct {
+		Configurations []map[string]interface{}
+	}
+	require.NoError(t, json.Unmarshal([]byte(out[start:end]), &launch))
+	require.Len(t, launch.Configurations, 1)
+	return launch.Configurations[0]
+}
@@ -0,0 +1,196 @@
+package function
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/pkg/errors"
+)
+
+const (
+	// eventReadyTimeout is the maximum time to wait for the Function to accept requests before the events are sent
+	eventReadyTimeout = 2 * time.Minute
+	eventReadyPoll    = 500 * time.Millisecond
+	healthPath        = "/healthz"
+)
+
+// event is a CloudEvent sent to the locally running Function
+type event struct {
+	// origin describes where the event comes from, such as the path of a recorded event
+	origin      string
+	attributes  map[string]string
+	contentType string
+	data        []byte
+}
+
+// loadEvents returns the events which are sent to the Function after it is started
+func (c *command) loadEvents() ([]event, error) {
+	switch {
+	case c.opts.Emit != "" && c.opts.EventReplay != "":
+		return nil, errors.New("The flags --emit and --event-replay cannot be used together")
+	case c.opts.Data != "" && c.opts.Emit == "":
+		return nil, errors.New("The flag --data can only be used with the --emit flag")
+	case c.opts.Emit != "":
+		e := event{
+			origin:      "--emit",
+			attributes:  map[string]string{"type": c.opts.Emit},
+			contentType: serverless.DefaultEventContentType,
+		}
+		if c.opts.Data != "" {
+			data, err := ioutil.ReadFile(c.opts.Data)
+			if err != nil {
+				return nil, errors.Wrap(err, "Could not read the event data")
+			}
+			e.data = data
+		}
+		return []event{e}, nil
+	case c.opts.EventReplay != "":
+		return readRecordedEvents(c.opts.EventReplay)
+	default:
+		return nil, nil
+	}
+}
+
+// readRecordedEvents reads the JSON files in the directory in the alphabetical order of their names.
+// Each file contains one CloudEvent in the structured content mode.
+func readRecordedEvents(dir string) ([]event, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("No recorded events found in '%s'. Each event must be stored in a JSON file", dir)
+	}
+	sort.Strings(files)
+
+	var events []event
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		e, err := parseRecordedEvent(content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid recorded event '%s'", file)
+		}
+		e.origin = file
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// parseRecordedEvent converts a CloudEvent in the structured content mode to its attributes and data
+func parseRecordedEvent(content []byte) (event, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return event{}, err
+	}
+
+	e := event{attributes: map[string]string{}, contentType: serverless.DefaultEventContentType}
+	if contentType, ok := fields["datacontenttype"].(string); ok {
+		e.contentType = contentType
+	}
+	for name, value := range fields {
+		switch name {
+		case "specversion", "datacontenttype":
+			// the spec version is always set by the CLI and the content type is sent as header
+		case "data":
+			if s, ok := value.(string); ok && !strings.Contains(e.contentType, "json") {
+				e.data = []byte(s)
+				continue
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				return event{}, err
+			}
+			e.data = data
+		case "data_base64":
+			data, err := base64.StdEncoding.DecodeString(fmt.Sprint(value))
+			if err != nil {
+				return event{}, errors.Wrap(err, "Could not decode data_base64")
+			}
+			e.data = data
+		default:
+			e.attributes[name] = fmt.Sprint(value)
+		}
+	}
+	if e.attributes["type"] == "" {
+		return event{}, errors.New("The event has no type")
+	}
+	return e, nil
+}
+
+// validateEvents verifies that the types of all events are declared in the subscriptions of the Function
+func validateEvents(events []event, cfg workspace.Cfg) error {
+	for _, e := range events {
+		if _, err := serverless.CloudEventHeaders(e.attributes, cfg); err != nil {
+			return errors.Wrapf(err, "Invalid event from %s", e.origin)
+		}
+	}
+	return nil
+}
+
+// emitEvents waits until the Function accepts requests and sends the events to it one after another
+func (c *command) emitEvents(ctx context.Context, cfg workspace.Cfg, events []event) error {
+	url := fmt.Sprintf("http://localhost:%s", c.opts.FuncPort)
+	if err := waitForFunction(ctx, url+healthPath); err != nil {
+		return err
+	}
+
+	client := &http.Client{}
+	for _, e := range events {
+		headers, err := serverless.CloudEventHeaders(e.attributes, cfg)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(e.data))
+		if err != nil {
+			return err
+		}
+		req.Header = headers
+		req.Header.Set("Content-Type", e.contentType)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return errors.Wrapf(err, "Could not send event '%s'", e.attributes["type"])
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		fmt.Printf("Event '%s' (ID %s) sent: %s %s\n", headers.Get("ce-type"), headers.Get("ce-id"), resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// waitForFunction waits until the Function responds to requests on the given URL
+func waitForFunction(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, eventReadyTimeout)
+	defer cancel()
+
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := http.DefaultClient.Do(req); err == nil {
+			resp.Body.Close()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "The Function did not start accepting requests")
+		case <-time.After(eventReadyPoll):
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package function
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func eventsConfig() workspace.Cfg {
+	return workspace.Cfg{
+		Name: "my-function",
+		Subscriptions: []workspace.Subscription{
+			{
+				Name: "orders",
+				Filter: workspace.Filter{Filters: []workspace.EventFilter{
+					{
+						EventSource: workspace.EventFilterProperty{Property: "source", Value: "my-app"},
+						EventType:   workspace.EventFilterProperty{Property: "type", Value: "order.created.v1"},
+					},
+				}},
+			},
+		},
+	}
+}
+
+func TestLoadEvents(t *testing.T) {
+	t.Run("emit and replay", func(t *testing.T) {
+		c := command{opts: &Options{Emit: "order.created.v1", EventReplay: "/events"}}
+		_, err := c.loadEvents()
+		require.Error(t, err)
+	})
+
+	t.Run("data without emit", func(t *testing.T) {
+		c := command{opts: &Options{Data: "data.json"}}
+		_, err := c.loadEvents()
+		require.Error(t, err)
+	})
+
+	t.Run("no events", func(t *testing.T) {
+		c := command{opts: &Options{}}
+		events, err := c.loadEvents()
+		require.NoError(t, err)
+		require.Empty(t, events)
+	})
+
+	t.Run("emit", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "emit")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		data := filepath.Join(dir, "data.json")
+		require.NoError(t, ioutil.WriteFile(data, []byte(`{"id":1}`), 0600))
+
+		c := command{opts: &Options{Emit: "order.created.v1", Data: data}}
+		events, err := c.loadEvents()
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, "order.created.v1", events[0].attributes["type"])
+		require.Equal(t, `{"id":1}`, string(events[0].data))
+		require.NoError(t, validateEvents(events, eventsConfig()))
+
+		c.opts.Emit = "order.deleted.v1"
+		events, err = c.loadEvents()
+		require.NoError(t, err)
+		require.Error(t, validateEvents(events, eventsConfig()), "Undeclared event types must be rejected")
+	})
+}
+
+func TestReadRecordedEvents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replay")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "02-text.json"),
+		[]byte(`{"specversion":"1.0","type":"order.created.v1","id":"2","datacontenttype":"text/plain","data":"hello"}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "01-json.json"),
+		[]byte(`{"specversion":"1.0","type":"order.created.v1","source":"recorded","id":"1","data":{"orderId":42}}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0600))
+
+	events, err := readRecordedEvents(dir)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	require.Equal(t, map[string]string{"type": "order.created.v1", "source": "recorded", "id": "1"}, events[0].attributes)
+	require.Equal(t, "application/json", events[0].contentType)
+	require.Equal(t, `{"orderId":42}`, string(events[0].data))
+
+	require.Equal(t, "2", events[1].attributes["id"])
+	require.Equal(t, "text/plain", events[1].contentType)
+	require.Equal(t, "hello", string(events[1].data))
+
+	_, err = parseRecordedEvent([]byte(`{"specversion":"1.0","id":"3"}`))
+	require.Error(t, err, "Events without type must be rejected")
+
+	_, err = readRecordedEvents(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func TestEmitEvents(t *testing.T) {
+	var received []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == healthPath {
+			return
+		}
+		received = append(received, r)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	c := command{opts: &Options{FuncPort: serverURL.Port()}}
+	err = c.emitEvents(context.Background(), eventsConfig(), []event{
+		{attributes: map[string]string{"type": "order.created.v1", "id": "1"}, contentType: "application/json", data: []byte(`{}`)},
+		{attributes: map[string]string{"type": "order.created.v1", "source": "other"}, contentType: "application/json"},
+	})
+	require.NoError(t, err)
+	require.Len(t, received, 2)
+
+	require.Equal(t, http.MethodPost, received[0].Method)
+	require.Equal(t, "order.created.v1", received[0].Header.Get("ce-type"))
+	require.Equal(t, "my-app", received[0].Header.Get("ce-source"))
+	require.Equal(t, "1", received[0].Header.Get("ce-id"))
+	require.Equal(t, "application/json", received[0].Header.Get("Content-Type"))
+	require.Equal(t, "other", received[1].Header.Get("ce-source"))
+}
@@ -3,13 +3,18 @@ package function
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/docker/docker/client"
 	"github.com/kyma-incubator/hydroform/function/pkg/docker"
 	"github.com/kyma-incubator/hydroform/function/pkg/docker/runtimes"
 	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
 	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/serverless"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -30,7 +35,8 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "function",
 		Short: "Runs Functions locally.",
-		Long:  `Use this command to run a Function in Docker from local sources.`,
+		Long: `Use this command to run a Function in Docker from local sources.
+To run the Function as it runs on the cluster, build its image with "kyma build function" and run the image with the "--image" flag. The sources and dependencies of the image are used instead of the local sources.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return c.Run()
 		},
@@ -42,12 +48,33 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().BoolVar(&o.Detach, "detach", false, `Change this flag to "true" if you don't want to follow the container logs after running the Function.`)
 	cmd.Flags().StringVarP(&o.FuncPort, "port", "p", "8080", `The port on which the container will be exposed.`)
 	cmd.Flags().BoolVar(&o.HotDeploy, "hot-deploy", false, `Change this flag to "true" if you want to start a Function in Hot Deploy mode.`)
-	cmd.Flags().BoolVar(&o.Debug, "debug", false, `Change this flag to "true" if you want to expose port 9229 for remote debugging.`)
+	cmd.Flags().BoolVar(&o.Debug, "debug", false, `Change this flag to "true" if you want to debug the Function. The debug port 9229 of the Node.js inspector or 5678 of debugpy for Python is exposed, and launch configurations for VS Code and GoLand are printed.`)
+	cmd.Flags().BoolVar(&o.DebugWait, "debug-wait", false, `Change this flag to "true" if you want the Function to wait for a debugger to attach before it starts. Implies "--debug".`)
+	cmd.Flags().BoolVar(&o.Remote, "remote", false, `Change this flag to "true" if you want to debug the Function deployed on the cluster instead of running it locally. The Node.js inspector of the Function is enabled with the NODE_OPTIONS environment variable, and its debug port is forwarded to the local machine until you press Ctrl+C, which restores the Function. Python Functions can only be debugged locally. Requires "--debug".`)
+	cmd.Flags().StringVar(&o.EnvFile, "env-file", "", `Full path to a file with environment variables in the format "NAME=VALUE", which override the environment variables of the Function. With the "--offline" flag, the ".env" file in the folder of the config file is used by default.`)
+	cmd.Flags().BoolVar(&o.Offline, "offline", false, `Change this flag to "true" if you don't want to read the ConfigMaps and Secrets referenced by the environment variables of the Function from the cluster. Their values must be set in the env file instead.`)
+
+	cmd.Flags().StringVar(&o.Image, "image", "", `Image of the Function built with "kyma build function". The Function runs with the sources and dependencies of the image instead of the local sources.`)
+
+	cmd.Flags().StringVar(&o.Emit, "emit", "", `Type of a CloudEvent which is sent to the Function as soon as it is running. The type must be declared in the subscriptions of the config file, which also provide the source of the event.`)
+	cmd.Flags().StringVar(&o.Data, "data", "", `Full path to a JSON file with the data of the event sent with the "--emit" flag.`)
+	cmd.Flags().StringVar(&o.EventReplay, "event-replay", "", `Full path to a folder with recorded CloudEvents in the structured JSON format, one event per file. The events are sent to the Function in the alphabetical order of the file names as soon as the Function is running.`)
 
 	return cmd
 }
 
 func (c *command) Run() error {
+	if c.opts.Image != "" && c.opts.HotDeploy {
+		return errors.New("The --hot-deploy flag can't be used with the --image flag, because the sources of the image can't be changed")
+	}
+
+	if c.opts.DebugWait {
+		c.opts.Debug = true
+	}
+	if c.opts.Remote && !c.opts.Debug {
+		return errors.New("The --remote flag can only be used together with the --debug flag")
+	}
+
 	if err := c.opts.defaultFilename(); err != nil {
 		return err
 	}
@@ -61,42 +88,98 @@ func (c *command) Run() error {
 		return err
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(c.Context())
 	defer cancel()
 
+	if c.opts.Remote {
+		return c.debugRemote(ctx, cfg)
+	}
+
+	envs, err := c.resolveEnvs(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	events, err := c.loadEvents()
+	if err != nil {
+		return err
+	}
+	if err := validateEvents(events, cfg); err != nil {
+		return err
+	}
+
 	client, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
 		return errors.Wrap(err, "white trying to interact with docker")
 	}
 
-	return c.runContainer(ctx, client, cfg)
+	return c.runContainer(ctx, client, cfg, envs, events)
 }
 
-func workspaceConfig(path string) (workspace.Cfg, error) {
-	file, err := os.Open(path)
+// resolveEnvs returns the environment variables of the Function with the values of the referenced ConfigMaps and Secrets
+func (c *command) resolveEnvs(ctx context.Context, cfg workspace.Cfg) ([]string, error) {
+	envFile := c.opts.EnvFile
+	if envFile == "" && c.opts.Offline {
+		envFile = filepath.Join(filepath.Dir(c.opts.Filename), serverless.DefaultEnvFile)
+	}
+
+	overrides := map[string]string{}
+	if envFile != "" {
+		var err error
+		overrides, err = serverless.ReadEnvFile(envFile)
+		// the default env file is optional
+		if os.IsNotExist(err) && c.opts.EnvFile == "" {
+			overrides, err = map[string]string{}, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "while reading the env file")
+		}
+	}
+
+	resolver := &serverless.EnvResolver{Overrides: overrides, Namespace: cfg.Namespace}
+	if !c.opts.Offline && serverless.HasReferences(cfg.Env, overrides) {
+		var err error
+		if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
+			return nil, errors.Wrap(err, "Could not initialize the Kubernetes client to resolve the ConfigMaps and Secrets referenced by the Function. Make sure your kubeconfig is valid or use the --offline flag")
+		}
+		if resolver.Namespace == "" {
+			resolver.Namespace = c.K8s.DefaultNamespace()
+		}
+		resolver.Client = c.K8s.Static()
+	}
+
+	envs, unresolved, err := resolver.Resolve(ctx, cfg.Env)
 	if err != nil {
-		return workspace.Cfg{}, err
+		return nil, err
+	}
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf("The environment variables %s reference ConfigMaps or Secrets and are not set in the env file", strings.Join(unresolved, ", "))
 	}
+	return envs, nil
+}
 
-	var cfg workspace.Cfg
-	if err := yaml.NewDecoder(file).Decode(&cfg); err != nil {
-		return workspace.Cfg{}, errors.Wrap(err, "while trying to decode the configuration file")
+func workspaceConfig(path string) (workspace.Cfg, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return workspace.Cfg{}, err
 	}
 
-	supportedRuntimes := map[string]struct{}{
-		"nodejs12": {},
-		"nodejs14": {},
-		"python38": {},
-		"python39": {},
+	warnings, err := serverless.ValidateConfigFile(path, content)
+	if err != nil {
+		return workspace.Cfg{}, err
 	}
-	if _, ok := supportedRuntimes[cfg.Runtime]; !ok {
-		return workspace.Cfg{}, fmt.Errorf("unsupported runtime: %s", cfg.Runtime)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
 	}
 
+	var cfg workspace.Cfg
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return workspace.Cfg{}, errors.Wrap(err, "while trying to decode the configuration file")
+	}
 	return cfg, nil
 }
 
-func (c *command) runContainer(ctx context.Context, client *client.Client, cfg workspace.Cfg) error {
+func (c *command) runContainer(ctx context.Context, client *client.Client, cfg workspace.Cfg, envs []string, events []event) error {
 	step := c.NewStep(fmt.Sprintf("Running container: %s", c.opts.ContainerName))
 	ports := map[string]string{
 		runtimes.ServerPort: c.opts.FuncPort,
@@ -107,18 +190,30 @@ func (c *command) runContainer(ctx context.Context, client *client.Client, cfg w
 		ports[debugPort] = debugPort
 	}
 
-	id, err := docker.RunContainer(ctx, client, docker.RunOpts{
-		Ports: ports,
-		Envs: append(
-			runtimes.ContainerEnvs(cfg.Runtime, c.opts.HotDeploy),
-			c.parseEnvs(cfg.Env)...,
-		),
-		ContainerName: c.opts.ContainerName,
-		Commands:      runtimes.ContainerCommands(cfg.Runtime, c.opts.Debug, c.opts.HotDeploy),
-		Image:         runtimes.ContainerImage(cfg.Runtime),
-		WorkDir:       c.opts.Dir,
-		User:          runtimes.ContainerUser(cfg.Runtime),
-	})
+	var id string
+	var err error
+	if c.opts.Image != "" {
+		id, err = runImage(ctx, client, imageRunOpts{
+			Ports:         ports,
+			Envs:          envs,
+			ContainerName: c.opts.ContainerName,
+			Commands:      debugCommands(imageCommands(cfg.Runtime, c.opts.Debug), c.opts.DebugWait),
+			Image:         c.opts.Image,
+		})
+	} else {
+		id, err = docker.RunContainer(ctx, client, docker.RunOpts{
+			Ports: ports,
+			Envs: append(
+				runtimes.ContainerEnvs(cfg.Runtime, c.opts.HotDeploy),
+				envs...,
+			),
+			ContainerName: c.opts.ContainerName,
+			Commands:      debugCommands(runtimes.ContainerCommands(cfg.Runtime, c.opts.Debug, c.opts.HotDeploy), c.opts.DebugWait),
+			Image:         runtimes.ContainerImage(cfg.Runtime),
+			WorkDir:       c.opts.Dir,
+			User:          runtimes.ContainerUser(cfg.Runtime),
+		})
+	}
 	if err != nil {
 		step.Failure()
 		return errors.Wrap(err, "while trying to run container")
@@ -126,19 +221,35 @@ func (c *command) runContainer(ctx context.Context, client *client.Client, cfg w
 
 	step.Successf("Ran container: %s", c.opts.ContainerName)
 	step.LogInfo("Container listening on port: " + runtimes.ServerPort)
-	if !c.opts.Detach {
-		fmt.Println("Logs from the container:")
-		followCtx := context.Background()
-		c.Finalizers.Add(docker.Stop(followCtx, client, id, func(i ...interface{}) { fmt.Print(i...) }))
-		return docker.FollowRun(followCtx, client, id, func(i ...interface{}) { fmt.Print(i...) })
+	if c.opts.Debug {
+		debugPort := runtimes.RuntimeDebugPort(cfg.Runtime)
+		if c.opts.DebugWait {
+			step.LogInfof("The Function waits for a debugger to attach on port: %s", debugPort)
+		} else {
+			step.LogInfo("Debugger listening on port: " + debugPort)
+		}
+		if err := printLaunchConfigurations(os.Stdout, cfg, debugPort, c.opts.Dir, c.opts.HotDeploy); err != nil {
+			return err
+		}
+	}
+	if c.opts.Detach {
+		if len(events) > 0 {
+			return c.emitEvents(ctx, cfg, events)
+		}
+		return nil
 	}
-	return nil
-}
 
-func (c *command) parseEnvs(envVars []workspace.EnvVar) []string {
-	var envs []string
-	for _, env := range envVars {
-		envs = append(envs, fmt.Sprintf("%s:%s", env.Name, env.Value))
+	if len(events) > 0 {
+		// the events are sent while the logs are followed, so the logs show how the Function processes them
+		go func() {
+			if err := c.emitEvents(ctx, cfg, events); err != nil {
+				fmt.Printf("%s\n", err)
+			}
+		}()
 	}
-	return envs
+
+	fmt.Println("Logs from the container:")
+	followCtx := context.Background()
+	c.Finalizers.Add(docker.Stop(followCtx, client, id, func(i ...interface{}) { fmt.Print(i...) }))
+	return docker.FollowRun(followCtx, client, id, func(i ...interface{}) { fmt.Print(i...) })
 }
@@ -21,6 +21,14 @@ func TestFunctionFlags(t *testing.T) {
 	require.Equal(t, false, o.Detach, "Default value for the --detach flag not as expected.")
 	require.Equal(t, false, o.HotDeploy, "Default value for the --hot-deploy flag not as expected.")
 	require.Equal(t, false, o.Debug, "Default value for the --debug flag not as expected.")
+	require.Equal(t, "", o.EnvFile, "Default value for the --env-file flag not as expected.")
+	require.Equal(t, false, o.Offline, "Default value for the --offline flag not as expected.")
+	require.Equal(t, "", o.Emit, "Default value for the --emit flag not as expected.")
+	require.Equal(t, "", o.Data, "Default value for the --data flag not as expected.")
+	require.Equal(t, "", o.EventReplay, "Default value for the --event-replay flag not as expected.")
+	require.Equal(t, "", o.Image, "Default value for the --image flag not as expected.")
+	require.Equal(t, false, o.DebugWait, "Default value for the --debug-wait flag not as expected.")
+	require.Equal(t, false, o.Remote, "Default value for the --remote flag not as expected.")
 
 	// test passing flags
 	err := c.ParseFlags([]string{
@@ -31,6 +39,14 @@ func TestFunctionFlags(t *testing.T) {
 		"--detach", "true",
 		"--hot-deploy", "true",
 		"--debug", "true",
+		"--env-file", "/test/.env",
+		"--offline", "true",
+		"--emit", "order.created.v1",
+		"--data", "/test/data.json",
+		"--event-replay", "/test/events",
+		"--image", "orders:latest",
+		"--debug-wait",
+		"--remote",
 	})
 
 	require.NoError(t, err, "Parsing flags should not return an error")
@@ -41,6 +57,14 @@ func TestFunctionFlags(t *testing.T) {
 	require.Equal(t, true, o.Detach, "The parsed value for the --detach flag not as expected.")
 	require.Equal(t, true, o.HotDeploy, "The parsed value for the --hot-deploy flag not as expected.")
 	require.Equal(t, true, o.Debug, "The parsed value for the --debug flag not as expected.")
+	require.Equal(t, "/test/.env", o.EnvFile, "The parsed value for the --env-file flag not as expected.")
+	require.Equal(t, true, o.Offline, "The parsed value for the --offline flag not as expected.")
+	require.Equal(t, "order.created.v1", o.Emit, "The parsed value for the --emit flag not as expected.")
+	require.Equal(t, "/test/data.json", o.Data, "The parsed value for the --data flag not as expected.")
+	require.Equal(t, "/test/events", o.EventReplay, "The parsed value for the --event-replay flag not as expected.")
+	require.Equal(t, "orders:latest", o.Image, "The parsed value for the --image flag not as expected.")
+	require.Equal(t, true, o.DebugWait, "The parsed value for the --debug-wait flag not as expected.")
+	require.Equal(t, true, o.Remote, "The parsed value for the --remote flag not as expected.")
 
 	err = c.ParseFlags([]string{
 		"-f", "test-name",
@@ -54,3 +78,10 @@ func TestFunctionFlags(t *testing.T) {
 	require.Equal(t, "test-container", o.ContainerName, "The parsed value for the --containerName flag not as expected.")
 	require.Equal(t, "9091", o.FuncPort, "The parsed value for the --port flag not as expected.")
 }
+
+func TestImageCommands(t *testing.T) {
+	t.Parallel()
+	require.Empty(t, imageCommands("nodejs14", false))
+	require.Equal(t, []string{"node --inspect=0.0.0.0 kubeless.js "}, imageCommands("nodejs14", true))
+	require.Equal(t, []string{"pip install debugpy", "python -m debugpy --listen 0.0.0.0:5678 kubeless.py"}, imageCommands("python39", true))
+}
@@ -0,0 +1,61 @@
+package function
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/kyma-incubator/hydroform/function/pkg/docker/runtimes"
+	"github.com/kyma-project/cli/pkg/docker"
+)
+
+// imageRunOpts are the options to run a Function from an image built with "kyma build function"
+type imageRunOpts struct {
+	Ports         map[string]string
+	Envs          []string
+	ContainerName string
+	Image         string
+	// Commands override the command of the image if they are set
+	Commands []string
+}
+
+// runImage starts a container of the image. Unlike for the local sources, no folder is mounted, because the image contains the sources and dependencies.
+func runImage(ctx context.Context, c *client.Client, opts imageRunOpts) (string, error) {
+	config := &container.Config{
+		Env:          opts.Envs,
+		ExposedPorts: nat.PortSet{},
+		Image:        opts.Image,
+	}
+	if len(opts.Commands) > 0 {
+		config.Cmd = []string{"/bin/sh", "-c", strings.Join(opts.Commands, ";")}
+	}
+	hostConfig := &container.HostConfig{
+		PortBindings: nat.PortMap{},
+		AutoRemove:   true,
+	}
+	for from, to := range opts.Ports {
+		config.ExposedPorts[nat.Port(from)] = struct{}{}
+		hostConfig.PortBindings[nat.Port(from)] = []nat.PortBinding{{HostPort: to}}
+	}
+
+	// the image may have been pushed to a registry but not built locally, in which case it is pulled
+	return docker.RunContainer(ctx, c, config, hostConfig, opts.ContainerName, os.Stdout)
+}
+
+// imageCommands returns the commands which start the Function in debug mode. The dependencies are not installed, because the image contains them.
+func imageCommands(runtime string, debug bool) []string {
+	if !debug {
+		return nil
+	}
+	install := runtimes.ContainerCommands(runtime, false, false)[0]
+	var commands []string
+	for _, command := range runtimes.ContainerCommands(runtime, true, false) {
+		if command != install {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
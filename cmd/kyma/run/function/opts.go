@@ -19,7 +19,15 @@ type Options struct {
 	FuncPort      string
 	Detach        bool
 	Debug         bool
+	DebugWait     bool
+	Remote        bool
 	HotDeploy     bool
+	EnvFile       string
+	Offline       bool
+	Emit          string
+	Data          string
+	EventReplay   string
+	Image         string
 }
 
 //NewOptions creates options with default values
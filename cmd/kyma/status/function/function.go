@@ -0,0 +1,241 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/output"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new status function command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		opts:    o,
+		Command: cli.Command{Options: o.Options},
+	}
+	cmd := &cobra.Command{
+		Use:   "function <name>",
+		Short: "Shows the status of a Function.",
+		Long: `Use this command to show the conditions of the given Function, the state of its latest build, its runtime image and replicas, and the readiness of the APIRules and Subscriptions of the Function.
+To check the logs of the Function or of its build, use the "kyma logs function" command.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.Run(args[0])
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing name of the function")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", `Namespace of the Function.`)
+	output.Enable(cmd, &o.OutputFormat, output.JSON, output.YAML, output.None, output.JSONPath, output.Template)
+
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run(name string) error {
+	var err error
+	if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
+		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
+	}
+	c.opts.setDefaults(c.K8s.DefaultNamespace())
+
+	ctx := c.Context()
+	status, err := serverless.GetStatus(ctx, c.K8s.Dynamic(), c.opts.Namespace, name)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("Function '%s' not found in Namespace '%s'", name, c.opts.Namespace)
+		}
+		return errors.Wrapf(err, "Could not get Function '%s'", name)
+	}
+
+	result := functionStatus{
+		Name:       name,
+		Namespace:  c.opts.Namespace,
+		Running:    status.Running(),
+		Observed:   status.Observed,
+		Conditions: status.Conditions,
+	}
+	c.getBuild(ctx, &result)
+	c.getDeployment(ctx, &result)
+	result.APIRules, result.apiRulesErr = serverless.APIRules(ctx, c.K8s.Dynamic(), c.opts.Namespace, name)
+	result.Subscriptions, result.subscriptionsErr = serverless.Subscriptions(ctx, c.K8s.Dynamic(), c.opts.Namespace, name)
+
+	if c.StructuredOutput() {
+		result.warn()
+		return c.PrintResult(result)
+	}
+	result.print()
+	return nil
+}
+
+//functionStatus is the result of the command. The resources which could not be read are printed as not available, or as warnings for structured output.
+type functionStatus struct {
+	Name          string                      `json:"name"`
+	Namespace     string                      `json:"namespace"`
+	Running       bool                        `json:"running"`
+	Observed      bool                        `json:"observed"`
+	Conditions    []serverless.Condition      `json:"conditions"`
+	Build         *buildStatus                `json:"build,omitempty"`
+	Deployment    *deploymentStatus           `json:"deployment,omitempty"`
+	APIRules      []serverless.ResourceStatus `json:"apiRules"`
+	Subscriptions []serverless.ResourceStatus `json:"subscriptions"`
+
+	buildErr         error
+	deploymentErr    error
+	apiRulesErr      error
+	subscriptionsErr error
+}
+
+//buildStatus is the state of the latest build Job of the Function
+type buildStatus struct {
+	Job     string    `json:"job"`
+	State   string    `json:"state"`
+	Started time.Time `json:"started"`
+}
+
+//deploymentStatus is the state of the runtime Deployment of the Function
+type deploymentStatus struct {
+	Images        []string `json:"images"`
+	ReadyReplicas int32    `json:"readyReplicas"`
+	Replicas      int32    `json:"replicas"`
+}
+
+func (c *command) getBuild(ctx context.Context, result *functionStatus) {
+	job, err := serverless.BuildJob(ctx, c.K8s.Static(), c.opts.Namespace, result.Name)
+	if err != nil || job == nil {
+		result.buildErr = err
+		return
+	}
+	result.Build = &buildStatus{
+		Job:     job.Name,
+		State:   serverless.JobState(*job),
+		Started: job.CreationTimestamp.Time,
+	}
+}
+
+func (c *command) getDeployment(ctx context.Context, result *functionStatus) {
+	deployment, err := serverless.Deployment(ctx, c.K8s.Static(), c.opts.Namespace, result.Name)
+	if err != nil || deployment == nil {
+		result.deploymentErr = err
+		return
+	}
+	result.Deployment = &deploymentStatus{
+		ReadyReplicas: deployment.Status.ReadyReplicas,
+		Replicas:      1,
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		result.Deployment.Images = append(result.Deployment.Images, container.Image)
+	}
+	if deployment.Spec.Replicas != nil {
+		result.Deployment.Replicas = *deployment.Spec.Replicas
+	}
+}
+
+//warn prints the resources which could not be read to the standard error
+func (s functionStatus) warn() {
+	kinds := []string{"build Job", "Deployment", "APIRules", "Subscriptions"}
+	for i, err := range []error{s.buildErr, s.deploymentErr, s.apiRulesErr, s.subscriptionsErr} {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read the %s of the Function: %s\n", kinds[i], err)
+		}
+	}
+}
+
+func (s functionStatus) print() {
+	fmt.Printf("Function:   %s\n", s.Name)
+	fmt.Printf("Namespace:  %s\n", s.Namespace)
+	fmt.Printf("Running:    %t\n", s.Running)
+	if !s.Observed {
+		fmt.Printf("            the latest change of the Function is not processed yet\n")
+	}
+	fmt.Println()
+	printConditions(s.Conditions, os.Stdout)
+
+	fmt.Println()
+	s.printBuild()
+	s.printDeployment()
+
+	printResources("APIRules", []string{"APIRULE", "READY", "HOST"}, s.APIRules, s.apiRulesErr)
+	printResources("Subscriptions", []string{"SUBSCRIPTION", "READY", "EVENT TYPES"}, s.Subscriptions, s.subscriptionsErr)
+}
+
+func printConditions(conditions []serverless.Condition, out io.Writer) {
+	if len(conditions) == 0 {
+		fmt.Fprintln(out, "No conditions reported yet.")
+		return
+	}
+	writer := output.NewTableWriter([]string{"CONDITION", "STATUS", "REASON", "LAST TRANSITION", "MESSAGE"}, out)
+	for _, cond := range conditions {
+		writer.Append([]string{cond.Type, cond.Status, cond.Reason, formatTime(cond.LastTransitionTime), cond.Message})
+	}
+	writer.Render()
+}
+
+func (s functionStatus) printBuild() {
+	switch {
+	case s.buildErr != nil:
+		fmt.Printf("Build:      not available (%s)\n", s.buildErr)
+	case s.Build == nil:
+		fmt.Printf("Build:      no build Job found\n")
+	default:
+		fmt.Printf("Build:      %s (%s, started %s)\n", s.Build.Job, s.Build.State, formatTime(s.Build.Started))
+	}
+}
+
+func (s functionStatus) printDeployment() {
+	switch {
+	case s.deploymentErr != nil:
+		fmt.Printf("Image:      not available (%s)\n", s.deploymentErr)
+	case s.Deployment == nil:
+		fmt.Printf("Image:      the Function is not deployed\n")
+	default:
+		for _, image := range s.Deployment.Images {
+			fmt.Printf("Image:      %s\n", image)
+		}
+		fmt.Printf("Replicas:   %d/%d ready\n", s.Deployment.ReadyReplicas, s.Deployment.Replicas)
+	}
+}
+
+func printResources(kind string, columns []string, resources []serverless.ResourceStatus, err error) {
+	fmt.Println()
+	if err != nil {
+		fmt.Printf("%s not available: %s\n", kind, err)
+		return
+	}
+	if len(resources) == 0 {
+		fmt.Printf("No %s found.\n", kind)
+		return
+	}
+	writer := output.NewTableWriter(columns, os.Stdout)
+	for _, r := range resources {
+		writer.Append([]string{r.Name, fmt.Sprintf("%t", r.Ready), r.Details})
+	}
+	writer.Render()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(timeFormat)
+}
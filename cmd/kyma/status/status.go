@@ -0,0 +1,19 @@
+package status
+
+import (
+	"github.com/kyma-project/cli/cmd/kyma/status/function"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new status command
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows the status of resources on the Kyma cluster.",
+		Long:  "Use this command to check whether the given resource is deployed and ready on the Kyma cluster. Currently, you can only use it for Functions.",
+	}
+
+	cmd.AddCommand(function.NewCmd(function.NewOptions(o)))
+	return cmd
+}
@@ -2,14 +2,22 @@ package function
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/kyma-incubator/hydroform/function/pkg/client"
+	"github.com/kyma-incubator/hydroform/function/pkg/operator"
 	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
 	"github.com/kyma-project/cli/internal/cli"
 	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/kyma-project/cli/pkg/step"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -28,11 +36,26 @@ func NewCmd(o *Options) *cobra.Command {
 		Use:   "function",
 		Short: "Synchronizes the local resources for your Function.",
 		Long: `Use this command to download the Function's code and dependencies from the cluster to create or update these resources in your local workspace.
-Use the flags to specify the name of your Function, the Namespace, or the location for your project.`,
+Use the flags to specify the name of your Function, the Namespace, or the location for your project.
+Use the "--all" flag to synchronize all Functions of the Namespace. Each Function is saved in a subfolder named after the Function.
+The command records the state of the Function in the "` + serverless.SyncStateFile + `" file to detect which files changed locally and which changed on the cluster since the last synchronization. Files changed only on the cluster are updated, and files changed only locally are kept.
+Use the "--strategy" flag to resolve the conflicts of files changed on both sides, and the "--diff" flag to see the differences between the local files and the cluster without changing anything.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.validateFlags(); err != nil {
+				return err
+			}
+			if o.All {
+				return c.RunAll()
+			}
 			return c.Run(args[0])
 		},
 		Args: func(cmd *cobra.Command, args []string) error {
+			if o.All {
+				if len(args) != 0 {
+					return errors.New("the name of the function cannot be used with the --all flag")
+				}
+				return nil
+			}
 			if len(args) != 1 {
 				return errors.New("missing name of the function")
 			}
@@ -42,6 +65,9 @@ Use the flags to specify the name of your Function, the Namespace, or the locati
 
 	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", `Namespace from which you want to sync the Function.`)
 	cmd.Flags().StringVarP(&o.Dir, "dir", "d", "", `Full path to the directory where you want to save the project.`)
+	cmd.Flags().BoolVar(&o.All, "all", false, `Synchronizes all Functions of the Namespace into subfolders of the directory.`)
+	cmd.Flags().BoolVar(&o.Diff, "diff", false, `Shows the differences between the local files and the Function on the cluster without changing the local files.`)
+	cmd.Flags().StringVar(&o.Strategy, "strategy", strategyCluster, `Resolves the conflicts of files changed both locally and on the cluster since the last synchronization. Use "cluster" to overwrite the local files, "local" to keep them without changing the Function on the cluster, or "prompt" to decide for each file. Run "kyma apply function" to update the Function on the cluster with the kept files.`)
 
 	return cmd
 }
@@ -65,23 +91,188 @@ func (c *command) Run(name string) error {
 		}
 	}
 
-	ctx := context.Background()
+	msg, err := c.sync(c.Context(), s, name, c.opts.Dir)
+	if err != nil {
+		s.Failure()
+		return err
+	}
+	s.Successf("%s", msg)
+	return nil
+}
+
+//RunAll synchronises all Functions of the Namespace, each into a subfolder of the directory
+func (c *command) RunAll() error {
+	s := c.NewStep("Listing Functions")
+	var err error
+	if c.K8s, err = kube.NewFromConfig("", c.KubeconfigPath); err != nil {
+		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid")
+	}
+
+	if err := c.opts.setDefaults(c.K8s.DefaultNamespace()); err != nil {
+		s.Failure()
+		return err
+	}
+
+	ctx := c.Context()
+	list, err := c.K8s.Dynamic().Resource(operator.GVRFunction).Namespace(c.opts.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		s.Failure()
+		return errors.Wrapf(err, "Could not list the Functions in Namespace '%s'", c.opts.Namespace)
+	}
+	if len(list.Items) == 0 {
+		s.Failure()
+		return fmt.Errorf("No Functions found in Namespace '%s'", c.opts.Namespace)
+	}
+	s.Successf("Found %d Functions in Namespace '%s'", len(list.Items), c.opts.Namespace)
 
-	var buildClient client.Build = func(namespace string, resource schema.GroupVersionResource) client.Client {
-		return c.K8s.Dynamic().Resource(resource).Namespace(namespace)
+	var failed []string
+	for _, item := range list.Items {
+		name := item.GetName()
+		dir := filepath.Join(c.opts.Dir, name)
+		s := c.NewStep(fmt.Sprintf("Synchronising Function '%s'", name))
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			s.Failuref("Could not create the directory for Function '%s': %s", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		msg, err := c.sync(ctx, s, name, dir)
+		if err != nil {
+			s.Failuref("Could not synchronise Function '%s': %s", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		s.Successf("%s", msg)
 	}
 
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d Functions could not be synchronised: %s", len(failed), len(list.Items), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+//sync synchronises the Function into the directory and returns the summary of the changes
+func (c *command) sync(ctx context.Context, s step.Step, name, dir string) (string, error) {
+	state, err := serverless.LoadSyncState(dir)
+	if err != nil {
+		return "", err
+	}
 	cfg := workspace.Cfg{
 		Name:      name,
 		Namespace: c.opts.Namespace,
 	}
+	clusterDir, resourceVersion, err := serverless.DownloadFunction(ctx, cfg, dir, c.buildClient)
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(clusterDir)
 
-	err = workspace.Synchronise(ctx, cfg, c.opts.Dir, buildClient)
+	diffs, err := serverless.DiffFunction(dir, clusterDir, state)
 	if err != nil {
-		s.Failure()
-		return err
+		return "", err
+	}
+	editedOnCluster := state.ResourceVersion != "" && state.ResourceVersion != resourceVersion
+	if editedOnCluster && hasClusterChanges(diffs) {
+		s.LogInfof("Function '%s' was changed on the cluster since the last synchronization", name)
 	}
 
-	s.Successf("Function synchronised in %s", c.opts.Dir)
-	return nil
+	if c.opts.Diff {
+		return c.printDiffs(name, diffs)
+	}
+
+	var updated, kept []string
+	for _, diff := range diffs {
+		if !diff.Changed() {
+			continue
+		}
+		useCluster, err := c.resolve(s, diff)
+		if err != nil {
+			return "", err
+		}
+		if !useCluster {
+			kept = append(kept, diff.Name)
+			continue
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, diff.Name), diff.Cluster, 0600); err != nil {
+			return "", errors.Wrapf(err, "Could not write file '%s'", diff.Name)
+		}
+		updated = append(updated, diff.Name)
+	}
+
+	if err := serverless.NewSyncState(resourceVersion, diffs).Save(dir); err != nil {
+		return "", errors.Wrap(err, "Could not record the state of the synchronization")
+	}
+
+	msg := fmt.Sprintf("Function '%s' synchronised in %s", name, dir)
+	if len(updated) > 0 {
+		msg += fmt.Sprintf(", updated: %s", strings.Join(updated, ", "))
+	}
+	if len(kept) > 0 {
+		msg += fmt.Sprintf(", kept local: %s. The kept files differ from the Function on the cluster, run \"kyma apply function\" to update it", strings.Join(kept, ", "))
+	}
+	return msg, nil
+}
+
+//resolve decides whether the local file is overwritten by the file on the cluster
+func (c *command) resolve(s step.Step, diff serverless.FileDiff) (bool, error) {
+	if !diff.Conflict() {
+		return diff.ClusterChanged, nil
+	}
+	switch c.opts.Strategy {
+	case strategyLocal:
+		return false, nil
+	case strategyPrompt:
+		unified, err := diff.Unified()
+		if err != nil {
+			return false, err
+		}
+		fmt.Print(unified)
+		for {
+			answer, err := s.Prompt(fmt.Sprintf("File '%s' changed locally and on the cluster. Keep the [l]ocal or the [c]luster version? ", diff.Name))
+			if err != nil {
+				return false, err
+			}
+			switch strings.ToLower(answer) {
+			case "l", "local":
+				return false, nil
+			case "c", "cluster":
+				return true, nil
+			}
+		}
+	default:
+		return true, nil
+	}
+}
+
+//printDiffs prints the differences between the local files and the cluster
+func (c *command) printDiffs(name string, diffs []serverless.FileDiff) (string, error) {
+	changed := 0
+	for _, diff := range diffs {
+		if !diff.Changed() {
+			continue
+		}
+		changed++
+		unified, err := diff.Unified()
+		if err != nil {
+			return "", err
+		}
+		fmt.Printf("# %s\n%s", diff, unified)
+	}
+	if changed == 0 {
+		return fmt.Sprintf("Function '%s' is up to date", name), nil
+	}
+	return fmt.Sprintf("%d files of Function '%s' differ from the cluster", changed, name), nil
+}
+
+//hasClusterChanges tells whether any file changed on the cluster since the last synchronization
+func hasClusterChanges(diffs []serverless.FileDiff) bool {
+	for _, diff := range diffs {
+		if diff.Changed() && diff.ClusterChanged {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *command) buildClient(namespace string, resource schema.GroupVersionResource) client.Client {
+	return c.K8s.Dynamic().Resource(resource).Namespace(namespace)
 }
@@ -16,14 +16,59 @@ func TestFunctionFlags(t *testing.T) {
 	// test default flag values
 	require.Empty(t, o.Namespace, "Default value for the --namespace flag not as expected.")
 	require.Equal(t, "", o.Dir, "Default value for the --dir flag not as expected.")
+	require.Equal(t, false, o.All, "Default value for the --all flag not as expected.")
+	require.Equal(t, false, o.Diff, "Default value for the --diff flag not as expected.")
+	require.Equal(t, "cluster", o.Strategy, "Default value for the --strategy flag not as expected.")
 
 	// test passing flags
 	err := c.ParseFlags([]string{
 		"--dir", "/fakepath",
 		"--namespace", "test-namespace",
+		"--all",
+		"--diff",
+		"--strategy", "local",
 	})
 
 	require.NoError(t, err, "Parsing flags should not return an error")
 	require.Equal(t, "/fakepath", o.Dir, "The parsed value for the --dir flag not as expected.")
 	require.Equal(t, "test-namespace", o.Namespace, "The parsed value for the --namespace flag not as expected.")
+	require.Equal(t, true, o.All, "The parsed value for the --all flag not as expected.")
+	require.Equal(t, true, o.Diff, "The parsed value for the --diff flag not as expected.")
+	require.Equal(t, "local", o.Strategy, "The parsed value for the --strategy flag not as expected.")
+}
+
+func TestValidateFlags(t *testing.T) {
+	t.Parallel()
+	o := NewOptions(&cli.Options{})
+	for _, strategy := range []string{"cluster", "local", "prompt"} {
+		o.Strategy = strategy
+		require.NoError(t, o.validateFlags())
+	}
+
+	// keep-local is an alias of local
+	o.Strategy = "keep-local"
+	require.NoError(t, o.validateFlags())
+	require.Equal(t, "local", o.Strategy)
+
+	for _, strategy := range []string{"newest", "remote"} {
+		o.Strategy = strategy
+		require.Error(t, o.validateFlags())
+	}
+
+	o.Strategy = "prompt"
+	o.CI = true
+	require.Error(t, o.validateFlags(), "Prompting is not possible in the CI mode")
+}
+
+func TestFunctionArgs(t *testing.T) {
+	t.Parallel()
+	o := NewOptions(&cli.Options{})
+	c := NewCmd(o)
+
+	require.NoError(t, c.Args(c, []string{"my-function"}))
+	require.Error(t, c.Args(c, []string{}), "The name of the Function is required without the --all flag")
+
+	o.All = true
+	require.NoError(t, c.Args(c, []string{}))
+	require.Error(t, c.Args(c, []string{"my-function"}), "The name of the Function cannot be used with the --all flag")
 }
@@ -1,6 +1,7 @@
 package function
 
 import (
+	"fmt"
 	"os"
 	"time"
 
@@ -13,15 +14,45 @@ type Options struct {
 
 	Namespace string
 	Dir       string
+	All       bool
+	Diff      bool
+	Strategy  string
 	Timeout   time.Duration
 }
 
+// strategies resolve the conflicts between the local files and the files on the cluster
+const (
+	strategyCluster = "cluster"
+	strategyLocal   = "local"
+	strategyPrompt  = "prompt"
+	// strategyKeepLocal is an alias of strategyLocal
+	strategyKeepLocal = "keep-local"
+)
+
 //NewOptions creates options with default values
 func NewOptions(o *cli.Options) *Options {
 	options := &Options{Options: o}
 	return options
 }
 
+// validateFlags applies a sanity check on provided options
+func (o *Options) validateFlags() error {
+	switch o.Strategy {
+	case strategyCluster, strategyLocal:
+		return nil
+	case strategyKeepLocal:
+		o.Strategy = strategyLocal
+		return nil
+	case strategyPrompt:
+		if o.CI {
+			return fmt.Errorf("The '%s' strategy cannot be used in the CI mode", strategyPrompt)
+		}
+		return nil
+	default:
+		return fmt.Errorf("Invalid strategy '%s'. Use '%s', '%s', or '%s'", o.Strategy, strategyCluster, strategyLocal, strategyPrompt)
+	}
+}
+
 func (o *Options) setDefaults(defaultNamespace string) (err error) {
 	if o.Dir == "" {
 		o.Dir, err = os.Getwd()
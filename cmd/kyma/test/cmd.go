@@ -7,8 +7,8 @@ import (
 func NewCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "test",
-		Short: "Runs tests on a provisioned Kyma cluster.",
-		Long:  "Use this command to run tests on a provisioned Kyma cluster.",
+		Short: "Runs tests on a provisioned Kyma cluster or the unit tests of a Function.",
+		Long:  "Use this command to run tests on a provisioned Kyma cluster, or to run the unit tests of a Function locally with the \"function\" subcommand.",
 	}
 	return cmd
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/kyma-project/cli/internal/cli"
 	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/output"
 	"github.com/kyma-project/cli/pkg/api/octopus"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -29,6 +30,7 @@ func NewCmd(o *Options) *cobra.Command {
 		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
 		Aliases: []string{"def"},
 	}
+	output.Enable(cobraCmd, &o.OutputFormat)
 	return cobraCmd
 }
 
@@ -42,6 +44,9 @@ func (cmd *command) Run() error {
 	if err != nil {
 		return err
 	}
+	if cmd.StructuredOutput() {
+		return cmd.PrintResult(definitions(testDefs))
+	}
 	if len(testDefs) == 0 {
 		fmt.Println("No test definitions found")
 		return nil
@@ -53,6 +58,18 @@ func (cmd *command) Run() error {
 	return nil
 }
 
+//definitions are the names of the test definitions printed by the command
+type definitions []string
+
+//TableRows returns the names of the test definitions as table rows
+func (d definitions) TableRows(wide bool) ([]string, [][]string) {
+	rows := make([][]string, 0, len(d))
+	for _, name := range d {
+		rows = append(rows, []string{name})
+	}
+	return []string{"TEST DEFINITION"}, rows
+}
+
 func listTestDefinitionNames(cli octopus.Interface) ([]string, error) {
 	defs, err := cli.ListTestDefinitions(metav1.ListOptions{})
 	if err != nil {
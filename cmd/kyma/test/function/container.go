@@ -0,0 +1,128 @@
+package function
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/kyma-incubator/hydroform/function/pkg/docker/runtimes"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/kyma-project/cli/pkg/docker"
+	"github.com/pkg/errors"
+)
+
+// testContainerOpts are the options of the runtime container which runs the tests of a Function
+type testContainerOpts struct {
+	Image   string
+	User    string
+	Envs    []string
+	WorkDir string
+	Command string
+}
+
+// testResult is the result of the tests run in the container
+type testResult struct {
+	ExitCode int
+	Output   string
+	// Report is the JUnit XML report written by the test framework, if it writes one
+	Report []byte
+}
+
+// createTestContainer creates the container with the sources of the Function mounted like by "kyma run function". Unlike a running Function, the container is not removed automatically, because its exit code and report are read after it stops.
+func createTestContainer(ctx context.Context, c *client.Client, opts testContainerOpts) (string, error) {
+	config := &container.Config{
+		Env:          opts.Envs,
+		Image:        opts.Image,
+		Cmd:          []string{"/bin/sh", "-c", opts.Command},
+		User:         opts.User,
+		AttachStdout: true,
+		AttachStderr: true,
+	}
+	hostConfig := &container.HostConfig{
+		Mounts: []mount.Mount{
+			{
+				Type:   mount.TypeBind,
+				Source: opts.WorkDir,
+				Target: runtimes.KubelessPath,
+			},
+		},
+	}
+
+	return docker.CreateContainer(ctx, c, config, hostConfig, "", os.Stdout)
+}
+
+// runTestContainer starts the container, copies its output to out until it stops, and reads the report of the test framework
+func runTestContainer(ctx context.Context, c *client.Client, id string, out io.Writer) (testResult, error) {
+	attach, err := c.ContainerAttach(ctx, id, types.ContainerAttachOptions{
+		Stream: true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return testResult{}, err
+	}
+	defer attach.Close()
+
+	// the wait starts before the container, so that the exit can't be missed
+	statusCh, errCh := c.ContainerWait(ctx, id, container.WaitConditionNextExit)
+	if err := c.ContainerStart(ctx, id, types.ContainerStartOptions{}); err != nil {
+		return testResult{}, err
+	}
+
+	output := &bytes.Buffer{}
+	copied := make(chan error, 1)
+	go func() {
+		writer := io.MultiWriter(out, output)
+		_, err := stdcopy.StdCopy(writer, writer, attach.Reader)
+		copied <- err
+	}()
+
+	var result testResult
+	select {
+	case status := <-statusCh:
+		if status.Error != nil {
+			return testResult{}, errors.New(status.Error.Message)
+		}
+		result.ExitCode = int(status.StatusCode)
+	case err := <-errCh:
+		return testResult{}, err
+	}
+	if err := <-copied; err != nil {
+		return testResult{}, errors.Wrap(err, "while reading the output of the tests")
+	}
+	result.Output = output.String()
+
+	result.Report, err = readReport(ctx, c, id)
+	return result, err
+}
+
+// readReport reads the JUnit XML report from the stopped container. The report is empty if the test framework didn't write one.
+func readReport(ctx context.Context, c *client.Client, id string) ([]byte, error) {
+	archive, _, err := c.CopyFromContainer(ctx, id, serverless.TestReportPath)
+	if client.IsErrNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "while reading the report of the tests")
+	}
+	defer archive.Close()
+
+	reader := tar.NewReader(archive)
+	if _, err := reader.Next(); err != nil {
+		return nil, errors.Wrap(err, "while reading the report of the tests")
+	}
+	return ioutil.ReadAll(reader)
+}
+
+// removeContainer removes the container, even if it is still running
+func removeContainer(c *client.Client, id string) error {
+	return c.ContainerRemove(context.Background(), id, types.ContainerRemoveOptions{Force: true})
+}
@@ -0,0 +1,192 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/kyma-incubator/hydroform/function/pkg/docker/runtimes"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/junitxml"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new test function command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		opts:    o,
+		Command: cli.Command{Options: o.Options},
+	}
+	cmd := &cobra.Command{
+		Use:   "function",
+		Short: "Runs the unit tests of a Function locally.",
+		Long: `Use this command to run the unit tests of a Function in Docker, inside the same runtime container which is used by "kyma run function". No cluster is required.
+The folder with the sources of the Function is mounted into the container, the dependencies including the development ones are installed, and the tests are run with "npm test" for Node.js Functions and with pytest for Python Functions.
+The environment variables of the Function are set with the values from the config file, overridden by the values from the env file. Environment variables which reference ConfigMaps or Secrets must be set in the env file, because no cluster is used. Otherwise, they are not set and a warning is printed.
+Use the "--report" flag to write a JUnit XML report, for example to gate pull requests in a CI system. For Python Functions, the report contains the test cases reported by pytest. For Node.js Functions, "npm test" is reported as a single test case.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", "", `Full path to the config file of the Function.`)
+	cmd.Flags().StringVarP(&o.Dir, "source-dir", "d", "", `Full path to the folder with the sources and tests of the Function. By default, it is the source path of the config file for inline Functions, and the folder of the config file for Git Functions.`)
+	cmd.Flags().StringVar(&o.EnvFile, "env-file", "", `Full path to a file with environment variables in the format "NAME=VALUE", which override the environment variables of the Function. By default, the ".env" file in the folder of the config file is used if it exists.`)
+	cmd.Flags().StringVar(&o.Report, "report", "", `Full path to the file to which the JUnit XML report of the tests is written.`)
+	cmd.Flags().DurationVarP(&o.Timeout, "timeout", "t", 0, `Maximum time during which the tests are run, where "0" means "infinite". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".`)
+
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run() error {
+	if err := c.opts.defaultFilename(); err != nil {
+		return err
+	}
+	cfg, err := c.loadConfiguration()
+	if err != nil {
+		return err
+	}
+	if err := c.opts.setDefaults(cfg); err != nil {
+		return err
+	}
+	testCommands, err := serverless.TestCommands(cfg.Runtime, runtimes.KubelessPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(c.Context())
+	if c.opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+	}
+	defer cancel()
+
+	envs, err := c.functionEnvs(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	client, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return errors.Wrap(err, "Could not initialize the Docker client")
+	}
+
+	step := c.NewStep(fmt.Sprintf("Creating the test container of Function '%s'", cfg.Name))
+	id, err := createTestContainer(ctx, client, testContainerOpts{
+		Image:   runtimes.ContainerImage(cfg.Runtime),
+		User:    runtimes.ContainerUser(cfg.Runtime),
+		Envs:    append(append(runtimes.ContainerEnvs(cfg.Runtime, false), serverless.TestEnvs...), envs...),
+		WorkDir: c.opts.Dir,
+		Command: strings.Join(testCommands, " && "),
+	})
+	if err != nil {
+		step.Failure()
+		return errors.Wrap(err, "Could not create the test container")
+	}
+	c.Finalizers.Add(func() {
+		if err := removeContainer(client, id); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not remove the test container: %s\n", err)
+		}
+	})
+	defer removeContainer(client, id)
+	step.Successf("Test container created from image '%s'", runtimes.ContainerImage(cfg.Runtime))
+
+	start := time.Now()
+	result, err := runTestContainer(ctx, client, id, os.Stdout)
+	if err != nil {
+		return errors.Wrap(err, "Could not run the tests")
+	}
+
+	if c.opts.Report != "" {
+		if err := c.writeReport(cfg, testCommands[len(testCommands)-1], time.Since(start), result); err != nil {
+			return err
+		}
+	}
+
+	step = c.NewStep("Checking the result of the tests")
+	if result.ExitCode != 0 {
+		step.Failure()
+		return fmt.Errorf("The tests of Function '%s' failed with exit code %d", cfg.Name, result.ExitCode)
+	}
+	step.Successf("The tests of Function '%s' passed", cfg.Name)
+	return nil
+}
+
+func (c *command) loadConfiguration() (workspace.Cfg, error) {
+	content, err := ioutil.ReadFile(c.opts.Filename)
+	if err != nil {
+		return workspace.Cfg{}, errors.Wrap(err, "Could not read the configuration file")
+	}
+	warnings, err := serverless.ValidateConfigFile(c.opts.Filename, content)
+	if err != nil {
+		return workspace.Cfg{}, err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	var cfg workspace.Cfg
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return workspace.Cfg{}, errors.Wrap(err, "Could not decode the configuration file")
+	}
+	return cfg, nil
+}
+
+//functionEnvs returns the environment variables of the Function in the format 'NAME=VALUE', overridden by the values of the env file.
+//References to ConfigMaps and Secrets which the env file doesn't set are skipped, because the tests run without a cluster.
+func (c *command) functionEnvs(ctx context.Context, cfg workspace.Cfg) ([]string, error) {
+	envFile := c.opts.EnvFile
+	if envFile == "" {
+		envFile = filepath.Join(filepath.Dir(c.opts.Filename), serverless.DefaultEnvFile)
+	}
+	overrides, err := serverless.ReadEnvFile(envFile)
+	// the default env file is optional
+	if os.IsNotExist(err) && c.opts.EnvFile == "" {
+		overrides, err = map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read the env file")
+	}
+
+	resolver := &serverless.EnvResolver{Overrides: overrides}
+	envs, unresolved, err := resolver.Resolve(ctx, cfg.Env)
+	if err != nil {
+		return nil, err
+	}
+	if len(unresolved) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: the environment variables %s reference ConfigMaps or Secrets and are not set in the tests. Set them in the env file\n", strings.Join(unresolved, ", "))
+	}
+	return envs, nil
+}
+
+//writeReport writes the JUnit XML report of the tests
+func (c *command) writeReport(cfg workspace.Cfg, testCommand string, duration time.Duration, result testResult) error {
+	file, err := os.Create(c.opts.Report)
+	if err != nil {
+		return errors.Wrap(err, "Could not create the report file")
+	}
+	defer file.Close()
+
+	return junitxml.WriteCommandResult(file, junitxml.CommandResult{
+		Suite:    cfg.Name,
+		Command:  testCommand,
+		Duration: duration,
+		ExitCode: result.ExitCode,
+		Output:   result.Output,
+		Report:   result.Report,
+	})
+}
@@ -0,0 +1,93 @@
+package function
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFunctionFlags ensures that the provided command flags are stored in the options.
+func TestFunctionFlags(t *testing.T) {
+	t.Parallel()
+	o := NewOptions(&cli.Options{})
+	c := NewCmd(o)
+
+	// test default flag values
+	require.Empty(t, o.Filename, "Default value for the --filename flag not as expected.")
+	require.Empty(t, o.Dir, "Default value for the --source-dir flag not as expected.")
+	require.Empty(t, o.EnvFile, "Default value for the --env-file flag not as expected.")
+	require.Empty(t, o.Report, "Default value for the --report flag not as expected.")
+	require.Equal(t, time.Duration(0), o.Timeout, "Default value for the --timeout flag not as expected.")
+
+	// test passing flags
+	err := c.ParseFlags([]string{
+		"-f", "/fakepath/config.yaml",
+		"-d", "/fakepath/src",
+		"--env-file", "/fakepath/.env.test",
+		"--report", "/fakepath/junit.xml",
+		"-t", "5m",
+	})
+	require.NoError(t, err, "Parsing flags should not return an error")
+	require.Equal(t, "/fakepath/config.yaml", o.Filename, "The parsed value for the --filename flag not as expected.")
+	require.Equal(t, "/fakepath/src", o.Dir, "The parsed value for the --source-dir flag not as expected.")
+	require.Equal(t, "/fakepath/.env.test", o.EnvFile, "The parsed value for the --env-file flag not as expected.")
+	require.Equal(t, "/fakepath/junit.xml", o.Report, "The parsed value for the --report flag not as expected.")
+	require.Equal(t, 5*time.Minute, o.Timeout, "The parsed value for the --timeout flag not as expected.")
+}
+
+func TestSetDefaults(t *testing.T) {
+	t.Parallel()
+	filename := filepath.Join("project", "config.yaml")
+	project, err := filepath.Abs("project")
+	require.NoError(t, err)
+
+	o := Options{Filename: filename}
+	require.NoError(t, o.setDefaults(workspace.Cfg{Source: workspace.Source{Type: workspace.SourceTypeInline, SourceInline: workspace.SourceInline{SourcePath: "src"}}}))
+	require.Equal(t, filepath.Join(project, "src"), o.Dir, "The folder is mounted and must be absolute")
+
+	o = Options{Filename: filename}
+	require.NoError(t, o.setDefaults(workspace.Cfg{Source: workspace.Source{Type: workspace.SourceTypeGit}}))
+	require.Equal(t, project, o.Dir)
+}
+
+func TestFunctionEnvs(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-function")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := workspace.Cfg{Env: []workspace.EnvVar{
+		{Name: "GREETING", Value: "Hello"},
+		{Name: "PASSWORD", ValueFrom: &workspace.EnvVarSource{SecretKeyRef: &workspace.SecretKeySelector{Name: "db", Key: "password"}}},
+		{Name: "EMPTY"},
+	}}
+	c := command{opts: &Options{Filename: filepath.Join(dir, workspace.CfgFilename)}}
+
+	envs, err := c.functionEnvs(context.Background(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"GREETING=Hello", "EMPTY="}, envs, "References to Secrets are not resolved without a cluster")
+
+	// the default env file sets the references
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".env"), []byte("PASSWORD=s3cr3t\n"), 0600))
+	envs, err = c.functionEnvs(context.Background(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"GREETING=Hello", "PASSWORD=s3cr3t", "EMPTY="}, envs)
+
+	c.opts.EnvFile = filepath.Join(dir, ".env.test")
+	require.NoError(t, ioutil.WriteFile(c.opts.EnvFile, []byte("GREETING=Hi\nPASSWORD=test\n"), 0600))
+	envs, err = c.functionEnvs(context.Background(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"GREETING=Hi", "PASSWORD=test", "EMPTY="}, envs)
+
+	// an env file set with the flag must exist
+	c.opts.EnvFile = filepath.Join(dir, ".env.missing")
+	_, err = c.functionEnvs(context.Background(), cfg)
+	require.Error(t, err)
+}
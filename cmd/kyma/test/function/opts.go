@@ -0,0 +1,57 @@
+package function
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/cli"
+)
+
+//Options defines available options for the command
+type Options struct {
+	*cli.Options
+
+	Filename string
+	Dir      string
+	EnvFile  string
+	Report   string
+	Timeout  time.Duration
+}
+
+//NewOptions creates options with default values
+func NewOptions(o *cli.Options) *Options {
+	options := &Options{Options: o}
+	return options
+}
+
+func (o *Options) defaultFilename() error {
+	if o.Filename == "" {
+		pwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		o.Filename = filepath.Join(pwd, workspace.CfgFilename)
+	}
+	return nil
+}
+
+func (o *Options) setDefaults(cfg workspace.Cfg) error {
+	if o.Dir == "" {
+		o.Dir = filepath.Dir(o.Filename)
+		if cfg.Source.Type == workspace.SourceTypeInline && cfg.Source.SourcePath != "" {
+			o.Dir = cfg.Source.SourcePath
+			if !filepath.IsAbs(o.Dir) {
+				o.Dir = filepath.Join(filepath.Dir(o.Filename), o.Dir)
+			}
+		}
+	}
+	// the folder is mounted into the container, which requires an absolute path
+	dir, err := filepath.Abs(o.Dir)
+	if err != nil {
+		return err
+	}
+	o.Dir = dir
+	return nil
+}
@@ -2,11 +2,11 @@ package list
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/kyma-project/cli/cmd/kyma/test"
 	"github.com/kyma-project/cli/internal/cli"
 	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/output"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,6 +30,7 @@ func NewCmd(o *Options) *cobra.Command {
 		RunE:    func(_ *cobra.Command, _ []string) error { return cmd.Run() },
 		Aliases: []string{"l"},
 	}
+	output.Enable(cobraCmd, &o.OutputFormat)
 	return cobraCmd
 }
 
@@ -44,31 +45,49 @@ func (cmd *command) Run() error {
 		return errors.Wrap(err, "Unable to get list of test suites")
 	}
 
-	if len(testSuites.Items) == 0 {
+	if len(testSuites.Items) == 0 && !cmd.StructuredOutput() {
 		fmt.Println("No test suites found")
 		return nil
 	}
 
-	writer := test.NewTableWriter([]string{"TEST SUITE", "COMPLETED", "STATUS"}, os.Stdout)
-
+	result := suites{}
 	for idx := range testSuites.Items {
 		ts := testSuites.Items[idx]
-		var testResult string
+		var status string
 		switch len(ts.Status.Results) {
 		case 0:
-			testResult = "-"
+			status = "-"
 		case 1:
-			testResult = string(ts.Status.Results[0].Status)
+			status = string(ts.Status.Results[0].Status)
 		default:
-			testResult = string(ts.Status.Conditions[len(ts.Status.Conditions)-1].Type)
+			status = string(ts.Status.Conditions[len(ts.Status.Conditions)-1].Type)
 		}
-		writer.Append([]string{
-			ts.GetName(),
-			fmt.Sprintf("%d/%d", test.GetNumberOfFinishedTests(&ts), len(ts.Status.Results)),
-			testResult,
+		result = append(result, suiteSummary{
+			Name:      ts.GetName(),
+			Completed: test.GetNumberOfFinishedTests(&ts),
+			Tests:     len(ts.Status.Results),
+			Status:    status,
 		})
 	}
-	writer.Render()
+	return cmd.PrintResult(result)
+}
+
+//suiteSummary is the state of a test suite printed by the command
+type suiteSummary struct {
+	Name      string `json:"name"`
+	Completed int    `json:"completed"`
+	Tests     int    `json:"tests"`
+	Status    string `json:"status"`
+}
+
+//suites is the result of the command
+type suites []suiteSummary
 
-	return nil
+//TableRows returns the test suites as table rows
+func (l suites) TableRows(wide bool) ([]string, [][]string) {
+	rows := make([][]string, 0, len(l))
+	for _, s := range l {
+		rows = append(rows, []string{s.Name, fmt.Sprintf("%d/%d", s.Completed, s.Tests), s.Status})
+	}
+	return []string{"TEST SUITE", "COMPLETED", "STATUS"}, rows
 }
@@ -130,7 +130,8 @@ func (cmd *command) Run(args []string) error {
 
 	if cmd.opts.Watch {
 		waitStep := cmd.NewStep("Waiting for test suite to finish")
-		err = waitForTestSuite(cmd.K8s.Octopus(), testResource.Name, clusterTestSuiteCompleted(waitStep), cmd.opts.Timeout)
+		cmd.OnInterrupt(fmt.Sprintf("Watching test suite '%s'", testSuiteName), fmt.Sprintf("The test suite keeps running in the cluster. Run 'kyma test status %s' to see its result.", testSuiteName))
+		err = waitForTestSuite(cmd.Context(), cmd.K8s.Octopus(), testResource.Name, clusterTestSuiteCompleted(waitStep), cmd.opts.Timeout)
 		if err != nil {
 			waitStep.Failure()
 			return err
@@ -187,8 +188,8 @@ func verifyIfTestNotExists(suiteName string,
 }
 
 // waitForTestSuite watches the given test suite until the exitCondition is true
-func waitForTestSuite(cli octopus.Interface, name string, exitCondition watchtools.ConditionFunc, timeout time.Duration) error {
-	ctx, cancel := context.WithCancel(context.Background())
+func waitForTestSuite(ctx context.Context, cli octopus.Interface, name string, exitCondition watchtools.ConditionFunc, timeout time.Duration) error {
+	ctx, cancel := context.WithCancel(ctx)
 	if timeout > 0 {
 		ctx, cancel = context.WithTimeout(ctx, timeout)
 	}
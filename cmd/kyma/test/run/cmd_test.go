@@ -1,6 +1,7 @@
 package run
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -256,7 +257,7 @@ func Test_WaitForTestSuite(t *testing.T) {
 			waitForTestSuiteDone := make(chan struct{}, 1)
 			var waitErr error
 			go func() {
-				waitErr = waitForTestSuite(mCli, fixTestSuite.Name, clusterTestSuiteCompleted(mStep), 5*time.Second)
+				waitErr = waitForTestSuite(context.Background(), mCli, fixTestSuite.Name, clusterTestSuiteCompleted(mStep), 5*time.Second)
 				waitForTestSuiteDone <- struct{}{}
 			}()
 
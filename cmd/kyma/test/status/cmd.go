@@ -1,7 +1,6 @@
 package status
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -12,12 +11,15 @@ import (
 	"github.com/kyma-project/cli/internal/junitxml"
 	"github.com/kyma-project/cli/internal/kube"
 	"github.com/kyma-project/cli/internal/logs"
+	"github.com/kyma-project/cli/internal/output"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// junitFormat prints the test suites as a JUnit XML report
+const junitFormat output.Format = "junit"
+
 type command struct {
 	opts *Options
 	cli.Command
@@ -43,8 +45,7 @@ To print the status of specific test cases, run ` + "`kyma test status testSuite
 		Aliases: []string{"s"},
 	}
 
-	cobraCmd.Flags().StringVarP(&o.OutputFormat, "output", "o", "",
-		"Output format. One of: json|yaml|wide|junit")
+	output.Enable(cobraCmd, &o.OutputFormat, output.JSON, output.YAML, output.Table, output.Wide, output.JSONPath, output.Template, junitFormat)
 	return cobraCmd
 }
 
@@ -54,6 +55,7 @@ func (cmd *command) Run(args []string) error {
 		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure that your kubeconfig is valid.")
 	}
 
+	var suites []oct.ClusterTestSuite
 	switch len(args) {
 	case 1:
 		testSuite, err := cmd.K8s.Octopus().GetTestSuite(args[0], metav1.GetOptions{})
@@ -61,68 +63,57 @@ func (cmd *command) Run(args []string) error {
 			return errors.Wrap(err, fmt.Sprintf("unable to get test suite '%s'",
 				args[0]))
 		}
-		return cmd.printTestSuiteStatus(testSuite, cmd.opts.OutputFormat)
+		suites = []oct.ClusterTestSuite{*testSuite}
 	case 0:
 		testList, err := cmd.K8s.Octopus().ListTestSuites(metav1.ListOptions{})
 		if err != nil {
 			return errors.Wrap(err, "unable to list test suites")
 		}
+		suites = testList.Items
+	default:
+		suites, err = test.ListTestSuitesByName(cmd.K8s.Octopus(), args)
+		if err != nil {
+			return errors.Wrap(err, "unable to list test suites")
+		}
+	}
 
-		if len(testList.Items) == 0 {
+	switch cmd.OutputFormat.Kind() {
+	case output.Default, output.Table, output.Wide:
+		if len(suites) == 0 {
 			fmt.Println("No test suites found")
 			return nil
 		}
-
-		for idx := range testList.Items {
-			if err := cmd.printTestSuiteStatus(&testList.Items[idx], cmd.opts.OutputFormat); err != nil {
+		for idx := range suites {
+			printTestSuite(&suites[idx], cmd.OutputFormat == output.Wide)
+		}
+	case junitFormat:
+		for idx := range suites {
+			if err := cmd.printJUnit(&suites[idx]); err != nil {
 				return err
 			}
 		}
 	default:
-		testsList, err := test.ListTestSuitesByName(cmd.K8s.Octopus(), args)
-		if err != nil {
-			return errors.Wrap(err, "unable to list test suites")
+		// a single test suite is printed as it is, and several ones as a list
+		if len(args) == 1 {
+			return cmd.PrintResult(suites[0])
 		}
-
-		for idx := range testsList {
-			if err := cmd.printTestSuiteStatus(&testsList[idx], cmd.opts.OutputFormat); err != nil {
-				return err
-			}
+		if suites == nil {
+			suites = []oct.ClusterTestSuite{}
 		}
+		return cmd.PrintResult(oct.ClusterTestSuiteList{
+			TypeMeta: metav1.TypeMeta{Kind: "List", APIVersion: "v1"},
+			Items:    suites,
+		})
 	}
 	return nil
 }
 
-func (cmd *command) printTestSuiteStatus(testSuite *oct.ClusterTestSuite, outputFormat string) error {
-	switch strings.ToLower(outputFormat) {
-	case "yaml":
-		d, err := yaml.Marshal(testSuite)
-		if err != nil {
-			return errors.Wrapf(err, "Unable to marshal test suite '%s' to yaml",
-				testSuite.GetName())
-		}
-		fmt.Println(string(d))
-		return nil
-	case "json":
-		d, err := json.MarshalIndent(testSuite, "", "\t")
-		if err != nil {
-			return errors.Wrapf(err, "Unable to marshal test suite '%s' to json",
-				testSuite.GetName())
-		}
-		fmt.Println(string(d))
-		return nil
-	case "wide":
-		printTestSuite(testSuite, true)
-	case "junit":
-		logsFetcher := logs.NewFetcherForTestingPods(cmd.K8s.Static().CoreV1(), []string{})
-		junitCreator := junitxml.NewCreator(logsFetcher)
-		if err := junitCreator.Write(os.Stdout, testSuite); err != nil {
-			return errors.Wrapf(err, "while writing junit report for '%s' test suite", testSuite.GetName())
-		}
-	default:
-		printTestSuite(testSuite, false)
+func (cmd *command) printJUnit(testSuite *oct.ClusterTestSuite) error {
+	logsFetcher := logs.NewFetcherForTestingPods(cmd.K8s.Static().CoreV1(), []string{})
+	junitCreator := junitxml.NewCreator(logsFetcher)
+	if err := junitCreator.Write(os.Stdout, testSuite); err != nil {
+		return errors.Wrapf(err, "while writing junit report for '%s' test suite", testSuite.GetName())
 	}
-
 	return nil
 }
 
@@ -145,7 +136,7 @@ func printTestSuite(testSuite *oct.ClusterTestSuite, wide bool) {
 		fmt.Printf("Condition:\t%s\r\n", testSuite.Status.Conditions[len(testSuite.Status.Conditions)-1].Type)
 	}
 
-	writer := test.NewTableWriter([]string{}, os.Stdout)
+	writer := output.NewTableWriter([]string{}, os.Stdout)
 	for _, t := range testSuite.Status.Results {
 
 		if wide {
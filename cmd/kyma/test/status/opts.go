@@ -6,8 +6,7 @@ import (
 
 type Options struct {
 	*cli.Options
-	Wait         bool
-	OutputFormat string
+	Wait bool
 }
 
 func NewOptions(o *cli.Options) *Options {
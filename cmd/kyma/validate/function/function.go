@@ -0,0 +1,79 @@
+package function
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/kyma-project/cli/internal/serverless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *Options
+	cli.Command
+}
+
+//NewCmd creates a new validate function command
+func NewCmd(o *Options) *cobra.Command {
+	c := command{
+		opts:    o,
+		Command: cli.Command{Options: o.Options},
+	}
+	cmd := &cobra.Command{
+		Use:   "function",
+		Short: "Validates the config file of a Function.",
+		Long: `Use this command to validate the config file of a Function against its JSON Schema and to check it for common mistakes, such as resource requests that exceed the limits.
+The command prints the errors and warnings with the line numbers of the config file. It fails if the config file contains errors.
+The "kyma apply function" and "kyma run function" commands validate the config file as well.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.Run()
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", "", `Full path to the config file.`)
+
+	return cmd
+}
+
+//Run runs the command
+func (c *command) Run() error {
+	if err := c.opts.defaultFilename(); err != nil {
+		return err
+	}
+	content, err := ioutil.ReadFile(c.opts.Filename)
+	if err != nil {
+		return errors.Wrap(err, "Could not read the configuration file")
+	}
+
+	step := c.NewStep(fmt.Sprintf("Validating configuration file '%s'", c.opts.Filename))
+	issues, err := serverless.ValidateConfig(content)
+	if err != nil {
+		step.Failure()
+		return err
+	}
+
+	errorCount := 0
+	for _, issue := range issues {
+		if !issue.Warning {
+			errorCount++
+		}
+	}
+	switch {
+	case errorCount > 0:
+		step.Failuref("Configuration file contains %d errors and %d warnings", errorCount, len(issues)-errorCount)
+	case len(issues) > 0:
+		step.Successf("Configuration file is valid with %d warnings", len(issues))
+	default:
+		step.Successf("Configuration file is valid")
+	}
+	for _, issue := range issues {
+		fmt.Printf("  %s\n", issue)
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("Invalid configuration file '%s'", c.opts.Filename)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package function
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFunctionFlags ensures that the provided command flags are stored in the options.
+func TestFunctionFlags(t *testing.T) {
+	t.Parallel()
+	o := NewOptions(&cli.Options{})
+	c := NewCmd(o)
+
+	// test default flag values
+	require.Equal(t, "", o.Filename, "Default value for the --filename flag not as expected.")
+
+	// test passing flags
+	err := c.ParseFlags([]string{
+		"-f", "/fakepath/config.yaml",
+	})
+	require.NoError(t, err, "Parsing flags should not return an error")
+	require.Equal(t, "/fakepath/config.yaml", o.Filename, "The parsed value for the --filename flag not as expected.")
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "validate")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, ioutil.WriteFile(valid, []byte("name: orders\nruntime: nodejs14\n"), 0600))
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, ioutil.WriteFile(invalid, []byte("name: orders\nruntime: nodejs10\n"), 0600))
+
+	c := command{opts: &Options{Options: &cli.Options{}, Filename: valid}}
+	c.Command.Options = c.opts.Options
+	require.NoError(t, c.Run())
+
+	c.opts.Filename = invalid
+	require.Error(t, c.Run())
+
+	c.opts.Filename = filepath.Join(dir, "missing.yaml")
+	require.Error(t, c.Run())
+}
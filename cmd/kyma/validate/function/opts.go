@@ -0,0 +1,33 @@
+package function
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/kyma-project/cli/internal/cli"
+)
+
+//Options defines available options for the command
+type Options struct {
+	*cli.Options
+
+	Filename string
+}
+
+//NewOptions creates options with default values
+func NewOptions(o *cli.Options) *Options {
+	options := &Options{Options: o}
+	return options
+}
+
+func (o *Options) defaultFilename() error {
+	if o.Filename == "" {
+		pwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		o.Filename = filepath.Join(pwd, workspace.CfgFilename)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package validate
+
+import (
+	"github.com/kyma-project/cli/cmd/kyma/validate/function"
+	"github.com/kyma-project/cli/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+//NewCmd creates a new validate command
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validates local resources.",
+		Long:  "Use this command to check the configuration of the given resource before you apply it to the Kyma cluster. Currently, you can only use it for Functions.",
+	}
+
+	cmd.AddCommand(function.NewCmd(function.NewOptions(o)))
+	return cmd
+}
@@ -3,10 +3,12 @@ package version
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/output"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -34,16 +36,25 @@ func NewCmd(o *Options) *cobra.Command {
 		RunE: func(_ *cobra.Command, _ []string) error { return c.Run() },
 	}
 	cmd.Flags().BoolVarP(&o.Client, "client", "c", false, "Client version only (no server required)")
+	output.Enable(cmd, &o.OutputFormat, output.JSON, output.YAML, output.JSONPath, output.Template)
 	return cmd
 }
 
+//versions is the result of the command
+type versions struct {
+	ClientVersion string `json:"clientVersion"`
+	KymaVersion   string `json:"kymaVersion,omitempty"`
+}
+
 //Run runs the command
 func (c command) Run() error {
-	version := Version
-	if version == "" {
-		version = "N/A"
+	result := versions{ClientVersion: Version}
+	if result.ClientVersion == "" {
+		result.ClientVersion = "N/A"
+	}
+	if c.opts.OutputFormat == output.Default {
+		fmt.Printf("Kyma CLI version: %s\n", result.ClientVersion)
 	}
-	fmt.Printf("Kyma CLI version: %s\n", version)
 
 	if !c.opts.Client {
 		k8s, err := kube.NewFromConfigWithTimeout("", c.opts.KubeconfigPath, 2*time.Second)
@@ -53,13 +64,23 @@ func (c command) Run() error {
 
 		version, err := KymaVersion(k8s)
 		if err != nil {
-			fmt.Printf("Unable to get Kyma cluster version due to error: %s. Check if your cluster is available and has Kyma installed\r\n", err.Error())
-			return nil
+			fmt.Fprintf(os.Stderr, "Unable to get Kyma cluster version due to error: %s. Check if your cluster is available and has Kyma installed\r\n", err.Error())
+			return c.printResult(result)
+		}
+		result.KymaVersion = version
+		if c.opts.OutputFormat == output.Default {
+			fmt.Printf("Kyma cluster version: %s\n", version)
 		}
-		fmt.Printf("Kyma cluster version: %s\n", version)
 	}
 
-	return nil
+	return c.printResult(result)
+}
+
+func (c command) printResult(result versions) error {
+	if c.opts.OutputFormat == output.Default {
+		return nil
+	}
+	return output.Print(os.Stdout, c.opts.OutputFormat, result)
 }
 
 //KymaVersion determines the version of kyma installed in the cluster sccessible via the provided kubernetes client
@@ -10,9 +10,16 @@ import (
 )
 
 func main() {
-	command := kyma.NewCmd(cli.NewOptions())
+	o := cli.NewOptions()
+	command := kyma.NewCmd(o)
+
+	if code, ok := kyma.RunPlugin(command, o, os.Args[1:]); ok {
+		os.Exit(code)
+	}
 
 	err := command.Execute()
+	// an interrupted command exits when its cleanup is done
+	o.Finalizers.Finish()
 	if err != nil {
 		os.Exit(1)
 	}
@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"context"
+	"os"
+
 	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/internal/output"
 	"github.com/kyma-project/cli/pkg/step"
 )
 
@@ -16,3 +20,28 @@ func (c *Command) NewStep(msg string) step.Step {
 	c.CurrentStep = s
 	return s
 }
+
+//PrintResult prints the result of the command to the standard output in the format selected with the "--output" flag
+func (c *Command) PrintResult(result interface{}) error {
+	return output.Print(os.Stdout, c.OutputFormat, result)
+}
+
+//StructuredOutput tells whether a format was selected with the "--output" flag, in which case the standard output must only contain the result
+func (c *Command) StructuredOutput() bool {
+	return c.OutputFormat != output.Default
+}
+
+//Context returns the context of the command, which is cancelled when Kyma CLI is interrupted with Ctrl+C
+func (c *Command) Context() context.Context {
+	return c.Finalizers.Context()
+}
+
+//OnInterrupt sets what is interrupted and how to resume it, which is printed if Kyma CLI is interrupted
+func (c *Command) OnInterrupt(operation, resume string) {
+	c.Finalizers.OnInterrupt(operation, resume)
+}
+
+//RunInterruptible runs a function which doesn't accept a context, and abandons it when Kyma CLI is interrupted with Ctrl+C
+func (c *Command) RunInterruptible(function func() error) error {
+	return c.Finalizers.RunInterruptible(function)
+}
@@ -1,25 +1,55 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 )
 
-const timeout = 5 * time.Second
+const (
+	//DefaultGracePeriod is the default time for the cleanup of an interrupted command before Kyma CLI exits
+	DefaultGracePeriod = 5 * time.Second
+	//ExitCodeInterrupted is the exit code of an interrupted command, like the one set by shells for programs stopped with Ctrl+C
+	ExitCodeInterrupted = 130
+)
 
+//Finalizers cancel the running command when Kyma CLI receives SIGINT or SIGTERM, run the registered cleanup functions, and exit with ExitCodeInterrupted
 type Finalizers struct {
 	notify func(c chan<- os.Signal, sig ...os.Signal)
+	stop   func(c chan<- os.Signal)
 	exit   func(int)
 	funcs  []func()
+	out    io.Writer
+
+	//GracePeriod is the time for the interrupted command and the cleanup functions to finish before Kyma CLI exits
+	GracePeriod time.Duration
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	signals  chan os.Signal
+	finished chan struct{}
+	once     sync.Once
+
+	mu          sync.Mutex
+	operation   string
+	resume      string
+	interrupted bool
+	// abandoned is set while a function which can't be cancelled runs with RunInterruptible
+	abandoned bool
 }
 
 func NewFinalizer() *Finalizers {
 	fin := &Finalizers{
-		notify: signal.Notify,
-		exit:   os.Exit,
+		notify:      signal.Notify,
+		stop:        signal.Stop,
+		exit:        os.Exit,
+		out:         os.Stderr,
+		GracePeriod: DefaultGracePeriod,
 	}
 	fin.setupCloseHandler()
 
@@ -30,29 +60,129 @@ func (f *Finalizers) Add(function func()) {
 	f.funcs = append(f.funcs, function)
 }
 
+//Context returns the context of the command, which is cancelled when Kyma CLI is interrupted
+func (f *Finalizers) Context() context.Context {
+	if f == nil || f.ctx == nil {
+		return context.Background()
+	}
+	return f.ctx
+}
+
+//OnInterrupt sets what is interrupted, such as "Kyma deployment", and how to resume it, which is printed if Kyma CLI is interrupted
+func (f *Finalizers) OnInterrupt(operation, resume string) {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.operation = operation
+	f.resume = resume
+}
+
+//Finish tells that the command returned. If the command was interrupted, Finish blocks until the cleanup is done and Kyma CLI exits with ExitCodeInterrupted.
+func (f *Finalizers) Finish() {
+	if f == nil || f.finished == nil {
+		return
+	}
+	f.once.Do(func() { close(f.finished) })
+	f.mu.Lock()
+	interrupted := f.interrupted
+	f.mu.Unlock()
+	if interrupted {
+		select {}
+	}
+}
+
+//Stop removes the signal handler, so that the caller handles SIGINT and SIGTERM itself, such as a plugin which runs instead of a command
+func (f *Finalizers) Stop() {
+	if f == nil || f.signals == nil || f.stop == nil {
+		return
+	}
+	f.stop(f.signals)
+}
+
 func (f *Finalizers) setupCloseHandler() {
-	c := make(chan os.Signal, 1)
+	f.ctx, f.cancel = context.WithCancel(context.Background())
+	f.finished = make(chan struct{})
+	if f.out == nil {
+		f.out = os.Stderr
+	}
+
+	c := make(chan os.Signal, 2)
+	f.signals = c
 	f.notify(c, syscall.SIGTERM, syscall.SIGINT)
 	go func() {
 		sig := <-c
-		fmt.Printf("\r- Signal '%v' received from Terminal. Exiting...\n ", sig)
-		lastChan := make(chan struct{})
+		f.mu.Lock()
+		f.interrupted = true
+		operation, resume, abandoned := f.operation, f.resume, f.abandoned
+		f.mu.Unlock()
+		f.cancel()
+
+		if operation == "" {
+			operation = "Command"
+		}
+		if abandoned {
+			fmt.Fprintf(f.out, "\r! Signal '%v' received: %s interrupted. It can't be cancelled, so it is abandoned in its current state when Kyma CLI exits in at most %s, press Ctrl+C again to exit immediately...\n", sig, operation, f.GracePeriod)
+		} else {
+			fmt.Fprintf(f.out, "\r! Signal '%v' received: %s interrupted. Cleaning up for at most %s, press Ctrl+C again to exit immediately...\n", sig, operation, f.GracePeriod)
+		}
 
+		cleanedUp := make(chan struct{})
 		go func() {
 			for _, f := range f.funcs {
 				if f != nil {
 					f()
 				}
 			}
-			lastChan <- struct{}{}
+			close(cleanedUp)
 		}()
 
+		// the cleanup is done when the registered functions and the cancelled command returned
+		done := make(chan struct{})
 		go func() {
-			time.Sleep(timeout)
-			lastChan <- struct{}{}
+			<-cleanedUp
+			<-f.finished
+			close(done)
 		}()
 
-		<-lastChan
-		f.exit(0)
+		select {
+		case <-done:
+		case <-time.After(f.GracePeriod):
+			fmt.Fprintf(f.out, "! Cleanup did not finish within %s\n", f.GracePeriod)
+		case <-c:
+		}
+		if resume != "" {
+			fmt.Fprintf(f.out, "  %s\n", resume)
+		}
+		f.exit(ExitCodeInterrupted)
+	}()
+}
+
+//RunInterruptible runs the function, which can't be cancelled itself, and returns the error of the context as soon as Kyma CLI is interrupted.
+//The interrupted function is abandoned rather than cancelled: it keeps running in the background until Kyma CLI exits and is stopped in whatever state it is in.
+//Use it only for operations which don't accept a context, and which can be resumed by running the command again.
+func (f *Finalizers) RunInterruptible(function func() error) error {
+	if f == nil {
+		return function()
+	}
+	f.mu.Lock()
+	f.abandoned = true
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.abandoned = false
+		f.mu.Unlock()
 	}()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- function()
+	}()
+	select {
+	case err := <-errc:
+		return err
+	case <-f.Context().Done():
+		return f.Context().Err()
+	}
 }
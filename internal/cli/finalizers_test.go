@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"os"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
@@ -190,3 +194,130 @@ func fixExit(exit chan struct{}) func(int) {
 		exit <- struct{}{}
 	}
 }
+
+func TestFinalizer_interrupt(t *testing.T) {
+	t.Run("should cancel the context, wait for the command, and exit with code 130", func(t *testing.T) {
+		t.Parallel()
+
+		code := make(chan int, 1)
+		out := &syncBuffer{}
+		d := &Finalizers{
+			notify:      fixNotify(syscall.SIGINT),
+			exit:        func(c int) { code <- c },
+			out:         out,
+			GracePeriod: time.Minute,
+		}
+		d.OnInterrupt("Kyma deployment", "Run the command again to resume.")
+		d.setupCloseHandler()
+
+		select {
+		case <-d.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("context was not cancelled")
+		}
+		select {
+		case <-code:
+			t.Fatal("exited before the command returned")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		go d.Finish()
+		require.Equal(t, ExitCodeInterrupted, <-code)
+		require.Contains(t, out.String(), "Kyma deployment interrupted")
+		require.Contains(t, out.String(), "Run the command again to resume.")
+	})
+
+	t.Run("should exit after the grace period", func(t *testing.T) {
+		t.Parallel()
+
+		code := make(chan int, 1)
+		d := &Finalizers{
+			notify:      fixNotify(syscall.SIGTERM),
+			exit:        func(c int) { code <- c },
+			out:         &syncBuffer{},
+			GracePeriod: 10 * time.Millisecond,
+		}
+		d.Add(func() { time.Sleep(time.Minute) })
+		d.setupCloseHandler()
+
+		select {
+		case c := <-code:
+			require.Equal(t, ExitCodeInterrupted, c)
+		case <-time.After(5 * time.Second):
+			t.Fatal("did not exit after the grace period")
+		}
+	})
+
+	t.Run("should not block commands which were not interrupted", func(t *testing.T) {
+		t.Parallel()
+
+		d := &Finalizers{
+			notify: func(c chan<- os.Signal, sig ...os.Signal) {},
+			exit:   func(int) {},
+		}
+		d.setupCloseHandler()
+		d.Finish()
+		require.NoError(t, d.Context().Err())
+	})
+}
+
+func TestRunInterruptible(t *testing.T) {
+	t.Parallel()
+
+	var nilFinalizers *Finalizers
+	require.EqualError(t, nilFinalizers.RunInterruptible(func() error { return errors.New("failed") }), "failed")
+
+	code := make(chan int, 1)
+	out := &syncBuffer{}
+	d := &Finalizers{
+		notify:      fixNotify(syscall.SIGINT),
+		exit:        func(c int) { code <- c },
+		out:         out,
+		GracePeriod: time.Minute,
+	}
+	d.OnInterrupt("Kyma deployment", "")
+	d.setupCloseHandler()
+	require.EqualError(t, d.RunInterruptible(func() error { return errors.New("failed") }), "failed")
+	require.Equal(t, context.Canceled, d.RunInterruptible(func() error {
+		time.Sleep(time.Minute)
+		return nil
+	}))
+
+	go d.Finish()
+	require.Equal(t, ExitCodeInterrupted, <-code)
+	require.Contains(t, out.String(), "Kyma deployment interrupted. It can't be cancelled, so it is abandoned")
+	require.NotContains(t, out.String(), "Cleaning up")
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFinalizer_Stop(t *testing.T) {
+	t.Parallel()
+	var stopped chan<- os.Signal
+	d := &Finalizers{
+		notify: func(c chan<- os.Signal, sig ...os.Signal) {},
+		stop:   func(c chan<- os.Signal) { stopped = c },
+	}
+	d.setupCloseHandler()
+	d.Stop()
+	require.NotNil(t, stopped, "The signal handler must be removed")
+	require.Equal(t, chan<- os.Signal(d.signals), stopped)
+
+	var nilFinalizers *Finalizers
+	nilFinalizers.Stop()
+}
@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"os"
+
+	"github.com/kyma-project/cli/internal/output"
 	"github.com/kyma-project/cli/pkg/step"
+	"github.com/spf13/cobra"
 )
 
 //Options defines available options for the command
@@ -10,7 +14,9 @@ type Options struct {
 	Verbose bool
 	step.Factory
 	KubeconfigPath string
-	Finalizers     *Finalizers
+	// OutputFormat is the format of the result of the command selected with the "--output" flag
+	OutputFormat output.Format
+	Finalizers   *Finalizers
 }
 
 //NewOptions creates options with default values
@@ -19,3 +25,15 @@ func NewOptions() *Options {
 		Finalizers: NewFinalizer(),
 	}
 }
+
+//SetupOutput checks that the command supports the format selected with the "--output" flag.
+//If a format is selected, the steps are written to the standard error, so that the standard output only contains the result.
+func (o *Options) SetupOutput(cmd *cobra.Command) error {
+	if err := output.Validate(cmd, o.OutputFormat); err != nil {
+		return err
+	}
+	if o.OutputFormat != output.Default && o.Factory.Output == nil {
+		o.Factory.Output = os.Stderr
+	}
+	return nil
+}
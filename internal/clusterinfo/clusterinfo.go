@@ -18,6 +18,9 @@ const (
 	k8sConfigMap string = "kyma-cluster-info"
 	k8sNamespace string = "kube-system"
 
+	registryKey     string = "registry"
+	registryPushKey string = "registryPush"
+
 	//ClusterProviderK3s indicates that K3s is used as cluster provider
 	ClusterProviderK3s ClusterProvider = "k3s"
 	//ClusterProviderGardener indicates that Gardener is used as cluster provider
@@ -36,6 +39,25 @@ type ClusterInfo struct {
 	initialized bool
 	local       bool
 	provider    ClusterProvider
+	registry    Registry
+}
+
+// Registry describes the container registry which is available to the cluster
+type Registry struct {
+	// Address (host:port) used by the cluster nodes to pull images
+	Address string
+	// PushAddress (host:port) used on the local machine to push images
+	PushAddress string
+}
+
+// Option sets optional cluster information
+type Option func(c *ClusterInfo)
+
+// WithRegistry sets the container registry of the cluster
+func WithRegistry(registry Registry) Option {
+	return func(c *ClusterInfo) {
+		c.registry = registry
+	}
 }
 
 // New creates a new cluster info instance
@@ -57,10 +79,22 @@ func (c *ClusterInfo) Exists() (bool, error) {
 }
 
 // Write cluster information into cluster
-func (c *ClusterInfo) Write(provider ClusterProvider, local bool) error {
+func (c *ClusterInfo) Write(provider ClusterProvider, local bool, opts ...Option) error {
 	if provider == "" {
 		return fmt.Errorf("Cluster provider cannot be empty")
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	data := map[string]string{
+		"provider": string(provider),
+		"local":    strconv.FormatBool(local),
+	}
+	if c.registry.Address != "" {
+		data[registryKey] = c.registry.Address
+		data[registryPushKey] = c.registry.PushAddress
+	}
 
 	// write config-map
 	_, err := c.k8sClient.CoreV1().ConfigMaps(k8sNamespace).Create(context.Background(), &corev1.ConfigMap{
@@ -68,10 +102,7 @@ func (c *ClusterInfo) Write(provider ClusterProvider, local bool) error {
 			Name:   k8sConfigMap,
 			Labels: map[string]string{"app": "kyma"},
 		},
-		Data: map[string]string{
-			"provider": string(provider),
-			"local":    strconv.FormatBool(local),
-		},
+		Data: data,
 	}, metav1.CreateOptions{})
 
 	// remember state
@@ -103,6 +134,10 @@ func (c *ClusterInfo) Read() error {
 	if err != nil {
 		return err
 	}
+	c.registry = Registry{
+		Address:     cm.Data[registryKey],
+		PushAddress: cm.Data[registryPushKey],
+	}
 	c.initialized = true
 
 	return nil
@@ -124,6 +159,14 @@ func (c *ClusterInfo) Provider() (ClusterProvider, error) {
 	return ClusterProvider(c.provider), nil
 }
 
+//Registry returns the container registry of the cluster. The address of the registry is empty if the cluster has no registry.
+func (c *ClusterInfo) Registry() (Registry, error) {
+	if err := c.isInitialized(); err != nil {
+		return Registry{}, err
+	}
+	return c.registry, nil
+}
+
 func (c *ClusterInfo) isInitialized() error {
 	if !c.initialized {
 		return fmt.Errorf("ClusterInfo not initialized. Either write or read it from cluster first")
@@ -0,0 +1,48 @@
+package clusterinfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	t.Run("without registry", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		require.NoError(t, New(client).Write(ClusterProviderGcp, false))
+
+		info := New(client)
+		require.NoError(t, info.Read())
+		provider, err := info.Provider()
+		require.NoError(t, err)
+		require.Equal(t, ClusterProviderGcp, provider)
+		local, err := info.IsLocal()
+		require.NoError(t, err)
+		require.False(t, local)
+		registry, err := info.Registry()
+		require.NoError(t, err)
+		require.Empty(t, registry.Address)
+	})
+
+	t.Run("with registry", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		reg := Registry{Address: "k3d-kyma-registry:5001", PushAddress: "localhost:5001"}
+		require.NoError(t, New(client).Write(ClusterProviderK3s, true, WithRegistry(reg)))
+
+		info := New(client)
+		require.NoError(t, info.Read())
+		registry, err := info.Registry()
+		require.NoError(t, err)
+		require.Equal(t, reg, registry)
+	})
+
+	t.Run("not initialized", func(t *testing.T) {
+		_, err := New(fake.NewSimpleClientset()).Registry()
+		require.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		require.Error(t, New(fake.NewSimpleClientset()).Read())
+	})
+}
@@ -0,0 +1,220 @@
+// Package clusters keeps track of the clusters provisioned by Kyma CLI.
+//
+// Hydroform persists the Terraform state of each cluster in "<kyma home>/clusters/<provider type>/<project>/<cluster>".
+// Next to this state, the CLI stores a record with the cluster and provider settings and the kubeconfig of the cluster,
+// which are needed to deprovision the cluster later on.
+package clusters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/hydroform/provision/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	clustersDir    = "clusters"
+	recordFile     = "kyma-cluster.json"
+	kubeconfigFile = "kubeconfig.yaml"
+	tfStateFile    = "terraform.tfstate"
+)
+
+// Record describes a cluster provisioned by Kyma CLI
+type Record struct {
+	// ProviderName is the display name of the provisioner (e.g. GKE, AKS, Garden(GCP))
+	ProviderName string          `json:"providerName"`
+	Cluster      *types.Cluster  `json:"cluster"`
+	Provider     *types.Provider `json:"provider"`
+	Created      time.Time       `json:"created"`
+
+	dir string
+}
+
+// Name returns the name of the cluster
+func (r *Record) Name() string {
+	return r.Cluster.Name
+}
+
+// Project returns the project (or resource group) the cluster belongs to
+func (r *Record) Project() string {
+	return r.Provider.ProjectName
+}
+
+// Region returns the location of the cluster
+func (r *Record) Region() string {
+	return r.Cluster.Location
+}
+
+// Deprovisionable returns true if the record contains all settings required to deprovision the cluster
+func (r *Record) Deprovisionable() bool {
+	return r.Provider.CredentialsFilePath != ""
+}
+
+// Kubeconfig returns the kubeconfig which was imported when the cluster was provisioned
+func (r *Record) Kubeconfig() ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(r.dir, kubeconfigFile))
+}
+
+// Remove deletes all local data of the cluster including the Terraform state
+func (r *Record) Remove() error {
+	return os.RemoveAll(r.dir)
+}
+
+// Save stores the record of a provisioned cluster and its kubeconfig in the data directory
+func Save(dataDir, providerName string, cluster *types.Cluster, provider *types.Provider, kubeconfig []byte) error {
+	dir := clusterDir(dataDir, provider.Type, provider.ProjectName, cluster.Name)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// the cluster info contains the whole Terraform state which is already persisted by Hydroform
+	c := *cluster
+	c.ClusterInfo = nil
+
+	data, err := json.MarshalIndent(&Record{
+		ProviderName: providerName,
+		Cluster:      &c,
+		Provider:     provider,
+		Created:      time.Now(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, recordFile), data, 0600); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, kubeconfigFile), kubeconfig, 0600)
+}
+
+// List returns the records of all clusters found in the data directory.
+// Clusters provisioned by older CLI versions have no record. For those, the record is derived from the directory structure
+// and the Terraform state, and cannot be used to deprovision the cluster.
+func List(dataDir string) ([]*Record, error) {
+	dirs, err := filepath.Glob(filepath.Join(dataDir, clustersDir, "*", "*", "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+	for _, dir := range dirs {
+		if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+			continue
+		}
+		r, err := load(dir)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			records = append(records, r)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Created.Before(records[j].Created)
+	})
+	return records, nil
+}
+
+// Find returns the record of the cluster with the given name.
+// If the name is not unique, the provider type and project can be used to select the cluster.
+func Find(dataDir, name string, providerType types.ProviderType, project string) (*Record, error) {
+	records, err := List(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*Record
+	for _, r := range records {
+		if r.Name() != name {
+			continue
+		}
+		if providerType != "" && r.Provider.Type != providerType {
+			continue
+		}
+		if project != "" && r.Project() != project {
+			continue
+		}
+		matches = append(matches, r)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("Cluster '%s' not found. Run 'kyma cluster list' to see all clusters provisioned by Kyma CLI", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("Found %d clusters with name '%s'. Please specify the provider type and project of the cluster", len(matches), name)
+	}
+}
+
+func load(dir string) (*Record, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, recordFile))
+	if os.IsNotExist(err) {
+		return recordFromState(dir), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Record{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, errors.Wrapf(err, "Could not read the cluster record in '%s'", dir)
+	}
+	if r.Cluster == nil || r.Provider == nil {
+		return nil, fmt.Errorf("Cluster record in '%s' is incomplete", dir)
+	}
+	r.Provider.CustomConfigurations = normalize(r.Provider.CustomConfigurations)
+	r.dir = dir
+	return r, nil
+}
+
+func recordFromState(dir string) *Record {
+	fi, err := os.Stat(filepath.Join(dir, tfStateFile))
+	if err != nil {
+		// no Terraform state: nothing was provisioned in this directory
+		return nil
+	}
+
+	project := filepath.Dir(dir)
+	return &Record{
+		ProviderName: filepath.Base(filepath.Dir(project)),
+		Cluster:      &types.Cluster{Name: filepath.Base(dir)},
+		Provider: &types.Provider{
+			Type:        types.ProviderType(filepath.Base(filepath.Dir(project))),
+			ProjectName: filepath.Base(project),
+		},
+		Created: fi.ModTime(),
+		dir:     dir,
+	}
+}
+
+// normalize restores the value types of custom configurations after JSON decoding.
+// Hydroform only considers int, string and []string values when generating the Terraform variables.
+func normalize(cfg map[string]interface{}) map[string]interface{} {
+	for k, v := range cfg {
+		switch t := v.(type) {
+		case float64:
+			if t == math.Trunc(t) {
+				cfg[k] = int(t)
+			}
+		case []interface{}:
+			s := make([]string, 0, len(t))
+			for _, item := range t {
+				s = append(s, fmt.Sprint(item))
+			}
+			cfg[k] = s
+		}
+	}
+	return cfg
+}
+
+func clusterDir(dataDir string, providerType types.ProviderType, project, cluster string) string {
+	return filepath.Join(dataDir, clustersDir, string(providerType), project, cluster)
+}
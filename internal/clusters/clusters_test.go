@@ -0,0 +1,100 @@
+package clusters
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/hydroform/provision/types"
+	"github.com/stretchr/testify/require"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "clusters")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestSaveAndFind(t *testing.T) {
+	dataDir := tempDir(t)
+
+	cluster := &types.Cluster{
+		Name:        "my-cluster",
+		Location:    "europe-west3",
+		NodeCount:   3,
+		ClusterInfo: &types.ClusterInfo{Endpoint: "1.2.3.4"},
+	}
+	provider := &types.Provider{
+		Type:                types.Gardener,
+		ProjectName:         "my-project",
+		CredentialsFilePath: "/path/to/credentials",
+		CustomConfigurations: map[string]interface{}{
+			"target_provider": "gcp",
+			"worker_minimum":  2,
+			"zones":           []string{"europe-west3-a", "europe-west3-b"},
+		},
+	}
+	require.NoError(t, Save(dataDir, "Garden(GCP)", cluster, provider, []byte("kubeconfig")))
+
+	r, err := Find(dataDir, "my-cluster", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "Garden(GCP)", r.ProviderName)
+	require.Equal(t, "my-cluster", r.Name())
+	require.Equal(t, "my-project", r.Project())
+	require.Equal(t, "europe-west3", r.Region())
+	require.Nil(t, r.Cluster.ClusterInfo, "Terraform state must not be stored in the record")
+	require.True(t, r.Deprovisionable())
+	require.Equal(t, provider.CustomConfigurations, r.Provider.CustomConfigurations, "Custom configuration types must survive the round trip")
+
+	kubeconfig, err := r.Kubeconfig()
+	require.NoError(t, err)
+	require.Equal(t, "kubeconfig", string(kubeconfig))
+
+	_, err = Find(dataDir, "other-cluster", "", "")
+	require.Error(t, err)
+
+	require.NoError(t, r.Remove())
+	_, err = Find(dataDir, "my-cluster", "", "")
+	require.Error(t, err)
+}
+
+func TestFindAmbiguous(t *testing.T) {
+	dataDir := tempDir(t)
+
+	cluster := &types.Cluster{Name: "my-cluster"}
+	require.NoError(t, Save(dataDir, "GKE", cluster, &types.Provider{Type: types.GCP, ProjectName: "a"}, nil))
+	require.NoError(t, Save(dataDir, "AKS", cluster, &types.Provider{Type: types.Azure, ProjectName: "b"}, nil))
+
+	_, err := Find(dataDir, "my-cluster", "", "")
+	require.Error(t, err)
+
+	r, err := Find(dataDir, "my-cluster", types.Azure, "")
+	require.NoError(t, err)
+	require.Equal(t, "AKS", r.ProviderName)
+
+	r, err = Find(dataDir, "my-cluster", "", "a")
+	require.NoError(t, err)
+	require.Equal(t, "GKE", r.ProviderName)
+}
+
+func TestListWithoutRecord(t *testing.T) {
+	dataDir := tempDir(t)
+
+	// cluster provisioned by an older CLI version: only the Terraform state exists
+	dir := filepath.Join(dataDir, "clusters", "gcp", "old-project", "old-cluster")
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "terraform.tfstate"), []byte("{}"), 0600))
+
+	// leftover directory without state
+	require.NoError(t, os.MkdirAll(filepath.Join(dataDir, "clusters", "gcp", "old-project", "empty"), 0700))
+
+	records, err := List(dataDir)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "old-cluster", records[0].Name())
+	require.Equal(t, "old-project", records[0].Project())
+	require.Equal(t, types.GCP, records[0].Provider.Type)
+	require.False(t, records[0].Deprovisionable())
+}
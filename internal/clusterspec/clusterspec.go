@@ -0,0 +1,369 @@
+// Package clusterspec provides a declarative, versioned cluster specification for the provisioning commands.
+//
+// A cluster spec file selects the provisioner through its provider discriminator. The cluster section maps onto the
+// Hydroform types.Cluster and the providerConfig section onto the Hydroform types.Provider. Settings of a provision command
+// which have no counterpart in Hydroform are defined in the customConfigurations of the providerConfig section.
+// The spec is validated against the flags of the provision command when it is loaded, and flags passed on the command line
+// always take precedence over the file.
+package clusterspec
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// APIVersion is the currently supported version of the cluster spec format
+	APIVersion = "cli.kyma-project.io/v1alpha1"
+	// Kind of the cluster spec document
+	Kind = "Cluster"
+
+	configFlag      = "config"
+	printConfigFlag = "print-config"
+
+	clusterSection  = "cluster"
+	providerSection = "providerConfig"
+)
+
+// Provider discriminators supported in the cluster spec
+const (
+	ProviderGKE         = "gke"
+	ProviderAKS         = "aks"
+	ProviderGardenerGCP = "gardener-gcp"
+	ProviderGardenerAWS = "gardener-aws"
+	ProviderGardenerAz  = "gardener-az"
+	ProviderMinikube    = "minikube"
+	ProviderK3s         = "k3s"
+)
+
+// Spec is the declarative definition of a cluster
+type Spec struct {
+	APIVersion     string         `yaml:"apiVersion"`
+	Kind           string         `yaml:"kind"`
+	Provider       string         `yaml:"provider"`
+	Cluster        Cluster        `yaml:"cluster,omitempty"`
+	ProviderConfig ProviderConfig `yaml:"providerConfig,omitempty"`
+}
+
+// Cluster defines the cluster. It maps onto the Hydroform types.Cluster.
+type Cluster struct {
+	Name              string `yaml:"name,omitempty"`
+	KubernetesVersion string `yaml:"kubernetesVersion,omitempty"`
+	Location          string `yaml:"location,omitempty"`
+	MachineType       string `yaml:"machineType,omitempty"`
+	DiskSizeGB        int    `yaml:"diskSizeGB,omitempty"`
+	NodeCount         int    `yaml:"nodeCount,omitempty"`
+}
+
+// ProviderConfig defines the infrastructure on which the cluster is provisioned. It maps onto the Hydroform types.Provider.
+type ProviderConfig struct {
+	ProjectName         string `yaml:"projectName,omitempty"`
+	CredentialsFilePath string `yaml:"credentialsFilePath,omitempty"`
+	// CustomConfigurations are the settings of the provision command which are not part of the Hydroform types, keyed by the name of their flag
+	CustomConfigurations map[string]interface{} `yaml:"customConfigurations,omitempty"`
+}
+
+// cloudFields maps the settings of the cluster and providerConfig sections to the flags of the commands which provision clusters in the cloud
+func cloudFields(location, nodeCount string) map[string]string {
+	return map[string]string{
+		"cluster.name":                       "name",
+		"cluster.kubernetesVersion":          "kube-version",
+		"cluster.location":                   location,
+		"cluster.machineType":                "type",
+		"cluster.diskSizeGB":                 "disk-size",
+		"cluster.nodeCount":                  nodeCount,
+		"providerConfig.projectName":         "project",
+		"providerConfig.credentialsFilePath": "credentials",
+	}
+}
+
+// fields maps the settings of the cluster and providerConfig sections to the flags of the provision command of each provider.
+// The node count of Gardener clusters is the maximum of the autoscaler, as in the Hydroform cluster built by the commands.
+var fields = map[string]map[string]string{
+	ProviderGKE:         cloudFields("location", "nodes"),
+	ProviderAKS:         cloudFields("location", "nodes"),
+	ProviderGardenerGCP: cloudFields("region", "scaler-max"),
+	ProviderGardenerAWS: cloudFields("region", "scaler-max"),
+	ProviderGardenerAz:  cloudFields("region", "scaler-max"),
+	ProviderMinikube: {
+		"cluster.name":              "profile",
+		"cluster.kubernetesVersion": "kube-version",
+	},
+	ProviderK3s: {
+		"cluster.name":              "name",
+		"cluster.kubernetesVersion": "kube-version",
+	},
+}
+
+// Load reads a cluster spec from the given file
+func Load(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not read the cluster spec file '%s'", path)
+	}
+
+	spec := &Spec{}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, errors.Wrapf(err, "Could not decode the cluster spec file '%s'", path)
+	}
+	if spec.APIVersion != APIVersion {
+		return nil, fmt.Errorf("Unsupported apiVersion '%s' in cluster spec file '%s'. Supported apiVersion is '%s'", spec.APIVersion, path, APIVersion)
+	}
+	if spec.Kind != "" && spec.Kind != Kind {
+		return nil, fmt.Errorf("Unsupported kind '%s' in cluster spec file '%s'. Supported kind is '%s'", spec.Kind, path, Kind)
+	}
+	if _, ok := fields[spec.Provider]; !ok {
+		return nil, fmt.Errorf("Unsupported provider '%s' in cluster spec file '%s'", spec.Provider, path)
+	}
+	return spec, nil
+}
+
+// FromFlags builds the effective cluster spec of a provider from the given flags
+func FromFlags(provider string, flags *pflag.FlagSet) (*Spec, error) {
+	sections := map[string]map[string]interface{}{
+		clusterSection:  {},
+		providerSection: {},
+	}
+	custom := map[string]interface{}{}
+	byFlag := flagFields(provider)
+	flags.VisitAll(func(f *pflag.Flag) {
+		if skipFlag(f.Name) {
+			return
+		}
+		if field, ok := byFlag[f.Name]; ok {
+			sections[field.section][field.name] = flagValue(f)
+			return
+		}
+		custom[f.Name] = flagValue(f)
+	})
+	if len(custom) > 0 {
+		sections[providerSection]["customConfigurations"] = custom
+	}
+
+	// the sections are converted to their types through YAML, which uses the same keys as the spec file
+	data, err := yaml.Marshal(sections)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{APIVersion: APIVersion, Kind: Kind, Provider: provider}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, errors.Wrap(err, "Could not build the cluster spec from the flags")
+	}
+	return spec, nil
+}
+
+// Apply validates the cluster spec against the flags of the provider and sets all flags which were not explicitly set by the user to the values defined in the spec.
+// All settings are validated, also the ones overridden by flags, so that the spec file is usable without them.
+func (s *Spec) Apply(provider string, flags *pflag.FlagSet) error {
+	if s.Provider != provider {
+		return fmt.Errorf("The cluster spec is defined for provider '%s' and cannot be used to provision a '%s' cluster", s.Provider, provider)
+	}
+	values, err := s.values()
+	if err != nil {
+		return err
+	}
+
+	// sort keys to report errors deterministically
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	byFlag := flagFields(provider)
+	byField := fields[provider]
+	for _, k := range keys {
+		flag, ok := byField[k]
+		if !ok {
+			if _, typed := byFlag[k]; typed {
+				return fmt.Errorf("Setting '%s' of provider '%s' must be defined in the %s section as '%s'", k, provider, byFlag[k].section, byFlag[k].name)
+			}
+			flag = k
+		}
+		f := flags.Lookup(flag)
+		if f == nil || skipFlag(flag) {
+			return fmt.Errorf("Setting '%s' is not supported by provider '%s'", k, provider)
+		}
+		if err := validateValue(f, values[k]); err != nil {
+			return errors.Wrapf(err, "Invalid value for setting '%s'", k)
+		}
+	}
+
+	for _, k := range keys {
+		flag, ok := byField[k]
+		if !ok {
+			flag = k
+		}
+		f := flags.Lookup(flag)
+		if f.Changed {
+			continue
+		}
+		if err := setFlag(flags, f, values[k]); err != nil {
+			return errors.Wrapf(err, "Invalid value for setting '%s'", k)
+		}
+	}
+	return nil
+}
+
+// Write prints the cluster spec as YAML
+func (s *Spec) Write(w io.Writer) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Bind adds the --config and --print-config flags to a provision command.
+// The cluster spec is applied before the command runs and, if requested, the effective spec is printed instead of provisioning the cluster.
+func Bind(cmd *cobra.Command, provider string) {
+	var configFile string
+	var printConfig bool
+
+	cmd.Flags().StringVar(&configFile, configFlag, "", "Path to a cluster spec file with the cluster settings. Flags passed on the command line take precedence over the file.")
+	cmd.Flags().BoolVar(&printConfig, printConfigFlag, false, "Prints the effective cluster spec instead of provisioning the cluster.")
+
+	run := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		// only the flags of the provision command itself are part of the spec (global flags are excluded)
+		flags := c.LocalNonPersistentFlags()
+		if configFile != "" {
+			spec, err := Load(configFile)
+			if err != nil {
+				return err
+			}
+			if err := spec.Apply(provider, flags); err != nil {
+				return errors.Wrapf(err, "Invalid cluster spec file '%s'", configFile)
+			}
+		}
+		if printConfig {
+			spec, err := FromFlags(provider, flags)
+			if err != nil {
+				return err
+			}
+			return spec.Write(os.Stdout)
+		}
+		return run(c, args)
+	}
+}
+
+// values returns the settings defined in the spec. The settings of the typed sections are keyed by their path, such as "cluster.name", custom configurations by their flag.
+func (s *Spec) values() (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for section, v := range map[string]interface{}{clusterSection: s.Cluster, providerSection: s.ProviderConfig} {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		settings := map[string]interface{}{}
+		if err := yaml.Unmarshal(data, &settings); err != nil {
+			return nil, err
+		}
+		for k, v := range settings {
+			if k == "customConfigurations" {
+				continue
+			}
+			values[section+"."+k] = v
+		}
+	}
+	for k, v := range s.ProviderConfig.CustomConfigurations {
+		values[k] = v
+	}
+	return values, nil
+}
+
+type field struct {
+	section string
+	name    string
+}
+
+// flagFields returns the fields of the typed sections of a provider keyed by their flag
+func flagFields(provider string) map[string]field {
+	byFlag := map[string]field{}
+	for path, flag := range fields[provider] {
+		parts := strings.SplitN(path, ".", 2)
+		byFlag[flag] = field{section: parts[0], name: parts[1]}
+	}
+	return byFlag
+}
+
+func skipFlag(name string) bool {
+	return name == configFlag || name == printConfigFlag || name == "help"
+}
+
+func flagValue(f *pflag.Flag) interface{} {
+	if sv, ok := f.Value.(pflag.SliceValue); ok {
+		return sv.GetSlice()
+	}
+	val := f.Value.String()
+	switch f.Value.Type() {
+	case "bool":
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	case "int", "uint":
+		if i, err := strconv.Atoi(val); err == nil {
+			return i
+		}
+	}
+	return val
+}
+
+// validateValue checks that the value of a setting can be set to the flag without changing the flag
+func validateValue(f *pflag.Flag, value interface{}) error {
+	if _, ok := f.Value.(pflag.SliceValue); ok {
+		if _, ok := value.([]interface{}); !ok {
+			return fmt.Errorf("expected a list but got '%v'", value)
+		}
+		return nil
+	}
+
+	switch value.(type) {
+	case []interface{}, map[interface{}]interface{}:
+		return fmt.Errorf("expected a single value but got '%v'", value)
+	}
+	val := fmt.Sprint(value)
+	var err error
+	switch f.Value.Type() {
+	case "bool":
+		_, err = strconv.ParseBool(val)
+	case "int":
+		_, err = strconv.Atoi(val)
+	case "uint":
+		_, err = strconv.ParseUint(val, 10, 0)
+	case "duration":
+		_, err = time.ParseDuration(val)
+	}
+	if err != nil {
+		return fmt.Errorf("expected a %s but got '%v'", f.Value.Type(), value)
+	}
+	return nil
+}
+
+func setFlag(flags *pflag.FlagSet, f *pflag.Flag, value interface{}) error {
+	if sv, ok := f.Value.(pflag.SliceValue); ok {
+		items := value.([]interface{})
+		vals := make([]string, 0, len(items))
+		for _, item := range items {
+			vals = append(vals, fmt.Sprint(item))
+		}
+		if err := sv.Replace(vals); err != nil {
+			return err
+		}
+		f.Changed = true
+		return nil
+	}
+	return flags.Set(f.Name, fmt.Sprint(value))
+}
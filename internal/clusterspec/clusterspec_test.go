@@ -0,0 +1,189 @@
+package clusterspec
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+type testOpts struct {
+	Name     string
+	Zones    []string
+	Nodes    int
+	Attempts uint
+	Timeout  time.Duration
+	Debug    bool
+}
+
+func newFlagSet(o *testOpts) *pflag.FlagSet {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.StringVarP(&o.Name, "name", "n", "", "")
+	fs.StringSliceVarP(&o.Zones, "zones", "z", []string{"europe-west3-a"}, "")
+	fs.IntVar(&o.Nodes, "nodes", 3, "")
+	fs.UintVar(&o.Attempts, "attempts", 3, "")
+	fs.DurationVar(&o.Timeout, "timeout", 5*time.Minute, "")
+	fs.BoolVar(&o.Debug, "debug", false, "")
+	return fs
+}
+
+func writeSpec(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "clusterspec")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "cluster.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("valid spec", func(t *testing.T) {
+		spec, err := Load(writeSpec(t, `apiVersion: cli.kyma-project.io/v1alpha1
+kind: Cluster
+provider: gke
+cluster:
+  name: my-cluster
+  kubernetesVersion: 1.20
+  nodeCount: 5
+providerConfig:
+  projectName: my-project
+  customConfigurations:
+    attempts: 1
+`))
+		require.NoError(t, err)
+		require.Equal(t, ProviderGKE, spec.Provider)
+		require.Equal(t, Cluster{Name: "my-cluster", KubernetesVersion: "1.20", NodeCount: 5}, spec.Cluster)
+		require.Equal(t, "my-project", spec.ProviderConfig.ProjectName)
+		require.Equal(t, 1, spec.ProviderConfig.CustomConfigurations["attempts"])
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := Load(writeSpec(t, "apiVersion: v0\nprovider: gke\n"))
+		require.Error(t, err)
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		_, err := Load(writeSpec(t, "apiVersion: cli.kyma-project.io/v1alpha1\nprovider: gkee\n"))
+		require.Error(t, err)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := Load(writeSpec(t, "apiVersion: cli.kyma-project.io/v1alpha1\nprovider: gke\ncluster:\n  nodes: 3\n"))
+		require.Error(t, err)
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		_, err := Load(writeSpec(t, "apiVersion: cli.kyma-project.io/v1alpha1\nprovider: gke\ncluster:\n  nodeCount: three\n"))
+		require.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := Load("/does/not/exist.yaml")
+		require.Error(t, err)
+	})
+}
+
+func TestApply(t *testing.T) {
+	spec := &Spec{
+		APIVersion: APIVersion,
+		Provider:   ProviderGKE,
+		Cluster:    Cluster{Name: "from-file", NodeCount: 7},
+		ProviderConfig: ProviderConfig{
+			CustomConfigurations: map[string]interface{}{
+				"zones":    []interface{}{"us-central1-a", "us-central1-b"},
+				"attempts": 1,
+				"timeout":  "10m",
+				"debug":    true,
+			},
+		},
+	}
+
+	t.Run("flags override the file", func(t *testing.T) {
+		o := &testOpts{}
+		fs := newFlagSet(o)
+		require.NoError(t, fs.Parse([]string{"-n", "from-flag"}))
+
+		require.NoError(t, spec.Apply(ProviderGKE, fs))
+		require.Equal(t, "from-flag", o.Name)
+		require.Equal(t, []string{"us-central1-a", "us-central1-b"}, o.Zones)
+		require.Equal(t, 7, o.Nodes)
+		require.Equal(t, uint(1), o.Attempts)
+		require.Equal(t, 10*time.Minute, o.Timeout)
+		require.True(t, o.Debug)
+	})
+
+	t.Run("wrong provider", func(t *testing.T) {
+		fs := newFlagSet(&testOpts{})
+		require.Error(t, spec.Apply(ProviderAKS, fs))
+	})
+
+	t.Run("unknown setting", func(t *testing.T) {
+		fs := newFlagSet(&testOpts{})
+		s := &Spec{Provider: ProviderGKE, ProviderConfig: ProviderConfig{CustomConfigurations: map[string]interface{}{"flux-capacitor": 1}}}
+		require.Error(t, s.Apply(ProviderGKE, fs))
+	})
+
+	t.Run("unsupported field", func(t *testing.T) {
+		fs := newFlagSet(&testOpts{})
+		s := &Spec{Provider: ProviderK3s, Cluster: Cluster{MachineType: "n1-standard-4"}}
+		require.Error(t, s.Apply(ProviderK3s, fs))
+	})
+
+	t.Run("typed setting as custom configuration", func(t *testing.T) {
+		fs := newFlagSet(&testOpts{})
+		s := &Spec{Provider: ProviderGKE, ProviderConfig: ProviderConfig{CustomConfigurations: map[string]interface{}{"nodes": 2}}}
+		err := s.Apply(ProviderGKE, fs)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "nodeCount")
+	})
+
+	t.Run("list expected", func(t *testing.T) {
+		fs := newFlagSet(&testOpts{})
+		s := &Spec{Provider: ProviderGKE, ProviderConfig: ProviderConfig{CustomConfigurations: map[string]interface{}{"zones": "us-central1-a"}}}
+		require.Error(t, s.Apply(ProviderGKE, fs))
+	})
+
+	t.Run("invalid value overridden by flag", func(t *testing.T) {
+		o := &testOpts{}
+		fs := newFlagSet(o)
+		require.NoError(t, fs.Parse([]string{"--timeout", "1m"}))
+		s := &Spec{Provider: ProviderGKE, ProviderConfig: ProviderConfig{CustomConfigurations: map[string]interface{}{"timeout": "soon", "attempts": 2}}}
+		require.Error(t, s.Apply(ProviderGKE, fs))
+		require.Equal(t, uint(3), o.Attempts, "no setting must be applied if the spec is invalid")
+	})
+}
+
+func TestFromFlags(t *testing.T) {
+	o := &testOpts{}
+	fs := newFlagSet(o)
+	require.NoError(t, fs.Parse([]string{"-n", "my-cluster", "--nodes", "2"}))
+
+	spec, err := FromFlags(ProviderGKE, fs)
+	require.NoError(t, err)
+	require.Equal(t, APIVersion, spec.APIVersion)
+	require.Equal(t, Kind, spec.Kind)
+	require.Equal(t, Cluster{Name: "my-cluster", NodeCount: 2}, spec.Cluster)
+	require.Equal(t, map[string]interface{}{
+		"zones":    []interface{}{"europe-west3-a"},
+		"attempts": 3,
+		"timeout":  "5m0s",
+		"debug":    false,
+	}, spec.ProviderConfig.CustomConfigurations)
+
+	// the printed spec must be loadable again
+	var buf bytes.Buffer
+	require.NoError(t, spec.Write(&buf))
+	loaded, err := Load(writeSpec(t, buf.String()))
+	require.NoError(t, err)
+
+	o2 := &testOpts{}
+	fs2 := newFlagSet(o2)
+	require.NoError(t, loaded.Apply(ProviderGKE, fs2))
+	require.Equal(t, *o, *o2)
+}
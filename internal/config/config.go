@@ -0,0 +1,217 @@
+// Package config manages the config file of Kyma CLI.
+//
+// The config file "<kyma home>/config.yaml" contains named profiles. Each profile sets default values of flags by keys,
+// which consist of the path of a command without "kyma" and the name of the flag, joined by dots, such as "alpha.deploy.concurrency".
+// Keys with a shorter path apply to all subcommands, so "namespace" applies to every command with a "--namespace" flag.
+// Environment variables named after the keys, such as KYMA_ALPHA_DEPLOY_CONCURRENCY, override the config file, and flags override both.
+// Keys without a command path only have an environment variable if they set a persistent flag of the root command, such as KYMA_CI,
+// so that generic variables like KYMA_NAME don't change the flags of every command.
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	configFile = "config.yaml"
+	// DefaultProfile is used if the config file doesn't select a profile
+	DefaultProfile = "default"
+	// ProfileEnv is the environment variable which selects the profile instead of the config file
+	ProfileEnv = "KYMA_CONFIG_PROFILE"
+	envPrefix  = "KYMA_"
+)
+
+// Profile maps the keys of flags to their default values
+type Profile map[string]string
+
+// Config is the content of the config file
+type Config struct {
+	CurrentProfile string             `yaml:"currentProfile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+// Load reads the config file from the data directory. The config is empty if the file doesn't exist.
+func Load(dataDir string) (*Config, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dataDir, configFile))
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read the config file")
+	}
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(content, cfg); err != nil {
+		return nil, errors.Wrapf(err, "Could not decode the config file '%s'", filepath.Join(dataDir, configFile))
+	}
+	return cfg, nil
+}
+
+// Save writes the config file to the data directory
+func (c *Config) Save(dataDir string) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dataDir, configFile), content, 0600)
+}
+
+// ActiveProfile returns the name of the profile selected by the environment or the config file
+func (c *Config) ActiveProfile() string {
+	if name := os.Getenv(ProfileEnv); name != "" {
+		return name
+	}
+	if c.CurrentProfile != "" {
+		return c.CurrentProfile
+	}
+	return DefaultProfile
+}
+
+// Profile returns the profile with the given name, which is empty if it doesn't exist
+func (c *Config) Profile(name string) Profile {
+	return c.Profiles[name]
+}
+
+// HasProfile tells whether the profile exists. The default profile always exists.
+func (c *Config) HasProfile(name string) bool {
+	_, ok := c.Profiles[name]
+	return ok || name == DefaultProfile
+}
+
+// Set sets the value of the key in the profile and creates the profile if it doesn't exist
+func (c *Config) Set(profile, key, value string) {
+	if c.Profiles == nil {
+		c.Profiles = map[string]Profile{}
+	}
+	if c.Profiles[profile] == nil {
+		c.Profiles[profile] = Profile{}
+	}
+	c.Profiles[profile][key] = value
+}
+
+// Unset removes the key from the profile
+func (c *Config) Unset(profile, key string) {
+	delete(c.Profiles[profile], key)
+}
+
+// Keys returns the keys of the profile in alphabetical order
+func (p Profile) Keys() []string {
+	keys := make([]string, 0, len(p))
+	for key := range p {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Apply sets the flags of the command which were not set on the command line to the values of the environment variables or the profile.
+func Apply(cmd *cobra.Command, profile Profile) error {
+	var err error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || flag.Name == "help" {
+			return
+		}
+		keys := Keys(cmd, flag.Name)
+		global := cmd.Root().PersistentFlags().Lookup(flag.Name) != nil
+		value, source, ok := lookup(keys, profile, global)
+		if !ok {
+			return
+		}
+		if setErr := flag.Value.Set(value); setErr != nil {
+			err = errors.Wrapf(setErr, "Invalid value '%s' of the flag '--%s' set by %s", value, flag.Name, source)
+		}
+	})
+	return err
+}
+
+// lookup returns the value of the most specific key, which is set by an environment variable or otherwise by the profile.
+// The key without a command path is only read from the environment if the flag is global.
+func lookup(keys []string, profile Profile, global bool) (string, string, bool) {
+	for _, key := range keys {
+		if !global && !strings.Contains(key, ".") {
+			continue
+		}
+		if value, ok := os.LookupEnv(EnvName(key)); ok {
+			return value, fmt.Sprintf("the environment variable %s", EnvName(key)), true
+		}
+	}
+	for _, key := range keys {
+		if value, ok := profile[key]; ok {
+			return value, fmt.Sprintf("the key '%s' of the config file", key), true
+		}
+	}
+	return "", "", false
+}
+
+// Keys returns the keys which can set the flag of the command, from the most specific to the least specific one
+func Keys(cmd *cobra.Command, flag string) []string {
+	path := commandPath(cmd)
+	keys := make([]string, 0, len(path)+1)
+	for i := len(path); i >= 0; i-- {
+		keys = append(keys, strings.Join(append(path[:i:i], flag), "."))
+	}
+	return keys
+}
+
+// EnvName returns the name of the environment variable which overrides the key.
+func EnvName(key string) string {
+	return envPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// ValidateKey checks that the key sets a flag of a command or of its subcommands
+func ValidateKey(root *cobra.Command, key string) error {
+	segments := strings.Split(key, ".")
+	cmd := root
+	for _, segment := range segments[:len(segments)-1] {
+		next := subcommand(cmd, segment)
+		if next == nil {
+			return fmt.Errorf("Invalid key '%s': '%s' has no subcommand '%s'", key, cmd.CommandPath(), segment)
+		}
+		cmd = next
+	}
+	flag := segments[len(segments)-1]
+	if !hasFlag(cmd, flag) {
+		return fmt.Errorf("Invalid key '%s': neither '%s' nor its subcommands have the flag '--%s'", key, cmd.CommandPath(), flag)
+	}
+	return nil
+}
+
+func subcommand(cmd *cobra.Command, name string) *cobra.Command {
+	for _, c := range cmd.Commands() {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// hasFlag tells whether the command, its parents or its subcommands have the flag
+func hasFlag(cmd *cobra.Command, flag string) bool {
+	if cmd.Flags().Lookup(flag) != nil || cmd.InheritedFlags().Lookup(flag) != nil {
+		return true
+	}
+	for _, c := range cmd.Commands() {
+		if hasFlag(c, flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// commandPath returns the names of the command and its parents without the root command
+func commandPath(cmd *cobra.Command) []string {
+	var path []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		path = append([]string{c.Name()}, path...)
+	}
+	return path
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAndSave(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg, err := Load(dir)
+	require.NoError(t, err)
+	require.Empty(t, cfg.Profiles, "The config is empty if the config file doesn't exist")
+
+	cfg.Set("ci", "ci", "true")
+	cfg.Set("ci", "alpha.deploy.concurrency", "8")
+	cfg.Set(DefaultProfile, "verbose", "true")
+	cfg.Unset(DefaultProfile, "verbose")
+	cfg.CurrentProfile = "ci"
+	require.NoError(t, cfg.Save(dir))
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+	require.Equal(t, cfg, loaded)
+	require.Equal(t, []string{"alpha.deploy.concurrency", "ci"}, loaded.Profile("ci").Keys())
+	require.True(t, loaded.HasProfile(DefaultProfile))
+	require.False(t, loaded.HasProfile("dev"))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, configFile), []byte("profile: ci"), 0600))
+	_, err = Load(dir)
+	require.Error(t, err, "Unknown fields are rejected")
+}
+
+func TestActiveProfile(t *testing.T) {
+	require.Equal(t, DefaultProfile, (&Config{}).ActiveProfile())
+	cfg := &Config{CurrentProfile: "ci"}
+	require.Equal(t, "ci", cfg.ActiveProfile())
+
+	require.NoError(t, os.Setenv(ProfileEnv, "dev"))
+	defer os.Unsetenv(ProfileEnv)
+	require.Equal(t, "dev", cfg.ActiveProfile(), "The environment variable takes precedence over the config file")
+}
+
+func TestKeys(t *testing.T) {
+	t.Parallel()
+	root, deploy := newCommands()
+	require.Equal(t, []string{"alpha.deploy.concurrency", "alpha.concurrency", "concurrency"}, Keys(deploy, "concurrency"))
+	require.Equal(t, []string{"verbose"}, Keys(root, "verbose"))
+	require.Equal(t, "KYMA_ALPHA_DEPLOY_TIMEOUT_COMPONENT", EnvName("alpha.deploy.timeout-component"))
+}
+
+func TestApply(t *testing.T) {
+	root, deploy := newCommands()
+	require.NoError(t, deploy.ParseFlags([]string{"--timeout", "1m"}))
+
+	profile := Profile{
+		"verbose":                  "true",
+		"alpha.deploy.concurrency": "8",
+		"concurrency":              "2",
+		"timeout":                  "5m",
+		"namespace":                "dev",
+	}
+	require.NoError(t, os.Setenv("KYMA_ALPHA_NAMESPACE", "prod"))
+	defer os.Unsetenv("KYMA_ALPHA_NAMESPACE")
+	require.NoError(t, os.Setenv("KYMA_CONCURRENCY", "16"))
+	defer os.Unsetenv("KYMA_CONCURRENCY")
+	require.NoError(t, os.Setenv("KYMA_VERBOSE", "false"))
+	defer os.Unsetenv("KYMA_VERBOSE")
+	require.NoError(t, Apply(deploy, profile))
+
+	concurrency, _ := deploy.Flags().GetInt("concurrency")
+	require.Equal(t, 8, concurrency, "The most specific key is used")
+	timeout, _ := deploy.Flags().GetDuration("timeout")
+	require.Equal(t, time.Minute, timeout, "Flags of the command line are not overridden")
+	namespace, _ := deploy.Flags().GetString("namespace")
+	require.Equal(t, "prod", namespace, "Environment variables override the profile")
+	verbose, _ := root.PersistentFlags().GetBool("verbose")
+	require.False(t, verbose, "Environment variables without a command path override global flags")
+
+	require.Error(t, Apply(deploy, Profile{"concurrency": "many"}))
+}
+
+func TestValidateKey(t *testing.T) {
+	t.Parallel()
+	root, _ := newCommands()
+	for _, key := range []string{"verbose", "concurrency", "alpha.concurrency", "alpha.deploy.concurrency", "alpha.deploy.verbose"} {
+		require.NoError(t, ValidateKey(root, key), key)
+	}
+	for _, key := range []string{"alpha.deploy", "delete.concurrency", "alpha.deploy.unknown", "unknown"} {
+		require.Error(t, ValidateKey(root, key), key)
+	}
+}
+
+func newCommands() (*cobra.Command, *cobra.Command) {
+	root := &cobra.Command{Use: "kyma"}
+	root.PersistentFlags().Bool("verbose", false, "")
+	alpha := &cobra.Command{Use: "alpha"}
+	deploy := &cobra.Command{Use: "deploy", Run: func(*cobra.Command, []string) {}}
+	deploy.Flags().Int("concurrency", 4, "")
+	deploy.Flags().Duration("timeout", 20*time.Minute, "")
+	deploy.Flags().String("namespace", "default", "")
+	alpha.AddCommand(deploy)
+	root.AddCommand(alpha)
+	return root, deploy
+}
@@ -0,0 +1,105 @@
+package junitxml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CommandResult is the result of a command which ran the tests of a project, such as "npm test"
+type CommandResult struct {
+	// Suite is the name of the test suite in the report
+	Suite    string
+	Command  string
+	Duration time.Duration
+	ExitCode int
+	Output   string
+	// Report is the JUnit XML report written by the test framework. If it is empty, the command is reported as a single test case.
+	Report []byte
+}
+
+// WriteCommandResult creates an XML document for the result of the test command and writes it to out.
+func WriteCommandResult(out io.Writer, result CommandResult) error {
+	c := &Creator{}
+	report, err := c.generateCommandReport(result)
+	if err != nil {
+		return err
+	}
+	if err := c.write(out, report); err != nil {
+		return errors.Wrap(err, "while writing JUnit XML")
+	}
+	return nil
+}
+
+func (c *Creator) generateCommandReport(result CommandResult) (JUnitTestSuites, error) {
+	properties := append(c.packageProperties(), JUnitProperty{Name: "command", Value: result.Command})
+	if len(result.Report) == 0 {
+		return JUnitTestSuites{Suites: []JUnitTestSuite{c.newCommandTestSuite(result, properties)}}, nil
+	}
+
+	report, err := parseReport(result.Report)
+	if err != nil {
+		return JUnitTestSuites{}, errors.Wrap(err, "while reading the JUnit XML report of the test framework")
+	}
+	failed := false
+	for i := range report.Suites {
+		report.Suites[i].Name = result.Suite
+		report.Suites[i].Properties = properties
+		failed = failed || report.Suites[i].Failures > 0 || report.Suites[i].Errors > 0
+	}
+	// the command can fail without failed test cases, for example if no tests were found
+	if result.ExitCode != 0 && !failed {
+		report.Suites = append(report.Suites, c.newCommandTestSuite(result, properties))
+	}
+	return report, nil
+}
+
+func (c *Creator) newCommandTestSuite(result CommandResult, properties []JUnitProperty) JUnitTestSuite {
+	testCase := JUnitTestCase{
+		Classname: result.Suite,
+		Name:      result.Command,
+		Time:      c.formatDurationAsSeconds(result.Duration),
+	}
+	failures := 0
+	if result.ExitCode != 0 {
+		failures = 1
+		testCase.Failure = &JUnitFailure{
+			Message:  fmt.Sprintf("Failed with exit code %d", result.ExitCode),
+			Contents: strings.ToValidUTF8(result.Output, ""),
+		}
+	}
+	return JUnitTestSuite{
+		Name:       result.Suite,
+		Tests:      1,
+		Failures:   failures,
+		Time:       c.formatDurationAsSeconds(result.Duration),
+		Properties: properties,
+		TestCases:  []JUnitTestCase{testCase},
+	}
+}
+
+// parseReport reads a JUnit XML report, whose root is either a "testsuites" or a single "testsuite" element
+func parseReport(data []byte) (JUnitTestSuites, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return JUnitTestSuites{}, err
+	}
+	if root.XMLName.Local == "testsuite" {
+		var suite JUnitTestSuite
+		if err := xml.Unmarshal(data, &suite); err != nil {
+			return JUnitTestSuites{}, err
+		}
+		return JUnitTestSuites{Suites: []JUnitTestSuite{suite}}, nil
+	}
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		return JUnitTestSuites{}, err
+	}
+	return suites, nil
+}
@@ -0,0 +1,98 @@
+package junitxml_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/cli/internal/junitxml"
+	"github.com/stretchr/testify/require"
+	"gotest.tools/golden"
+)
+
+const pytestReport = `<?xml version="1.0" encoding="utf-8"?>
+<testsuites>
+	<testsuite name="pytest" errors="0" failures="1" skipped="1" tests="3" time="0.052" timestamp="2021-07-20T10:00:00" hostname="runtime">
+		<testcase classname="test_handler" name="test_greets_the_name_from_the_request_body" time="0.001" />
+		<testcase classname="test_handler" name="test_greets_serverless_by_default" time="0.002">
+			<failure message="AssertionError: assert 'Hello ' == 'Hello Serverless'">def test_greets_serverless_by_default():
+&gt;       assert main({"data": {}}, {}) == "Hello Serverless"</failure>
+		</testcase>
+		<testcase classname="test_handler" name="test_skipped" time="0.000">
+			<skipped type="pytest.skip" message="not ready" />
+		</testcase>
+	</testsuite>
+</testsuites>`
+
+// TestWriteCommandResult tests that proper JUnit XML document is written for the result of a test command.
+//
+// This test is based on golden file.
+// If the `-test.update-golden` flag is set then the actual content is written
+// to the golden file.
+//
+// Example:
+//   go test ./internal/junitxml/... -v -test.update-golden
+func TestWriteCommandResult(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		result junitxml.CommandResult
+	}{
+		{
+			name: "Command succeeded",
+			result: junitxml.CommandResult{
+				Suite:    "my-function",
+				Command:  "npm test",
+				Duration: 3 * time.Second,
+				Output:   "2 tests passed",
+			},
+		},
+		{
+			name: "Command failed",
+			result: junitxml.CommandResult{
+				Suite:    "my-function",
+				Command:  "npm test",
+				Duration: 3 * time.Second,
+				ExitCode: 1,
+				Output:   "1 test failed",
+			},
+		},
+		{
+			name: "Report of the test framework",
+			result: junitxml.CommandResult{
+				Suite:    "my-function",
+				Command:  "pytest",
+				Duration: 3 * time.Second,
+				ExitCode: 1,
+				Report:   []byte(pytestReport),
+			},
+		},
+		{
+			name: "Command failed without failed tests",
+			result: junitxml.CommandResult{
+				Suite:    "my-function",
+				Command:  "pytest",
+				Duration: time.Second,
+				ExitCode: 5,
+				Output:   "no tests ran",
+				Report:   []byte(`<testsuite name="pytest" errors="0" failures="0" skipped="0" tests="0" time="0.01"></testsuite>`),
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			gotOutput := new(bytes.Buffer)
+
+			err := junitxml.WriteCommandResult(gotOutput, test.result)
+
+			require.NoError(t, err)
+			golden.Assert(t, gotOutput.String(), t.Name()+".golden.xml")
+		})
+	}
+
+	t.Run("Invalid report of the test framework", func(t *testing.T) {
+		err := junitxml.WriteCommandResult(new(bytes.Buffer), junitxml.CommandResult{Report: []byte("<testsuites")})
+		require.Error(t, err)
+	})
+}
@@ -1,6 +1,23 @@
 package k3s
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	//ClusterRunning indicates that all nodes of a cluster are running
+	ClusterRunning = "running"
+	//ClusterStopped indicates that no node of a cluster is running
+	ClusterStopped = "stopped"
+	//ClusterDegraded indicates that only some nodes of a cluster are running
+	ClusterDegraded = "degraded"
+
+	roleServer = "server"
+	roleAgent  = "agent"
+)
 
 //ClusterList containing cluster entities
 type ClusterList struct {
@@ -15,10 +32,11 @@ type Cluster struct {
 
 //Node in the K3s setup (could be lb, main, agent etc.)
 type Node struct {
-	Name   string
-	Role   string
-	Labels map[string]string
-	State  State
+	Name         string
+	Role         string
+	Labels       map[string]string
+	State        State
+	PortMappings PortMappings
 }
 
 //State of a node
@@ -27,6 +45,9 @@ type State struct {
 	Status  string
 }
 
+//PortMappings of a node in the format 'HOST_IP:HOST_PORT->CONTAINER_PORT/PROTOCOL'
+type PortMappings []string
+
 //Unmarshal converts a JSON to nested structs
 func (cl *ClusterList) Unmarshal(data []byte) error {
 	var clusters []Cluster
@@ -36,3 +57,94 @@ func (cl *ClusterList) Unmarshal(data []byte) error {
 	cl.Clusters = clusters
 	return nil
 }
+
+//Servers returns the number of running and total server nodes
+func (c *Cluster) Servers() (running int, total int) {
+	return c.countNodes(roleServer)
+}
+
+//Agents returns the number of running and total agent nodes
+func (c *Cluster) Agents() (running int, total int) {
+	return c.countNodes(roleAgent)
+}
+
+//State returns whether the nodes of the cluster are running, stopped or only partially running
+func (c *Cluster) State() string {
+	running := 0
+	for _, n := range c.Nodes {
+		if n.State.Running {
+			running++
+		}
+	}
+	switch {
+	case running == 0:
+		return ClusterStopped
+	case running == len(c.Nodes):
+		return ClusterRunning
+	default:
+		return ClusterDegraded
+	}
+}
+
+//Ports returns the port mappings of all nodes of the cluster
+func (c *Cluster) Ports() []string {
+	var ports []string
+	for _, n := range c.Nodes {
+		ports = append(ports, n.PortMappings...)
+	}
+	sort.Strings(ports)
+	return ports
+}
+
+func (c *Cluster) countNodes(role string) (running int, total int) {
+	for _, n := range c.Nodes {
+		if n.Role != role {
+			continue
+		}
+		total++
+		if n.State.Running {
+			running++
+		}
+	}
+	return running, total
+}
+
+//UnmarshalJSON supports the port mappings of k3d nodes as list of Docker port specs (e.g. '0.0.0.0:8000:80/tcp')
+//and as Docker port map (e.g. {"80/tcp": [{"HostIp": "0.0.0.0", "HostPort": "8000"}]})
+func (p *PortMappings) UnmarshalJSON(data []byte) error {
+	var specs []string
+	if err := json.Unmarshal(data, &specs); err == nil {
+		mappings := make(PortMappings, 0, len(specs))
+		for _, spec := range specs {
+			mappings = append(mappings, formatPortSpec(spec))
+		}
+		*p = mappings
+		return nil
+	}
+
+	var portMap map[string][]struct {
+		HostIP   string `json:"HostIp"`
+		HostPort string `json:"HostPort"`
+	}
+	if err := json.Unmarshal(data, &portMap); err != nil {
+		return err
+	}
+	var mappings PortMappings
+	for containerPort, bindings := range portMap {
+		for _, b := range bindings {
+			mappings = append(mappings, fmt.Sprintf("%s:%s->%s", b.HostIP, b.HostPort, containerPort))
+		}
+	}
+	sort.Strings(mappings)
+	*p = mappings
+	return nil
+}
+
+//formatPortSpec converts a Docker port spec 'HOST_IP:HOST_PORT:CONTAINER_PORT/PROTOCOL' to the format of the port mappings
+func formatPortSpec(spec string) string {
+	i := strings.LastIndex(spec, ":")
+	if i < 0 {
+		return spec
+	}
+	return fmt.Sprintf("%s->%s", spec[:i], spec[i+1:])
+}
@@ -0,0 +1,62 @@
+package k3s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterState(t *testing.T) {
+	cluster := Cluster{
+		Name: "kyma",
+		Nodes: []Node{
+			{Name: "k3d-kyma-serverlb", Role: "loadbalancer", State: State{Running: true}},
+			{Name: "k3d-kyma-server-0", Role: "server", State: State{Running: true}},
+			{Name: "k3d-kyma-agent-0", Role: "agent", State: State{Running: false}},
+		},
+	}
+	require.Equal(t, ClusterDegraded, cluster.State())
+
+	running, total := cluster.Agents()
+	require.Equal(t, 0, running)
+	require.Equal(t, 1, total)
+
+	cluster.Nodes[2].State.Running = true
+	require.Equal(t, ClusterRunning, cluster.State())
+
+	for i := range cluster.Nodes {
+		cluster.Nodes[i].State.Running = false
+	}
+	require.Equal(t, ClusterStopped, cluster.State())
+}
+
+func TestPortMappingsUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []string
+	}{
+		{
+			name: "list of port specs",
+			json: `[{"name": "kyma", "nodes": [{"name": "lb", "portMappings": ["0.0.0.0:8000:80/tcp"]}]}]`,
+			want: []string{"0.0.0.0:8000->80/tcp"},
+		},
+		{
+			name: "port map",
+			json: `[{"name": "kyma", "nodes": [{"name": "lb", "portMappings": {"443/tcp": [{"HostIp": "0.0.0.0", "HostPort": "8443"}], "80/tcp": [{"HostIp": "0.0.0.0", "HostPort": "8000"}]}}]}]`,
+			want: []string{"0.0.0.0:8000->80/tcp", "0.0.0.0:8443->443/tcp"},
+		},
+		{
+			name: "no port mappings",
+			json: `[{"name": "kyma", "nodes": [{"name": "server"}]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := &ClusterList{}
+			require.NoError(t, cl.Unmarshal([]byte(tt.json)))
+			require.Equal(t, tt.want, cl.Clusters[0].Ports())
+		})
+	}
+}
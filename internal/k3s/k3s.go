@@ -2,9 +2,11 @@ package k3s
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,13 +21,21 @@ const (
 
 //RunCmd executes a k3d command with given arguments
 func RunCmd(verbose bool, timeout time.Duration, args ...string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	return RunCmdContext(context.Background(), verbose, timeout, args...)
+}
+
+//RunCmdContext executes a k3d command with given arguments. The command is killed if the context is cancelled.
+func RunCmdContext(ctx context.Context, verbose bool, timeout time.Duration, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "k3d", args...)
 
 	outBytes, err := cmd.CombinedOutput()
 	out := string(outBytes)
+	if ctx.Err() == context.Canceled {
+		return out, errors.Wrapf(ctx.Err(), "Executing 'k3d %s' was interrupted", strings.Join(args, " "))
+	}
 	if err != nil {
 		if verbose {
 			fmt.Printf("Failing command:\n  k3d %s\nwith output:\n  %s\nand error:\n  %s\n", strings.Join(args, " "), string(out), err)
@@ -104,19 +114,88 @@ func Initialize(verbose bool) error {
 	return err
 }
 
-//ClusterExists checks whether a cluster exists
-func ClusterExists(verbose bool, clusterName string) (bool, error) {
-	args := []string{"cluster", "list", "-o", "json"}
-	clusterJSON, err := RunCmd(verbose, defaultTimeout, args...)
+//RegistryName returns the name of the registry which is created for a cluster.
+//k3d prefixes the names of all its containers with 'k3d-'.
+func RegistryName(clusterName string) string {
+	return fmt.Sprintf("k3d-%s-registry", clusterName)
+}
+
+//ContextName returns the name of the kubeconfig context which k3d creates for a cluster.
+func ContextName(clusterName string) string {
+	return fmt.Sprintf("k3d-%s", clusterName)
+}
+
+//RegistryExists checks whether a registry exists
+func RegistryExists(verbose bool, registryName string) (bool, error) {
+	registryJSON, err := RunCmd(verbose, defaultTimeout, "registry", "list", "-o", "json")
 	if err != nil {
 		return false, err
 	}
+
+	var registries []struct {
+		Name string
+	}
+	if err := json.Unmarshal([]byte(registryJSON), &registries); err != nil {
+		return false, err
+	}
+
+	for _, r := range registries {
+		if r.Name == registryName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+//CreateRegistry creates a registry which is exposed on the given port of the host
+func CreateRegistry(verbose bool, timeout time.Duration, registryName string, port int) error {
+	// k3d adds the 'k3d-' prefix to the name itself
+	_, err := RunCmd(verbose, timeout, "registry", "create", strings.TrimPrefix(registryName, "k3d-"), "--port", strconv.Itoa(port))
+	return err
+}
+
+//DeleteRegistry deletes a registry
+func DeleteRegistry(verbose bool, timeout time.Duration, registryName string) error {
+	_, err := RunCmd(verbose, timeout, "registry", "delete", registryName)
+	return err
+}
+
+//ListClusters returns all k3d clusters including their nodes
+func ListClusters(verbose bool) (*ClusterList, error) {
+	clusterJSON, err := RunCmd(verbose, defaultTimeout, "cluster", "list", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
 	if verbose {
 		fmt.Printf("K3d cluster list JSON: '%s'", clusterJSON)
 	}
 
 	clusterList := &ClusterList{}
 	if err := clusterList.Unmarshal([]byte(clusterJSON)); err != nil {
+		return nil, err
+	}
+	return clusterList, nil
+}
+
+//GetCluster returns the k3d cluster with the given name
+func GetCluster(verbose bool, clusterName string) (*Cluster, error) {
+	clusterList, err := ListClusters(verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range clusterList.Clusters {
+		if clusterList.Clusters[i].Name == clusterName {
+			return &clusterList.Clusters[i], nil
+		}
+	}
+	return nil, fmt.Errorf("K3d cluster '%s' does not exist", clusterName)
+}
+
+//ClusterExists checks whether a cluster exists
+func ClusterExists(verbose bool, clusterName string) (bool, error) {
+	clusterList, err := ListClusters(verbose)
+	if err != nil {
 		return false, err
 	}
 
@@ -149,10 +228,14 @@ type Settings struct {
 	Version     string
 	PortMap     map[string]int
 	PortMapping []string
+	//RegistryUse is the address (name:port) of an existing registry. If empty, k3d creates a new registry for the cluster.
+	RegistryUse string
+	//NoSwitchContext keeps the current context of the kubeconfig instead of switching to the context of the cluster
+	NoSwitchContext bool
 }
 
-//StartCluster starts a cluster
-func StartCluster(verbose bool, timeout time.Duration, workers int, serverArgs []string, agentArgs []string, k3d Settings) error {
+//StartCluster starts a cluster. The creation is stopped if the context is cancelled.
+func StartCluster(ctx context.Context, verbose bool, timeout time.Duration, workers int, serverArgs []string, agentArgs []string, k3d Settings) error {
 	k3sImage, err := getK3sImage(k3d.Version)
 	if err != nil {
 		return err
@@ -162,11 +245,20 @@ func StartCluster(verbose bool, timeout time.Duration, workers int, serverArgs [
 		"--kubeconfig-update-default",
 		"--timeout", fmt.Sprintf("%ds", int(timeout.Seconds())),
 		"--agents", fmt.Sprintf("%d", workers),
-		"--registry-create",
+	}
+	if k3d.NoSwitchContext {
+		cmdArgs = append(cmdArgs, "--kubeconfig-switch-context=false")
+	}
+	if k3d.RegistryUse == "" {
+		cmdArgs = append(cmdArgs, "--registry-create")
+	} else {
+		cmdArgs = append(cmdArgs, "--registry-use", k3d.RegistryUse)
+	}
+	cmdArgs = append(cmdArgs,
 		"--image", k3sImage,
 		"--k3s-server-arg", "--disable",
 		"--k3s-server-arg", "traefik",
-	}
+	)
 
 	cmdArgs = append(cmdArgs, constructArgs("--k3s-server-arg", serverArgs)...)
 	cmdArgs = append(cmdArgs, constructArgs("--k3s-agent-arg", agentArgs)...)
@@ -175,7 +267,7 @@ func StartCluster(verbose bool, timeout time.Duration, workers int, serverArgs [
 	//add further k3d args which are not offered by the Kyma CLI flags
 	cmdArgs = append(cmdArgs, k3d.Args...)
 
-	_, err = RunCmd(verbose, timeout, cmdArgs...)
+	_, err = RunCmdContext(ctx, verbose, timeout, cmdArgs...)
 
 	return err
 }
@@ -185,3 +277,24 @@ func DeleteCluster(verbose bool, timeout time.Duration, clusterName string) erro
 	_, err := RunCmd(verbose, timeout, "cluster", "delete", clusterName)
 	return err
 }
+
+//StopCluster stops all nodes of a cluster without deleting it
+func StopCluster(verbose bool, timeout time.Duration, clusterName string) error {
+	_, err := RunCmd(verbose, timeout, "cluster", "stop", clusterName)
+	return err
+}
+
+//ResumeCluster starts all nodes of a previously stopped cluster
+func ResumeCluster(verbose bool, timeout time.Duration, clusterName string) error {
+	_, err := RunCmd(verbose, timeout, "cluster", "start", clusterName, "--timeout", fmt.Sprintf("%ds", int(timeout.Seconds())))
+	return err
+}
+
+//Kubeconfig returns the kubeconfig of a cluster
+func Kubeconfig(verbose bool, clusterName string) ([]byte, error) {
+	kubeconfig, err := RunCmd(verbose, defaultTimeout, "kubeconfig", "get", clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(kubeconfig), nil
+}
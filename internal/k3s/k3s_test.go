@@ -1,6 +1,7 @@
 package k3s
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -109,7 +110,7 @@ func TestStartCluster(t *testing.T) {
 		Version:     "1.20.7",
 		PortMapping: []string{"80:80@loadbalancer", "443:443@loadbalancer"},
 	}
-	err := StartCluster(false, 5*time.Second, 1, []string{"--alsologtostderr"}, []string{"--no-rollback"}, k3sSettings)
+	err := StartCluster(context.Background(), false, 5*time.Second, 1, []string{"--alsologtostderr"}, []string{"--no-rollback"}, k3sSettings)
 	require.NoError(t, err)
 }
 
@@ -131,3 +132,72 @@ func TestArgConstruction(t *testing.T) {
 	res := constructArgs("-p", rawPorts)
 	require.Equal(t, []string{"-p", "8000:80@loadbalancer", "-p", "8443:443@loadbalancer"}, res)
 }
+
+func TestGetCluster(t *testing.T) {
+	os.Setenv("K3D_MOCK_DUMPFILE", "cluster_list_exists.json")
+	defer os.Setenv("K3D_MOCK_DUMPFILE", "")
+
+	cluster, err := GetCluster(false, "kyma")
+	require.NoError(t, err)
+	require.Equal(t, "kyma", cluster.Name)
+	require.Equal(t, ClusterRunning, cluster.State())
+	running, total := cluster.Servers()
+	require.Equal(t, 1, running)
+	require.Equal(t, 1, total)
+	require.Equal(t, []string{"0.0.0.0:57465->6443/tcp"}, cluster.Ports())
+
+	_, err = GetCluster(false, "does-not-exist")
+	require.Error(t, err)
+}
+
+func TestStopCluster(t *testing.T) {
+	err := StopCluster(false, 5*time.Second, "kyma")
+	require.NoError(t, err)
+}
+
+func TestResumeCluster(t *testing.T) {
+	err := ResumeCluster(false, 5*time.Second, "kyma")
+	require.NoError(t, err)
+}
+
+func TestKubeconfig(t *testing.T) {
+	kubeconfig, err := Kubeconfig(false, "kyma")
+	require.NoError(t, err)
+	require.Contains(t, string(kubeconfig), "k3d-kyma")
+}
+
+func TestStartClusterWithRegistry(t *testing.T) {
+	k3sSettings := Settings{
+		ClusterName: "kyma",
+		Version:     "1.20.7",
+		RegistryUse: "k3d-kyma-registry:5001",
+	}
+	err := StartCluster(context.Background(), false, 5*time.Second, 1, nil, nil, k3sSettings)
+	require.NoError(t, err)
+}
+
+func TestStartClusterWithoutContextSwitch(t *testing.T) {
+	k3sSettings := Settings{
+		ClusterName:     "kyma",
+		Version:         "1.20.7",
+		RegistryUse:     "k3d-kyma-registry:5001",
+		NoSwitchContext: true,
+	}
+	err := StartCluster(context.Background(), false, 5*time.Second, 1, nil, nil, k3sSettings)
+	require.NoError(t, err)
+}
+
+func TestRegistry(t *testing.T) {
+	require.Equal(t, "k3d-kyma-registry", RegistryName("kyma"))
+
+	exists, err := RegistryExists(false, RegistryName("kyma"))
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = RegistryExists(false, RegistryName("other"))
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	require.NoError(t, CreateRegistry(false, 5*time.Second, RegistryName("kyma"), 5001))
+	require.NoError(t, DeleteRegistry(false, 5*time.Second, RegistryName("kyma")))
+}
@@ -13,6 +13,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 
 	istio "istio.io/client-go/pkg/clientset/versioned"
@@ -50,6 +51,36 @@ func NewFromConfigWithTimeout(url, file string, t time.Duration) (KymaKube, erro
 		return nil, err
 	}
 
+	kubeConfig, err := kubeConfig(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(config, kubeConfig, t)
+}
+
+// NewFromContext creates a new Kubernetes client for the context of the Kubeconfig in the file instead of its current context.
+// It is used for clusters whose context was added without switching to it.
+func NewFromContext(file, context string) (KymaKube, error) {
+	kubeConfig, err := kubeConfig(file)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := kubeConfig.Contexts[context]; !ok {
+		return nil, contextNotFound(context)
+	}
+
+	config, err := clientcmd.NewNonInteractiveClientConfig(*kubeConfig, context, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// the client reports the context it uses as the current one
+	kubeConfig.CurrentContext = context
+	return newClient(config, kubeConfig, defaultHTTPTimeout)
+}
+
+func newClient(config *rest.Config, kubeConfig *api.Config, t time.Duration) (KymaKube, error) {
 	config.Timeout = t
 
 	sClient, err := kubernetes.NewForConfig(config)
@@ -72,11 +103,6 @@ func NewFromConfigWithTimeout(url, file string, t time.Duration) (KymaKube, erro
 		return nil, err
 	}
 
-	kubeConfig, err := kubeConfig(file)
-	if err != nil {
-		return nil, err
-	}
-
 	return &client{
 			static:  sClient,
 			dynamic: dClient,
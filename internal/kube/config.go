@@ -37,21 +37,47 @@ func KubeconfigPath(file string) string {
 
 // Append adds the provided kubeconfig in the []byte to the Kubeconfig in the target path without altering other existing conifgs.
 // If the target path is empty, standard kubeconfig loading rules apply.
-func AppendConfig(cfg []byte, target string) error {
+// The current context switches to the one of the provided kubeconfig unless the current context is kept with the options.
+func AppendConfig(cfg []byte, target string, opts ...ConfigOption) error {
 	s, err := clientcmd.Load(cfg)
 	if err != nil {
 		return err
 	}
 
-	// Default PathOptions gets kubeconfig in this order: the explicit path given, KUBECONFIG current context, recommentded file path
-	po := clientcmd.NewDefaultPathOptions()
-	po.LoadingRules.ExplicitPath = target
+	o := &configOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return modifyConfig(target, func(t *api.Config) error {
+		return appendConfig(t, s, o)
+	})
+}
 
-	t, err := po.GetStartingConfig()
+// RemoveConfig remoes the provided kubeconfig in the []byte from the Kubeconfig in the target path without altering other existing conifgs.
+// If the target path is empty, standard kubeconfig loading rules apply.
+func RemoveConfig(cfg []byte, target string) error {
+	s, err := clientcmd.Load(cfg)
 	if err != nil {
 		return err
 	}
 
+	return modifyConfig(target, func(t *api.Config) error {
+		removeConfig(t, s)
+		return nil
+	})
+}
+
+// appendConfig adds the source kubeconfig to the target kubeconfig
+func appendConfig(t, s *api.Config, o *configOptions) error {
+	if o.tag != nil {
+		for _, ctx := range s.Contexts {
+			if err := setClusterTag(ctx, *o.tag); err != nil {
+				return err
+			}
+		}
+	}
+
 	// append contexts
 	for k, v := range s.Contexts {
 		t.Contexts[k] = v
@@ -67,30 +93,23 @@ func AppendConfig(cfg []byte, target string) error {
 		t.AuthInfos[k] = v
 	}
 
-	t.CurrentContext = s.CurrentContext
-
-	// write config back
-	return clientcmd.ModifyConfig(po, *t, false)
-}
-
-// RemoveConfig remoes the provided kubeconfig in the []byte from the Kubeconfig in the target path without altering other existing conifgs.
-// If the target path is empty, standard kubeconfig loading rules apply.
-func RemoveConfig(cfg []byte, target string) error {
-	s, err := clientcmd.Load(cfg)
-	if err != nil {
-		return err
+	if !o.keepCurrentContext || t.CurrentContext == "" {
+		t.CurrentContext = s.CurrentContext
 	}
+	return nil
+}
 
-	// Default PathOptions gets kubeconfig in this order: the explicit path given, KUBECONFIG current context, recommentded file path
-	po := clientcmd.NewDefaultPathOptions()
-	po.LoadingRules.ExplicitPath = target
-
-	t, err := po.GetStartingConfig()
-	if err != nil {
-		return err
+// removeConfig removes the source kubeconfig from the target kubeconfig
+func removeConfig(t, s *api.Config) {
+	// remove contexts, including the ones which were renamed after they were added
+	for k, ctx := range t.Contexts {
+		if _, ok := s.Clusters[ctx.Cluster]; ok {
+			delete(t.Contexts, k)
+			if t.CurrentContext == k {
+				t.CurrentContext = ""
+			}
+		}
 	}
-
-	// remove contexts
 	for k := range s.Contexts {
 		delete(t.Contexts, k)
 	}
@@ -105,8 +124,8 @@ func RemoveConfig(cfg []byte, target string) error {
 		delete(t.AuthInfos, k)
 	}
 
-	t.CurrentContext = ""
-
-	// write config back
-	return clientcmd.ModifyConfig(po, *t, false)
+	// only reset the current context if it points to a removed context
+	if _, ok := s.Contexts[t.CurrentContext]; ok {
+		t.CurrentContext = ""
+	}
 }
@@ -0,0 +1,97 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/kyma-project/cli/internal/clusterinfo"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const clusterKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: new-cluster
+  cluster:
+    server: https://new.example.com
+contexts:
+- name: new-context
+  context:
+    cluster: new-cluster
+    user: new-user
+current-context: new-context
+users:
+- name: new-user
+  user:
+    token: new-token
+`
+
+func loadClusterKubeconfig(t *testing.T) *api.Config {
+	cfg, err := clientcmd.Load([]byte(clusterKubeconfig))
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestAppendConfig(t *testing.T) {
+	tag := ClusterTag{Provider: clusterinfo.ClusterProviderGcp, Cluster: "new"}
+
+	t.Run("switch context", func(t *testing.T) {
+		cfg := loadKubeconfig(t)
+		require.NoError(t, appendConfig(cfg, loadClusterKubeconfig(t), &configOptions{tag: &tag}))
+		require.Equal(t, "new-context", cfg.CurrentContext)
+		require.Contains(t, cfg.Clusters, "new-cluster")
+		require.Contains(t, cfg.AuthInfos, "new-user")
+		require.Contains(t, cfg.Contexts, "local", "existing contexts must be kept")
+		require.Equal(t, &tag, clusterTag(cfg.Contexts["new-context"]))
+	})
+
+	t.Run("keep context", func(t *testing.T) {
+		cfg := loadKubeconfig(t)
+		require.NoError(t, appendConfig(cfg, loadClusterKubeconfig(t), &configOptions{keepCurrentContext: true}))
+		require.Equal(t, "local", cfg.CurrentContext)
+		require.Contains(t, cfg.Contexts, "new-context")
+		require.Nil(t, clusterTag(cfg.Contexts["new-context"]))
+	})
+
+	t.Run("keep context without current context", func(t *testing.T) {
+		cfg := loadKubeconfig(t)
+		cfg.CurrentContext = ""
+		require.NoError(t, appendConfig(cfg, loadClusterKubeconfig(t), &configOptions{keepCurrentContext: true}))
+		require.Equal(t, "new-context", cfg.CurrentContext, "the appended context must be used if there is no context to keep")
+	})
+
+	t.Run("invalid kubeconfig", func(t *testing.T) {
+		require.Error(t, AppendConfig([]byte("clusters: ["), writeKubeconfig(t)))
+	})
+}
+
+func TestRemoveConfig(t *testing.T) {
+	t.Run("remove renamed current context", func(t *testing.T) {
+		cfg := loadKubeconfig(t)
+		require.NoError(t, appendConfig(cfg, loadClusterKubeconfig(t), &configOptions{}))
+		require.NoError(t, renameContext(cfg, "new-context", "renamed"))
+		require.Equal(t, "renamed", cfg.CurrentContext)
+
+		removeConfig(cfg, loadClusterKubeconfig(t))
+		require.NotContains(t, cfg.Contexts, "renamed", "contexts of the removed cluster must be deleted")
+		require.NotContains(t, cfg.Clusters, "new-cluster")
+		require.NotContains(t, cfg.AuthInfos, "new-user")
+		require.Equal(t, "", cfg.CurrentContext)
+		require.Contains(t, cfg.Contexts, "local")
+		require.Contains(t, cfg.Contexts, "gke-context")
+	})
+
+	t.Run("keep current context of other cluster", func(t *testing.T) {
+		cfg := loadKubeconfig(t)
+		require.NoError(t, appendConfig(cfg, loadClusterKubeconfig(t), &configOptions{keepCurrentContext: true}))
+
+		removeConfig(cfg, loadClusterKubeconfig(t))
+		require.NotContains(t, cfg.Contexts, "new-context")
+		require.Equal(t, "local", cfg.CurrentContext)
+	})
+
+	t.Run("invalid kubeconfig", func(t *testing.T) {
+		require.Error(t, RemoveConfig([]byte("clusters: ["), writeKubeconfig(t)))
+	})
+}
@@ -0,0 +1,268 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/kyma-project/cli/internal/clusterinfo"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// tagExtension is the name of the context extension which marks the contexts created by Kyma CLI
+const tagExtension = "kyma-project.io/cluster"
+
+// ClusterTag marks a kubeconfig context created by Kyma CLI with the provider and the name of its cluster
+type ClusterTag struct {
+	Provider clusterinfo.ClusterProvider `json:"provider"`
+	Cluster  string                      `json:"cluster"`
+}
+
+// Context describes a context of the kubeconfig
+type Context struct {
+	Name      string      `json:"name"`
+	Cluster   string      `json:"cluster"`
+	User      string      `json:"user"`
+	Namespace string      `json:"namespace,omitempty"`
+	Current   bool        `json:"current"`
+	Tag       *ClusterTag `json:"kyma,omitempty"`
+}
+
+// ConfigOption changes how a kubeconfig is appended
+type ConfigOption func(o *configOptions)
+
+type configOptions struct {
+	keepCurrentContext bool
+	tag                *ClusterTag
+}
+
+// KeepCurrentContext keeps the current context of the target kubeconfig instead of switching to the context of the appended kubeconfig
+func KeepCurrentContext(keep bool) ConfigOption {
+	return func(o *configOptions) {
+		o.keepCurrentContext = keep
+	}
+}
+
+// WithClusterTag marks the contexts of the appended kubeconfig as created by Kyma CLI for the cluster
+func WithClusterTag(tag ClusterTag) ConfigOption {
+	return func(o *configOptions) {
+		o.tag = &tag
+	}
+}
+
+// pathOptions returns the options to load and write the kubeconfig in the target path.
+// Default PathOptions gets kubeconfig in this order: the explicit path given, KUBECONFIG current context, recommentded file path
+func pathOptions(target string) *clientcmd.PathOptions {
+	po := clientcmd.NewDefaultPathOptions()
+	po.LoadingRules.ExplicitPath = target
+	return po
+}
+
+// ListContexts returns the contexts of the kubeconfig in the target path sorted by name
+func ListContexts(target string) ([]Context, error) {
+	t, err := pathOptions(target).GetStartingConfig()
+	if err != nil {
+		return nil, err
+	}
+	contexts := make([]Context, 0, len(t.Contexts))
+	for name, ctx := range t.Contexts {
+		contexts = append(contexts, Context{
+			Name:      name,
+			Cluster:   ctx.Cluster,
+			User:      ctx.AuthInfo,
+			Namespace: ctx.Namespace,
+			Current:   name == t.CurrentContext,
+			Tag:       clusterTag(ctx),
+		})
+	}
+	sort.Slice(contexts, func(i, j int) bool { return contexts[i].Name < contexts[j].Name })
+	return contexts, nil
+}
+
+// CurrentContext returns the name of the current context of the kubeconfig in the target path, which is empty if there is none
+func CurrentContext(target string) (string, error) {
+	t, err := pathOptions(target).GetStartingConfig()
+	if err != nil {
+		return "", err
+	}
+	return t.CurrentContext, nil
+}
+
+// UseContext sets the current context of the kubeconfig in the target path
+func UseContext(target, name string) error {
+	return modifyConfig(target, func(t *api.Config) error {
+		return useContext(t, name)
+	})
+}
+
+// RenameContext renames the context of the kubeconfig in the target path. The current context follows the rename.
+func RenameContext(target, name, newName string) error {
+	return modifyConfig(target, func(t *api.Config) error {
+		return renameContext(t, name, newName)
+	})
+}
+
+// RemoveContext removes the context from the kubeconfig in the target path, together with its cluster and user if no other context refers to them
+func RemoveContext(target, name string) error {
+	return modifyConfig(target, func(t *api.Config) error {
+		return removeContext(t, name)
+	})
+}
+
+// TagContext marks the context of the kubeconfig in the target path as created by Kyma CLI.
+// It is used for contexts which are written by other tools, such as k3d or Minikube.
+func TagContext(target, name string, tag ClusterTag) error {
+	return modifyConfig(target, func(t *api.Config) error {
+		return tagContext(t, name, tag)
+	})
+}
+
+// ExportContext returns a standalone kubeconfig which only contains the context of the kubeconfig in the target path.
+// Certificates and tokens referenced by files are embedded, so that the kubeconfig can be used on other machines.
+func ExportContext(target, name string) (*api.Config, error) {
+	t, err := pathOptions(target).GetStartingConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctx, ok := t.Contexts[name]
+	if !ok {
+		return nil, contextNotFound(name)
+	}
+	cluster, ok := t.Clusters[ctx.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("Cluster '%s' of context '%s' not found", ctx.Cluster, name)
+	}
+	user, ok := t.AuthInfos[ctx.AuthInfo]
+	if !ok {
+		return nil, fmt.Errorf("User '%s' of context '%s' not found", ctx.AuthInfo, name)
+	}
+
+	export := api.NewConfig()
+	export.Contexts[name] = ctx.DeepCopy()
+	export.Clusters[ctx.Cluster] = cluster.DeepCopy()
+	export.AuthInfos[ctx.AuthInfo] = user.DeepCopy()
+	export.CurrentContext = name
+
+	if err := api.FlattenConfig(export); err != nil {
+		return nil, errors.Wrapf(err, "Could not embed the certificates of context '%s'", name)
+	}
+	exported := export.AuthInfos[ctx.AuthInfo]
+	if exported.TokenFile != "" {
+		token, err := ioutil.ReadFile(exported.TokenFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not embed the token of context '%s'", name)
+		}
+		exported.Token = string(token)
+		exported.TokenFile = ""
+	}
+	return export, nil
+}
+
+// modifyConfig changes the kubeconfig in the target path and writes it back unless the change fails
+func modifyConfig(target string, change func(t *api.Config) error) error {
+	po := pathOptions(target)
+	t, err := po.GetStartingConfig()
+	if err != nil {
+		return err
+	}
+	if err := change(t); err != nil {
+		return err
+	}
+	return clientcmd.ModifyConfig(po, *t, false)
+}
+
+func useContext(t *api.Config, name string) error {
+	if _, ok := t.Contexts[name]; !ok {
+		return contextNotFound(name)
+	}
+	t.CurrentContext = name
+	return nil
+}
+
+func renameContext(t *api.Config, name, newName string) error {
+	ctx, ok := t.Contexts[name]
+	if !ok {
+		return contextNotFound(name)
+	}
+	if _, exists := t.Contexts[newName]; exists {
+		return fmt.Errorf("Context '%s' already exists", newName)
+	}
+	delete(t.Contexts, name)
+	t.Contexts[newName] = ctx
+	if t.CurrentContext == name {
+		t.CurrentContext = newName
+	}
+	return nil
+}
+
+func removeContext(t *api.Config, name string) error {
+	ctx, ok := t.Contexts[name]
+	if !ok {
+		return contextNotFound(name)
+	}
+	delete(t.Contexts, name)
+
+	clusterUsed, userUsed := false, false
+	for _, other := range t.Contexts {
+		clusterUsed = clusterUsed || other.Cluster == ctx.Cluster
+		userUsed = userUsed || other.AuthInfo == ctx.AuthInfo
+	}
+	if !clusterUsed {
+		delete(t.Clusters, ctx.Cluster)
+	}
+	if !userUsed {
+		delete(t.AuthInfos, ctx.AuthInfo)
+	}
+
+	if t.CurrentContext == name {
+		t.CurrentContext = ""
+	}
+	return nil
+}
+
+func tagContext(t *api.Config, name string, tag ClusterTag) error {
+	ctx, ok := t.Contexts[name]
+	if !ok {
+		return contextNotFound(name)
+	}
+	return setClusterTag(ctx, tag)
+}
+
+// contextNotFound returns the error for a context which is not in the kubeconfig
+func contextNotFound(name string) error {
+	return fmt.Errorf("Context '%s' not found in the kubeconfig", name)
+}
+
+// clusterTag returns the tag of a context created by Kyma CLI, or nil for other contexts
+func clusterTag(ctx *api.Context) *ClusterTag {
+	ext, ok := ctx.Extensions[tagExtension]
+	if !ok {
+		return nil
+	}
+	unknown, ok := ext.(*runtime.Unknown)
+	if !ok {
+		return nil
+	}
+	tag := &ClusterTag{}
+	if err := json.Unmarshal(unknown.Raw, tag); err != nil {
+		return nil
+	}
+	return tag
+}
+
+// setClusterTag adds the tag as an extension to the context, which kubectl keeps when it changes the kubeconfig
+func setClusterTag(ctx *api.Context, tag ClusterTag) error {
+	raw, err := json.Marshal(tag)
+	if err != nil {
+		return err
+	}
+	if ctx.Extensions == nil {
+		ctx.Extensions = map[string]runtime.Object{}
+	}
+	ctx.Extensions[tagExtension] = &runtime.Unknown{Raw: raw, ContentType: runtime.ContentTypeJSON}
+	return nil
+}
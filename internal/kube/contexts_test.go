@@ -0,0 +1,198 @@
+package kube
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/cli/internal/clusterinfo"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: local
+  cluster:
+    server: https://localhost:6443
+- name: gke-cluster
+  cluster:
+    server: https://gke.example.com
+    certificate-authority: %s
+contexts:
+- name: local
+  context:
+    cluster: local
+    user: local
+- name: gke-context
+  context:
+    cluster: gke-cluster
+    user: gke-user
+    namespace: dev
+    extensions:
+    - name: kyma-project.io/cluster
+      extension:
+        provider: gcp
+        cluster: my-cluster
+current-context: local
+users:
+- name: local
+  user:
+    token: local-token
+- name: gke-user
+  user:
+    tokenFile: %s
+`
+
+func writeKubeconfig(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	ca := filepath.Join(dir, "ca.crt")
+	require.NoError(t, ioutil.WriteFile(ca, []byte("cert"), 0600))
+	token := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(token, []byte("gke-token"), 0600))
+
+	path := filepath.Join(dir, "config")
+	require.NoError(t, ioutil.WriteFile(path, []byte(fmt.Sprintf(kubeconfig, ca, token)), 0600))
+	return path
+}
+
+func TestListContexts(t *testing.T) {
+	path := writeKubeconfig(t)
+
+	contexts, err := ListContexts(path)
+	require.NoError(t, err)
+	require.Equal(t, []Context{
+		{
+			Name:      "gke-context",
+			Cluster:   "gke-cluster",
+			User:      "gke-user",
+			Namespace: "dev",
+			Tag:       &ClusterTag{Provider: clusterinfo.ClusterProviderGcp, Cluster: "my-cluster"},
+		},
+		{
+			Name:    "local",
+			Cluster: "local",
+			User:    "local",
+			Current: true,
+		},
+	}, contexts)
+}
+
+func TestExportContext(t *testing.T) {
+	path := writeKubeconfig(t)
+
+	export, err := ExportContext(path, "gke-context")
+	require.NoError(t, err)
+	require.Equal(t, "gke-context", export.CurrentContext)
+	require.Len(t, export.Contexts, 1)
+	require.Len(t, export.Clusters, 1)
+	require.Len(t, export.AuthInfos, 1)
+
+	cluster := export.Clusters["gke-cluster"]
+	require.Equal(t, "", cluster.CertificateAuthority, "certificate file must be embedded")
+	require.Equal(t, []byte("cert"), cluster.CertificateAuthorityData)
+	user := export.AuthInfos["gke-user"]
+	require.Equal(t, "", user.TokenFile, "token file must be embedded")
+	require.Equal(t, "gke-token", user.Token)
+
+	_, err = ExportContext(path, "unknown")
+	require.Error(t, err)
+}
+
+// loadKubeconfig returns the test kubeconfig without writing it, so that its changes can be checked in memory
+func loadKubeconfig(t *testing.T) *api.Config {
+	cfg, err := clientcmd.Load([]byte(fmt.Sprintf(kubeconfig, "ca.crt", "token")))
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestCurrentContext(t *testing.T) {
+	current, err := CurrentContext(writeKubeconfig(t))
+	require.NoError(t, err)
+	require.Equal(t, "local", current)
+}
+
+func TestUseContext(t *testing.T) {
+	cfg := loadKubeconfig(t)
+	require.NoError(t, useContext(cfg, "gke-context"))
+	require.Equal(t, "gke-context", cfg.CurrentContext)
+
+	require.Error(t, useContext(cfg, "unknown"))
+	require.Equal(t, "gke-context", cfg.CurrentContext, "current context must not change for an unknown context")
+
+	require.Error(t, UseContext(writeKubeconfig(t), "unknown"))
+}
+
+func TestRenameContext(t *testing.T) {
+	t.Run("rename current context", func(t *testing.T) {
+		cfg := loadKubeconfig(t)
+		require.NoError(t, renameContext(cfg, "local", "dev"))
+		require.NotContains(t, cfg.Contexts, "local")
+		require.Equal(t, "local", cfg.Contexts["dev"].Cluster)
+		require.Equal(t, "dev", cfg.CurrentContext, "current context must follow the rename")
+	})
+
+	t.Run("rename other context", func(t *testing.T) {
+		cfg := loadKubeconfig(t)
+		require.NoError(t, renameContext(cfg, "gke-context", "gke"))
+		require.Equal(t, "local", cfg.CurrentContext)
+		require.Equal(t, &ClusterTag{Provider: clusterinfo.ClusterProviderGcp, Cluster: "my-cluster"}, clusterTag(cfg.Contexts["gke"]), "tag must be kept")
+	})
+
+	t.Run("existing name", func(t *testing.T) {
+		cfg := loadKubeconfig(t)
+		require.Error(t, renameContext(cfg, "gke-context", "local"))
+		require.Contains(t, cfg.Contexts, "gke-context")
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		require.Error(t, renameContext(loadKubeconfig(t), "unknown", "dev"))
+		require.Error(t, RenameContext(writeKubeconfig(t), "unknown", "dev"))
+	})
+}
+
+func TestRemoveContext(t *testing.T) {
+	t.Run("remove current context", func(t *testing.T) {
+		cfg := loadKubeconfig(t)
+		require.NoError(t, removeContext(cfg, "local"))
+		require.NotContains(t, cfg.Contexts, "local")
+		require.NotContains(t, cfg.Clusters, "local")
+		require.NotContains(t, cfg.AuthInfos, "local")
+		require.Equal(t, "", cfg.CurrentContext)
+		require.Contains(t, cfg.Contexts, "gke-context")
+	})
+
+	t.Run("keep cluster and user of other contexts", func(t *testing.T) {
+		cfg := loadKubeconfig(t)
+		cfg.Contexts["gke-admin"] = &api.Context{Cluster: "gke-cluster", AuthInfo: "gke-user"}
+		require.NoError(t, removeContext(cfg, "gke-context"))
+		require.NotContains(t, cfg.Contexts, "gke-context")
+		require.Contains(t, cfg.Clusters, "gke-cluster")
+		require.Contains(t, cfg.AuthInfos, "gke-user")
+		require.Equal(t, "local", cfg.CurrentContext)
+	})
+
+	t.Run("unknown context", func(t *testing.T) {
+		require.Error(t, removeContext(loadKubeconfig(t), "unknown"))
+		require.Error(t, RemoveContext(writeKubeconfig(t), "unknown"))
+	})
+}
+
+func TestTagContext(t *testing.T) {
+	cfg := loadKubeconfig(t)
+	require.Nil(t, clusterTag(cfg.Contexts["local"]))
+
+	tag := ClusterTag{Provider: clusterinfo.ClusterProviderK3s, Cluster: "kyma"}
+	require.NoError(t, tagContext(cfg, "local", tag))
+	require.Equal(t, &tag, clusterTag(cfg.Contexts["local"]))
+
+	require.Error(t, tagContext(cfg, "unknown", tag))
+	require.Error(t, TagContext(writeKubeconfig(t), "unknown", tag))
+}
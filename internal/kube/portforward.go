@@ -0,0 +1,67 @@
+package kube
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward forwards a free local port to the given port of a pod until the stop channel is closed.
+// It returns the local port as soon as the port forwarding is ready.
+func PortForward(config *rest.Config, namespace, pod string, port string, stop <-chan struct{}) (uint16, error) {
+	return PortForwardTo(config, namespace, pod, "0", port, stop)
+}
+
+// PortForwardTo forwards the local port to the given port of a pod until the stop channel is closed.
+// If the local port is "0", a free local port is used. It returns the local port as soon as the port forwarding is ready.
+func PortForwardTo(config *rest.Config, namespace, pod string, localPort, port string, stop <-chan struct{}) (uint16, error) {
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return 0, err
+	}
+
+	host, err := url.Parse(config.Host)
+	if err != nil {
+		return 0, err
+	}
+	target := &url.URL{
+		Scheme: host.Scheme,
+		Host:   host.Host,
+		Path:   fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s/portforward", strings.TrimSuffix(host.Path, "/"), namespace, pod),
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, target)
+
+	ready := make(chan struct{})
+	errOut := &strings.Builder{}
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"localhost"}, []string{fmt.Sprintf("%s:%s", localPort, port)}, stop, ready, ioutil.Discard, errOut)
+	if err != nil {
+		return 0, err
+	}
+
+	failed := make(chan error, 1)
+	go func() {
+		failed <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-ready:
+	case err := <-failed:
+		return 0, errors.Wrapf(err, "Could not forward port %s of pod '%s' %s", port, pod, errOut.String())
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		return 0, err
+	}
+	if len(ports) == 0 {
+		return 0, errors.Errorf("Could not forward port %s of pod '%s'", port, pod)
+	}
+	return ports[0].Local, nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/blang/semver/v4"
 	docker "github.com/docker/docker/client"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -19,7 +20,12 @@ const (
 
 //RunCmd executes a minikube command with given arguments
 func RunCmd(verbose bool, profile string, timeout time.Duration, rawArgs ...string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	return RunCmdContext(context.Background(), verbose, profile, timeout, rawArgs...)
+}
+
+//RunCmdContext executes a minikube command with given arguments. The command is killed if the context is cancelled.
+func RunCmdContext(ctx context.Context, verbose bool, profile string, timeout time.Duration, rawArgs ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	args := []string{}
@@ -34,6 +40,9 @@ func RunCmd(verbose bool, profile string, timeout time.Duration, rawArgs ...stri
 	out, err := cmd.CombinedOutput()
 	unquotedOut := strings.Replace(string(out), "'", "", -1)
 
+	if ctx.Err() == context.Canceled {
+		return unquotedOut, errors.Wrapf(ctx.Err(), "Executing the 'minikube %s' command was interrupted", strings.Join(args, " "))
+	}
 	if ctx.Err() == context.DeadlineExceeded {
 		return unquotedOut, fmt.Errorf("Executing 'minikube %s' command with output '%s' timed out, try running the command manually or increasing timeout using the 'timeout' flag", strings.Join(args, " "), out)
 	}
@@ -0,0 +1,259 @@
+// Package output prints the results of commands in the format selected with the "--output" flag.
+//
+// Commands which support the flag add it with Enable and print their result object with Print.
+// Everything else a command writes, such as steps and warnings, goes to the standard error when a format is selected, so that the standard output only contains the result.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// Format is the output format of a command. It implements the pflag.Value interface.
+type Format string
+
+const (
+	// Default is the human-readable output of a command
+	Default Format = ""
+	// JSON prints the result as JSON
+	JSON Format = "json"
+	// YAML prints the result as YAML
+	YAML Format = "yaml"
+	// Table prints the result as a table
+	Table Format = "table"
+	// Wide prints the result as a table with additional columns
+	Wide Format = "wide"
+	// None prints no result
+	None Format = "none"
+	// JSONPath prints the fields of the result selected by the JSONPath template which follows "jsonpath="
+	JSONPath Format = "jsonpath"
+	// Template prints the result with the Go template which follows "go-template="
+	Template Format = "go-template"
+
+	// text selects the default format. Some commands used it as the name of their human-readable output before the formats were shared.
+	text = "text"
+)
+
+// annotation marks the commands which support the output flag
+const annotation = "kyma-project.io/output-formats"
+
+// Formats are the formats supported by Print
+var Formats = []Format{JSON, YAML, Table, Wide, None, JSONPath, Template}
+
+// Tabular is implemented by results which can be printed as a table
+type Tabular interface {
+	// TableRows returns the header and the rows of the table. The wide table can contain additional columns.
+	TableRows(wide bool) (header []string, rows [][]string)
+}
+
+// String returns the format as set with the flag
+func (f *Format) String() string {
+	return string(*f)
+}
+
+// Set sets the format. The formats supported by a command are checked with Validate.
+func (f *Format) Set(v string) error {
+	*f = Format(strings.TrimSpace(v))
+	if *f == text {
+		*f = Default
+	}
+	if f.Kind() == JSONPath || f.Kind() == Template {
+		if f.Expression() == "" {
+			return fmt.Errorf("Missing template of the output format '%s='", f.Kind())
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the flag shown in the help
+func (f *Format) Type() string {
+	return "string"
+}
+
+// Kind returns the format without the template of the "jsonpath" and "go-template" formats
+func (f Format) Kind() Format {
+	return Format(strings.SplitN(string(f), "=", 2)[0])
+}
+
+// Expression returns the template of the "jsonpath" and "go-template" formats
+func (f Format) Expression() string {
+	parts := strings.SplitN(string(f), "=", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+// Enable adds the "--output" flag with the given formats to the command. Without formats, the command supports all Formats.
+// Commands can support additional formats which they print themselves, such as "junit".
+func Enable(cmd *cobra.Command, f *Format, formats ...Format) {
+	if len(formats) == 0 {
+		formats = Formats
+	}
+	names := make([]string, 0, len(formats))
+	usage := make([]string, 0, len(formats))
+	for _, format := range formats {
+		names = append(names, string(format))
+		if format == JSONPath || format == Template {
+			usage = append(usage, string(format)+"=TEMPLATE")
+		} else {
+			usage = append(usage, string(format))
+		}
+	}
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[annotation] = strings.Join(names, ",")
+	cmd.Flags().VarP(f, "output", "o", fmt.Sprintf(`Output format of the result. One of: %s. If a format is set, the progress of the command is printed to the standard error, so that the standard output only contains the result.`, strings.Join(usage, "|")))
+}
+
+// Supported returns the formats supported by the command, which are empty if the command doesn't support the output flag
+func Supported(cmd *cobra.Command) []Format {
+	value, ok := cmd.Annotations[annotation]
+	if !ok {
+		return nil
+	}
+	var formats []Format
+	for _, name := range strings.Split(value, ",") {
+		formats = append(formats, Format(name))
+	}
+	return formats
+}
+
+// Validate checks that the command supports the format
+func Validate(cmd *cobra.Command, f Format) error {
+	if f == Default {
+		return nil
+	}
+	formats := Supported(cmd)
+	if len(formats) == 0 {
+		return fmt.Errorf("The command '%s' doesn't support the \"--output\" flag", cmd.CommandPath())
+	}
+	names := make([]string, 0, len(formats))
+	for _, supported := range formats {
+		if f.Kind() == supported {
+			return nil
+		}
+		names = append(names, string(supported))
+	}
+	return fmt.Errorf("The command '%s' doesn't support the output format '%s'. Use one of: %s", cmd.CommandPath(), f.Kind(), strings.Join(names, "|"))
+}
+
+// Print writes the result to out in the given format. The default format prints results which implement Tabular as a table, and others as YAML.
+func Print(out io.Writer, f Format, result interface{}) error {
+	switch f.Kind() {
+	case None:
+		return nil
+	case JSON:
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "Could not encode the result as JSON")
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case YAML:
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return errors.Wrap(err, "Could not encode the result as YAML")
+		}
+		_, err = out.Write(data)
+		return err
+	case JSONPath:
+		return printJSONPath(out, f.Expression(), result)
+	case Template:
+		return printTemplate(out, f.Expression(), result)
+	case Table, Wide:
+		tabular, ok := result.(Tabular)
+		if !ok {
+			return fmt.Errorf("The result can't be printed as a table")
+		}
+		printTable(out, tabular, f == Wide)
+		return nil
+	case Default:
+		if tabular, ok := result.(Tabular); ok {
+			printTable(out, tabular, false)
+			return nil
+		}
+		return Print(out, YAML, result)
+	}
+	return fmt.Errorf("Unknown output format '%s'", f.Kind())
+}
+
+// printJSONPath prints the fields selected by the JSONPath template like kubectl does
+func printJSONPath(out io.Writer, expression string, result interface{}) error {
+	parser := jsonpath.New("output").AllowMissingKeys(true)
+	if err := parser.Parse(expression); err != nil {
+		return errors.Wrapf(err, "Invalid JSONPath template '%s'", expression)
+	}
+	data, err := generic(result)
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	if err := parser.Execute(buf, data); err != nil {
+		return errors.Wrapf(err, "Could not apply the JSONPath template '%s'", expression)
+	}
+	_, err = fmt.Fprintln(out, buf.String())
+	return err
+}
+
+// printTemplate prints the result with the Go template, which refers to the fields by their JSON names
+func printTemplate(out io.Writer, expression string, result interface{}) error {
+	tmpl, err := template.New("output").Parse(expression)
+	if err != nil {
+		return errors.Wrapf(err, "Invalid Go template '%s'", expression)
+	}
+	data, err := generic(result)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(out, data); err != nil {
+		return errors.Wrapf(err, "Could not apply the Go template '%s'", expression)
+	}
+	return nil
+}
+
+// generic converts the result into maps and slices, so that templates use the same field names as the JSON output
+func generic(result interface{}) (interface{}, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not encode the result as JSON")
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, errors.Wrap(err, "Could not decode the result")
+	}
+	return value, nil
+}
+
+func printTable(out io.Writer, result Tabular, wide bool) {
+	header, rows := result.TableRows(wide)
+	writer := NewTableWriter(header, out)
+	writer.AppendBulk(rows)
+	writer.Render()
+}
+
+// NewTableWriter creates a table writer without borders or wrapped cells, like the tables of kubectl
+func NewTableWriter(columns []string, out io.Writer) *tablewriter.Table {
+	writer := tablewriter.NewWriter(out)
+	writer.SetBorder(false)
+	writer.SetHeader(columns)
+	writer.SetAlignment(tablewriter.ALIGN_LEFT)
+	writer.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	writer.SetHeaderLine(false)
+	writer.SetRowSeparator("")
+	writer.SetCenterSeparator("")
+	writer.SetColumnSeparator("")
+	writer.SetAutoWrapText(false)
+	return writer
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+type cluster struct {
+	Name  string `json:"name"`
+	Nodes int    `json:"nodes"`
+}
+
+type clusters []cluster
+
+func (l clusters) TableRows(wide bool) ([]string, [][]string) {
+	header := []string{"NAME"}
+	if wide {
+		header = append(header, "NODES")
+	}
+	var rows [][]string
+	for _, c := range l {
+		row := []string{c.Name}
+		if wide {
+			row = append(row, strconv.Itoa(c.Nodes))
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+func TestPrint(t *testing.T) {
+	t.Parallel()
+	result := clusters{{Name: "kyma", Nodes: 3}, {Name: "dev", Nodes: 1}}
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{format: JSON, want: "[\n  {\n    \"name\": \"kyma\",\n    \"nodes\": 3\n  },\n  {\n    \"name\": \"dev\",\n    \"nodes\": 1\n  }\n]\n"},
+		{format: YAML, want: "- name: kyma\n  nodes: 3\n- name: dev\n  nodes: 1\n"},
+		{format: None, want: ""},
+		{format: "jsonpath={[*].name}", want: "kyma dev\n"},
+		{format: `go-template={{range .}}{{.name}}={{.nodes}};{{end}}`, want: "kyma=3;dev=1;"},
+		{format: Table, want: "  NAME  \n  kyma  \n  dev   \n"},
+		{format: Default, want: "  NAME  \n  kyma  \n  dev   \n"},
+		{format: Wide, want: "  NAME  NODES  \n  kyma  3      \n  dev   1      \n"},
+	}
+	for _, test := range tests {
+		out := &bytes.Buffer{}
+		require.NoError(t, Print(out, test.format, result), test.format)
+		require.Equal(t, test.want, out.String(), test.format)
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, Print(out, Default, cluster{Name: "kyma"}))
+	require.Equal(t, "name: kyma\nnodes: 0\n", out.String(), "Results which are no tables are printed as YAML by default")
+
+	require.Error(t, Print(out, Table, cluster{Name: "kyma"}), "Results which are no tables can't be printed as a table")
+	require.Error(t, Print(out, "jsonpath={.[", result), "Invalid JSONPath templates are rejected")
+	require.Error(t, Print(out, "go-template={{.name", result), "Invalid Go templates are rejected")
+	require.Error(t, Print(out, "xml", result))
+}
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+	var f Format
+	require.NoError(t, f.Set("jsonpath={.name}"))
+	require.Equal(t, JSONPath, f.Kind())
+	require.Equal(t, "{.name}", f.Expression())
+
+	require.NoError(t, f.Set("text"))
+	require.Equal(t, Default, f, "The text format is the default format")
+
+	require.Error(t, f.Set("go-template="), "The template is required")
+}
+
+func TestEnable(t *testing.T) {
+	t.Parallel()
+	var f Format
+	cmd := &cobra.Command{Use: "list"}
+	require.Error(t, Validate(cmd, JSON), "Commands support no formats unless enabled")
+	require.NoError(t, Validate(cmd, Default))
+
+	Enable(cmd, &f, JSON, JSONPath, "junit")
+	require.NoError(t, cmd.ParseFlags([]string{"-o", "jsonpath={.name}"}))
+	require.NoError(t, Validate(cmd, f))
+	require.NoError(t, Validate(cmd, "junit"))
+	require.Error(t, Validate(cmd, YAML))
+	require.Equal(t, []Format{JSON, JSONPath, "junit"}, Supported(cmd))
+
+	all := &cobra.Command{Use: "get"}
+	Enable(all, &f)
+	require.Equal(t, Formats, Supported(all))
+}
@@ -0,0 +1,354 @@
+// Package plugins discovers, installs, and runs the plugins of Kyma CLI.
+//
+// A plugin is an executable named "kyma-<name>" in the plugins folder of the Kyma CLI home directory or in a folder of the PATH.
+// Kyma CLI runs the plugin for "kyma <name>" if there is no built-in command with the name. Dashes in the name of a plugin
+// separate its subcommands, so "kyma-company-login" runs for "kyma company login".
+package plugins
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// Prefix is the prefix of the names of the plugin executables
+	Prefix     = "kyma-"
+	pluginsDir = "plugins"
+)
+
+// Environment variables set for plugins
+const (
+	KubeconfigEnv     = "KYMA_KUBECONFIG"
+	CIEnv             = "KYMA_CI"
+	VerboseEnv        = "KYMA_VERBOSE"
+	NonInteractiveEnv = "KYMA_NON_INTERACTIVE"
+	DomainEnv         = "KYMA_CLUSTER_DOMAIN"
+)
+
+var (
+	validName     = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+	validChecksum = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	// httpClient downloads the plugins
+	httpClient = http.DefaultClient
+)
+
+// Plugin is an executable which extends Kyma CLI
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// ShadowedBy is the path of the plugin with the same name which is found first, or the built-in command with the same name. A shadowed plugin is never run.
+	ShadowedBy string `json:"shadowedBy,omitempty"`
+}
+
+// Settings are passed to the plugins in environment variables
+type Settings struct {
+	// Kubeconfig is the path of the kubeconfig used by Kyma CLI
+	Kubeconfig string
+	// KubeconfigFlag is set if the kubeconfig was selected with the "--kubeconfig" flag, which then replaces the KUBECONFIG environment variable of the plugin
+	KubeconfigFlag bool
+	CI             bool
+	Verbose        bool
+	NonInteractive bool
+	// Domain of the Kyma cluster. It is empty if the cluster is not available.
+	Domain string
+}
+
+// Dir returns the plugins folder in the Kyma CLI home directory
+func Dir(home string) string {
+	return filepath.Join(home, pluginsDir)
+}
+
+// Dirs returns the folders which are searched for plugins in their order: the plugins folder of the Kyma CLI home directory, and the folders of the PATH
+func Dirs(home string) []string {
+	return append([]string{Dir(home)}, filepath.SplitList(os.Getenv("PATH"))...)
+}
+
+// List returns the plugins found in the folders. Plugins with the same name as a plugin found before are marked as shadowed.
+func List(dirs []string) ([]Plugin, error) {
+	var plugins []Plugin
+	found := map[string]string{}
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		entries, err := ioutil.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not read the plugins in '%s'", dir)
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry)
+			if !ok {
+				continue
+			}
+			p := Plugin{Name: name, Path: filepath.Join(dir, entry.Name())}
+			if first, ok := found[name]; ok {
+				p.ShadowedBy = first
+			} else {
+				found[name] = p.Path
+			}
+			plugins = append(plugins, p)
+		}
+	}
+	return plugins, nil
+}
+
+// Find returns the plugin with the given name which is found first in the folders
+func Find(dirs []string, name string) (Plugin, bool, error) {
+	plugins, err := List(dirs)
+	if err != nil {
+		return Plugin{}, false, err
+	}
+	for _, p := range plugins {
+		if p.Name == name {
+			return p, true, nil
+		}
+	}
+	return Plugin{}, false, nil
+}
+
+// pluginName returns the name of the plugin if the file is a plugin executable
+func pluginName(fi os.FileInfo) (string, bool) {
+	name := fi.Name()
+	if !strings.HasPrefix(name, Prefix) || fi.IsDir() {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	} else if fi.Mode()&0111 == 0 {
+		return "", false
+	}
+	name = strings.TrimPrefix(name, Prefix)
+	return name, validName.MatchString(name)
+}
+
+// IsBuiltIn tells whether the name is a command or alias of the root command, which can't be replaced by a plugin
+func IsBuiltIn(root *cobra.Command, name string) bool {
+	if name == "help" {
+		return true
+	}
+	for _, c := range root.Commands() {
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FirstCommand returns the first subcommand of the plugin, which is run by "kyma <first command>"
+func FirstCommand(name string) string {
+	return strings.SplitN(name, "-", 2)[0]
+}
+
+// MarkBuiltIns marks the plugins whose first subcommand is a command of the root command as shadowed
+func MarkBuiltIns(root *cobra.Command, plugins []Plugin) {
+	for i := range plugins {
+		first := FirstCommand(plugins[i].Name)
+		if plugins[i].ShadowedBy == "" && IsBuiltIn(root, first) {
+			plugins[i].ShadowedBy = fmt.Sprintf("built-in command '%s %s'", root.Name(), first)
+		}
+	}
+}
+
+// ValidateName checks that the name of a plugin consists of lower case letters, digits, and dashes
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("Invalid plugin name '%s': use lower case letters, digits, and dashes", name)
+	}
+	return nil
+}
+
+// NameFromSource returns the name of the plugin installed from the URL or file, whose name must start with "kyma-"
+func NameFromSource(source string) (string, error) {
+	base := filepath.Base(source)
+	if u, err := url.Parse(source); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		base = path.Base(u.Path)
+	}
+	if !strings.HasPrefix(base, Prefix) {
+		return "", fmt.Errorf("Could not derive the plugin name from '%s', because its file name doesn't start with '%s'. Set the name of the plugin", source, Prefix)
+	}
+	name := strings.TrimPrefix(base, Prefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, ValidateName(name)
+}
+
+// Install downloads the plugin from the URL, or copies it from the local file, into the plugins folder and returns its path.
+// If the SHA-256 checksum is set, the plugin is only installed if its content matches the checksum. Plugins are only downloaded
+// over plain HTTP if the checksum is set. An installed plugin with the same name is only replaced if force is set.
+func Install(source, dir, name, checksum string, force bool) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	checksum = strings.ToLower(checksum)
+	if checksum != "" && !validChecksum.MatchString(checksum) {
+		return "", fmt.Errorf("Invalid SHA-256 checksum '%s': use 64 hexadecimal digits", checksum)
+	}
+	target := filepath.Join(dir, Prefix+name)
+	if runtime.GOOS == "windows" {
+		target += ".exe"
+	}
+	if _, err := os.Stat(target); err == nil && !force {
+		return "", fmt.Errorf("Plugin '%s' is already installed in '%s'", name, target)
+	}
+
+	content, err := open(source, checksum != "")
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", errors.Wrap(err, "Could not create the plugins folder")
+	}
+	// the plugin is written to a temporary file first, so that a failed download doesn't leave a broken plugin
+	tmp, err := ioutil.TempFile(dir, ".install-")
+	if err != nil {
+		return "", errors.Wrap(err, "Could not create the plugin file")
+	}
+	defer os.Remove(tmp.Name())
+	hash := sha256.New()
+	if _, err := io.Copy(tmp, io.TeeReader(content, hash)); err != nil {
+		tmp.Close()
+		return "", errors.Wrapf(err, "Could not read the plugin from '%s'", source)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); checksum != "" && sum != checksum {
+		return "", fmt.Errorf("The SHA-256 checksum %s of the plugin from '%s' doesn't match the expected checksum %s", sum, source, checksum)
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return "", errors.Wrap(err, "Could not install the plugin")
+	}
+	return target, nil
+}
+
+// open returns the content of the plugin at the URL or in the local file.
+// Plain HTTP is only used if the content is verified afterwards, because the plugin could be replaced on the way.
+func open(source string, verified bool) (io.ReadCloser, error) {
+	u, err := url.Parse(source)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		f, err := os.Open(source)
+		return f, errors.Wrapf(err, "Could not open the plugin file '%s'", source)
+	}
+	if u.Scheme == "http" && !verified {
+		return nil, fmt.Errorf("The plugin from '%s' can't be verified. Download it over HTTPS or set its SHA-256 checksum", source)
+	}
+	resp, err := httpClient.Get(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not download the plugin from '%s'", source)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("Could not download the plugin from '%s': %s", source, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// Remove removes the plugin from the plugins folder. Plugins found in the PATH are not removed.
+func Remove(dir, name string) (string, error) {
+	plugins, err := List([]string{dir})
+	if err != nil {
+		return "", err
+	}
+	for _, p := range plugins {
+		if p.Name == name {
+			return p.Path, os.Remove(p.Path)
+		}
+	}
+	return "", fmt.Errorf("Plugin '%s' is not installed in '%s'", name, dir)
+}
+
+// Env returns the environment of the plugin, which is the environment of Kyma CLI with the settings
+func Env(s Settings) []string {
+	env := append(os.Environ(),
+		KubeconfigEnv+"="+s.Kubeconfig,
+		CIEnv+"="+strconv.FormatBool(s.CI),
+		VerboseEnv+"="+strconv.FormatBool(s.Verbose),
+		NonInteractiveEnv+"="+strconv.FormatBool(s.NonInteractive),
+	)
+	if s.KubeconfigFlag {
+		env = append(env, "KUBECONFIG="+s.Kubeconfig)
+	}
+	if s.Domain != "" {
+		env = append(env, DomainEnv+"="+s.Domain)
+	}
+	return env
+}
+
+// Run runs the plugin with the arguments and the settings, connected to the standard streams of Kyma CLI, and returns its exit code.
+// Kyma CLI waits for the plugin when it receives SIGINT or SIGTERM, and forwards SIGTERM to the plugin. SIGINT is not forwarded,
+// because Ctrl+C reaches the plugin from the terminal anyway, and a second interrupt could make the plugin skip its cleanup.
+func Run(p Plugin, args []string, s Settings) (int, error) {
+	cmd := exec.Command(p.Path, args...)
+	cmd.Env = Env(s)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer func() {
+		signal.Stop(signals)
+		close(signals)
+	}()
+	if err := cmd.Start(); err != nil {
+		return 1, errors.Wrapf(err, "Could not run the plugin '%s'", p.Path)
+	}
+	go func() {
+		for sig := range signals {
+			if sig != os.Interrupt {
+				_ = cmd.Process.Signal(sig)
+			}
+		}
+	}()
+
+	err := cmd.Wait()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitCode(exitErr), nil
+	}
+	if err != nil {
+		return 1, errors.Wrapf(err, "Could not run the plugin '%s'", p.Path)
+	}
+	return 0, nil
+}
+
+// exitCode returns the exit code of the plugin. A plugin stopped by a signal exits with 128 plus the number of the signal, like in shells.
+func exitCode(err *exec.ExitError) int {
+	if status, ok := err.Sys().(interface {
+		Signaled() bool
+		Signal() syscall.Signal
+	}); ok && status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+	return err.ExitCode()
+}
@@ -0,0 +1,44 @@
+// +build !windows
+
+package plugins
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	exit := filepath.Join(dir, "kyma-exit")
+	require.NoError(t, ioutil.WriteFile(exit, []byte("#!/bin/sh\nexit 3\n"), 0755))
+	code, err := Run(Plugin{Name: "exit", Path: exit}, nil, Settings{})
+	require.NoError(t, err)
+	require.Equal(t, 3, code, "The exit code of the plugin is returned")
+
+	// the plugin signals that it handles SIGTERM by creating a file, and then waits to be terminated
+	ready := filepath.Join(dir, "ready")
+	trap := filepath.Join(dir, "kyma-trap")
+	script := fmt.Sprintf("#!/bin/sh\ntrap 'exit 4' TERM\ntouch %s\nwhile true; do sleep 0.1; done\n", ready)
+	require.NoError(t, ioutil.WriteFile(trap, []byte(script), 0755))
+	go func() {
+		for {
+			if _, err := os.Stat(ready); err == nil {
+				_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+	code, err = Run(Plugin{Name: "trap", Path: trap}, nil, Settings{})
+	require.NoError(t, err)
+	require.Equal(t, 4, code, "SIGTERM is forwarded to the plugin and Kyma CLI waits for it")
+}
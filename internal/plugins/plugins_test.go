@@ -0,0 +1,155 @@
+package plugins
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	t.Parallel()
+	first, second := tempDir(t), tempDir(t)
+	defer os.RemoveAll(first)
+	defer os.RemoveAll(second)
+	writePlugin(t, first, "kyma-login", 0755)
+	writePlugin(t, first, "kyma-notes", 0644)
+	writePlugin(t, first, "kubectl-kyma", 0755)
+	writePlugin(t, second, "kyma-login", 0755)
+	writePlugin(t, second, "kyma-config-export", 0755)
+
+	plugins, err := List([]string{first, "", filepath.Join(first, "missing"), second})
+	require.NoError(t, err)
+	require.Equal(t, []Plugin{
+		{Name: "login", Path: filepath.Join(first, "kyma-login")},
+		{Name: "config-export", Path: filepath.Join(second, "kyma-config-export")},
+		{Name: "login", Path: filepath.Join(second, "kyma-login"), ShadowedBy: filepath.Join(first, "kyma-login")},
+	}, plugins, "Only executables with the prefix are plugins, and the first one of a name is used")
+
+	root := &cobra.Command{Use: "kyma"}
+	root.AddCommand(&cobra.Command{Use: "config"})
+	MarkBuiltIns(root, plugins)
+	require.Equal(t, "built-in command 'kyma config'", plugins[1].ShadowedBy)
+	require.Equal(t, "config", FirstCommand("config-export"))
+
+	p, ok, err := Find([]string{first, second}, "login")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, filepath.Join(first, "kyma-login"), p.Path)
+	_, ok, err = Find([]string{first, second}, "notes")
+	require.NoError(t, err)
+	require.False(t, ok, "Files which are not executable are no plugins")
+}
+
+func TestInstallAndRemove(t *testing.T) {
+	t.Parallel()
+	src, home := tempDir(t), tempDir(t)
+	defer os.RemoveAll(src)
+	defer os.RemoveAll(home)
+	writePlugin(t, src, "kyma-login", 0644)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/releases/kyma-audit" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("#!/bin/sh\necho audit\n"))
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+	httpClient = tlsServer.Client()
+
+	path, err := Install(filepath.Join(src, "kyma-login"), Dir(home), "login", "", false)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(home, "plugins", "kyma-login"), path)
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NotZero(t, fi.Mode()&0111, "Installed plugins are executable")
+
+	_, err = Install(filepath.Join(src, "kyma-login"), Dir(home), "login", "", false)
+	require.Error(t, err, "Installed plugins are only replaced with force")
+	_, err = Install(filepath.Join(src, "kyma-login"), Dir(home), "login", "", true)
+	require.NoError(t, err)
+
+	name, err := NameFromSource(server.URL + "/releases/kyma-audit")
+	require.NoError(t, err)
+	require.Equal(t, "audit", name)
+	_, err = Install(server.URL+"/releases/kyma-audit", Dir(home), name, "", false)
+	require.Error(t, err, "Plugins are only downloaded over plain HTTP with a checksum")
+	_, err = Install(server.URL+"/releases/kyma-audit", Dir(home), name, strings.Repeat("0", 64), false)
+	require.Error(t, err, "Plugins with a wrong checksum are not installed")
+	_, err = os.Stat(filepath.Join(home, "plugins", "kyma-audit"))
+	require.True(t, os.IsNotExist(err), "Plugins with a wrong checksum don't leave a plugin")
+	_, err = Install(server.URL+"/releases/kyma-audit", Dir(home), name, "invalid", false)
+	require.Error(t, err)
+
+	checksum := sha256.Sum256([]byte("#!/bin/sh\necho audit\n"))
+	path, err = Install(server.URL+"/releases/kyma-audit", Dir(home), name, strings.ToUpper(hex.EncodeToString(checksum[:])), false)
+	require.NoError(t, err)
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "#!/bin/sh\necho audit\n", string(content))
+
+	_, err = Install(tlsServer.URL+"/releases/kyma-audit", Dir(home), name, "", true)
+	require.NoError(t, err, "Plugins are downloaded over HTTPS without a checksum")
+
+	_, err = Install(server.URL+"/releases/kyma-missing", Dir(home), "missing", hex.EncodeToString(checksum[:]), false)
+	require.Error(t, err)
+	_, err = os.Stat(filepath.Join(home, "plugins", "kyma-missing"))
+	require.True(t, os.IsNotExist(err), "Failed downloads don't leave a plugin")
+
+	_, err = NameFromSource("/downloads/login")
+	require.Error(t, err, "The name can only be derived from files with the prefix")
+	require.Error(t, ValidateName("Login/../x"))
+
+	path, err = Remove(Dir(home), "login")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(home, "plugins", "kyma-login"), path)
+	_, err = Remove(Dir(home), "login")
+	require.Error(t, err)
+}
+
+func TestEnv(t *testing.T) {
+	t.Parallel()
+	env := Env(Settings{Kubeconfig: "/kubeconfig", CI: true, Domain: "kyma.example.com"})
+	require.Equal(t, "/kubeconfig", lookup(env, KubeconfigEnv))
+	require.Equal(t, "true", lookup(env, CIEnv))
+	require.Equal(t, "false", lookup(env, VerboseEnv))
+	require.Equal(t, "kyma.example.com", lookup(env, DomainEnv))
+	require.Equal(t, os.Getenv("KUBECONFIG"), lookup(env, "KUBECONFIG"), "KUBECONFIG is only replaced for the --kubeconfig flag")
+
+	env = Env(Settings{Kubeconfig: "/kubeconfig", KubeconfigFlag: true})
+	require.Equal(t, "/kubeconfig", lookup(env, "KUBECONFIG"))
+	require.Empty(t, lookup(env, DomainEnv))
+}
+
+// lookup returns the last value of the variable in the environment, which is the one used by the plugin
+func lookup(env []string, name string) string {
+	value := ""
+	for _, e := range env {
+		if strings.HasPrefix(e, name+"=") {
+			value = strings.TrimPrefix(e, name+"=")
+		}
+	}
+	return value
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "plugins")
+	require.NoError(t, err)
+	return dir
+}
+
+func writePlugin(t *testing.T, dir, name string, mode os.FileMode) {
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode))
+}
@@ -0,0 +1,159 @@
+package serverless
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/docker/runtimes"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/pkg/errors"
+)
+
+const (
+	// sourceFolder is the folder of the build context which contains the sources of the Function
+	sourceFolder = "src"
+	// installVolume is the folder of the image which contains the sources and dependencies of the Function
+	installVolume = runtimes.KubelessPath
+)
+
+// ignoredFolders are not added to the build context of Git Functions
+var ignoredFolders = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"__pycache__":  true,
+}
+
+// Dockerfile returns the Dockerfile which builds the image of a Function on top of the runtime image.
+// Like the build on the cluster, it installs the dependencies first and copies the sources afterwards.
+func Dockerfile(runtime string) (string, error) {
+	_, depsFile, ok := workspace.InlineFileNames(runtime)
+	if !ok {
+		return "", fmt.Errorf("Unsupported runtime '%s'", runtime)
+	}
+
+	commands := runtimes.ContainerCommands(runtime, false, false)
+	install, run := commands[0], commands[len(commands)-1]
+
+	lines := []string{
+		fmt.Sprintf("FROM %s", runtimes.ContainerImage(runtime)),
+		"USER root",
+	}
+	for _, env := range runtimes.ContainerEnvs(runtime, false) {
+		// the variables of the runtime refer to each other in the Kubernetes syntax
+		lines = append(lines, "ENV "+strings.ReplaceAll(env, "$(KUBELESS_INSTALL_VOLUME)", "${KUBELESS_INSTALL_VOLUME}"))
+	}
+	lines = append(lines,
+		fmt.Sprintf("COPY %s/%s %s/%s", sourceFolder, depsFile, installVolume, depsFile),
+		fmt.Sprintf("RUN %s", install),
+		fmt.Sprintf("COPY %s %s", sourceFolder, installVolume),
+		"RUN rm -rf /tmp/*",
+		fmt.Sprintf("USER %s", runtimes.ContainerUser(runtime)),
+		fmt.Sprintf("EXPOSE %s", runtimes.ServerPort),
+		fmt.Sprintf(`CMD ["/bin/sh", "-c", "%s"]`, run),
+	)
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// BuildContext returns the build context of the image of the Function as a tar archive.
+// The sources of inline Functions are read from the source and dependency files in the folder, the same files which are applied to the cluster.
+// The sources of Git Functions are read from all files of the folder.
+func BuildContext(cfg workspace.Cfg, dir string) (io.Reader, error) {
+	dockerfile, err := Dockerfile(cfg.Runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := tar.NewWriter(&buffer)
+	if err := addFile(writer, "Dockerfile", []byte(dockerfile)); err != nil {
+		return nil, err
+	}
+
+	if cfg.Source.Type == workspace.SourceTypeGit {
+		_, depsFile, _ := workspace.InlineFileNames(cfg.Runtime)
+		if _, err := os.Stat(filepath.Join(dir, depsFile)); err != nil {
+			return nil, errors.Wrapf(err, "Could not find the dependencies of the Function. The folder '%s' must contain the %s file", dir, depsFile)
+		}
+		err = addFolder(writer, dir)
+	} else {
+		err = addInlineSources(writer, cfg, dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return &buffer, nil
+}
+
+// addInlineSources adds the source and dependency files of the Function with the default names of the runtime, as they are stored in the Function
+func addInlineSources(writer *tar.Writer, cfg workspace.Cfg, dir string) error {
+	sourceFile, depsFile, _ := workspace.InlineFileNames(cfg.Runtime)
+	files := map[string]string{
+		sourceFile: sourceFile,
+		depsFile:   depsFile,
+	}
+	if cfg.Source.SourceHandlerName != "" {
+		files[sourceFile] = cfg.Source.SourceHandlerName
+	}
+	if cfg.Source.DepsHandlerName != "" {
+		files[depsFile] = cfg.Source.DepsHandlerName
+	}
+
+	for name, source := range files {
+		content, err := ioutil.ReadFile(filepath.Join(dir, source))
+		if err != nil {
+			return errors.Wrap(err, "Could not read the sources of the Function")
+		}
+		if err := addFile(writer, sourceFolder+"/"+name, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addFolder(writer *tar.Writer, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && ignoredFolders[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return addFile(writer, sourceFolder+"/"+filepath.ToSlash(rel), content)
+	})
+}
+
+func addFile(writer *tar.Writer, name string, content []byte) error {
+	if err := writer.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(content)),
+	}); err != nil {
+		return err
+	}
+	_, err := writer.Write(content)
+	return err
+}
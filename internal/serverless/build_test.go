@@ -0,0 +1,108 @@
+package serverless
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/resources/types"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerfile(t *testing.T) {
+	t.Parallel()
+	dockerfile, err := Dockerfile(types.Nodejs14)
+	require.NoError(t, err)
+	require.Contains(t, dockerfile, "FROM eu.gcr.io/kyma-project/function-runtime-nodejs14:")
+	require.Contains(t, dockerfile, "ENV NODE_PATH=${KUBELESS_INSTALL_VOLUME}/node_modules\n")
+	require.Contains(t, dockerfile, "COPY src/package.json /kubeless/package.json\nRUN /kubeless-npm-install.sh\nCOPY src /kubeless\n")
+	require.Contains(t, dockerfile, `CMD ["/bin/sh", "-c", "node kubeless.js"]`)
+
+	dockerfile, err = Dockerfile(types.Python39)
+	require.NoError(t, err)
+	require.Contains(t, dockerfile, "COPY src/requirements.txt /kubeless/requirements.txt\nRUN pip install -r $KUBELESS_INSTALL_VOLUME/requirements.txt\n")
+	require.Contains(t, dockerfile, `CMD ["/bin/sh", "-c", "python kubeless.py"]`)
+
+	_, err = Dockerfile("java11")
+	require.Error(t, err)
+}
+
+func TestBuildContext(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "function")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"index.js":                  "module.exports = {}",
+		"deps.json":                 "{}",
+		"handler.test.js":           "test",
+		"lib/util.js":               "util",
+		"node_modules/jest/jest.js": "jest",
+		".git/HEAD":                 "ref",
+	}
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0700))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, path), []byte(content), 0600))
+	}
+
+	t.Run("inline Function", func(t *testing.T) {
+		cfg := workspace.Cfg{
+			Runtime: types.Nodejs14,
+			Source: workspace.Source{
+				Type:         workspace.SourceTypeInline,
+				SourceInline: workspace.SourceInline{SourceHandlerName: "index.js", DepsHandlerName: "deps.json"},
+			},
+		}
+		context, err := BuildContext(cfg, dir)
+		require.NoError(t, err)
+		content := readTar(t, context)
+		require.Len(t, content, 3)
+		require.Contains(t, content, "Dockerfile")
+		require.Equal(t, "module.exports = {}", content["src/handler.js"])
+		require.Equal(t, "{}", content["src/package.json"])
+	})
+
+	t.Run("inline Function with missing sources", func(t *testing.T) {
+		cfg := workspace.Cfg{Runtime: types.Python39, Source: workspace.Source{Type: workspace.SourceTypeInline}}
+		_, err := BuildContext(cfg, dir)
+		require.Error(t, err)
+	})
+
+	t.Run("Git Function", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "package.json"), []byte("{}"), 0600))
+		defer os.Remove(filepath.Join(dir, "package.json"))
+		cfg := workspace.Cfg{Runtime: types.Nodejs12, Source: workspace.Source{Type: workspace.SourceTypeGit}}
+		context, err := BuildContext(cfg, dir)
+		require.NoError(t, err)
+		content := readTar(t, context)
+		for _, path := range []string{"Dockerfile", "src/index.js", "src/deps.json", "src/handler.test.js", "src/lib/util.js", "src/package.json"} {
+			require.Contains(t, content, path)
+		}
+		require.Len(t, content, 6)
+	})
+
+	t.Run("Git Function without dependencies", func(t *testing.T) {
+		cfg := workspace.Cfg{Runtime: types.Python38, Source: workspace.Source{Type: workspace.SourceTypeGit}}
+		_, err := BuildContext(cfg, dir)
+		require.Error(t, err)
+	})
+}
+
+func readTar(t *testing.T, archive io.Reader) map[string]string {
+	content := map[string]string{}
+	reader := tar.NewReader(archive)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return content
+		}
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		content[header.Name] = string(data)
+	}
+}
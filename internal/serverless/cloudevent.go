@@ -0,0 +1,70 @@
+package serverless
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	cloudEventSpecVersion = "1.0"
+	defaultEventSource    = "kyma-cli"
+	// DefaultEventContentType is the content type of CloudEvents whose data has no explicit content type
+	DefaultEventContentType = "application/json"
+)
+
+// attributeName is the format of CloudEvent attribute names defined by the CloudEvents specification
+var attributeName = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// CloudEventHeaders returns the headers of a CloudEvent in binary content mode built from the given attributes.
+// The event type must be declared in the subscriptions of the Function. The event source defaults to the source of the matching subscription filter.
+func CloudEventHeaders(attributes map[string]string, cfg workspace.Cfg) (http.Header, error) {
+	eventType := attributes["type"]
+	if eventType == "" {
+		return nil, errors.New("The type of the CloudEvent is missing")
+	}
+
+	filter, err := subscriptionFilter(eventType, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := http.Header{}
+	headers.Set("ce-specversion", cloudEventSpecVersion)
+	headers.Set("ce-type", eventType)
+	headers.Set("ce-source", valueOrDefault(filter.EventSource.Value, defaultEventSource))
+	headers.Set("ce-id", string(uuid.NewUUID()))
+	for name, value := range attributes {
+		if !attributeName.MatchString(name) {
+			return nil, errors.Errorf("Invalid CloudEvent attribute '%s'. Attribute names can only contain lowercase letters and digits", name)
+		}
+		headers.Set("ce-"+name, value)
+	}
+	return headers, nil
+}
+
+// subscriptionFilter returns the filter of the subscriptions of the Function which matches the event type
+func subscriptionFilter(eventType string, cfg workspace.Cfg) (workspace.EventFilter, error) {
+	var declared []string
+	for _, s := range cfg.Subscriptions {
+		for _, f := range s.Filter.Filters {
+			if f.EventType.Value == eventType {
+				return f, nil
+			}
+			declared = append(declared, f.EventType.Value)
+		}
+	}
+
+	if len(declared) == 0 {
+		return workspace.EventFilter{}, fmt.Errorf("Function '%s' has no subscriptions. Declare the event types of the Function in the subscriptions of its configuration file", cfg.Name)
+	}
+	sort.Strings(declared)
+	return workspace.EventFilter{}, fmt.Errorf("Event type '%s' is not declared in the subscriptions of Function '%s'. Declared event types: %s",
+		eventType, cfg.Name, strings.Join(declared, ", "))
+}
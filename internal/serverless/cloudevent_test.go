@@ -0,0 +1,50 @@
+package serverless
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudEventHeaders(t *testing.T) {
+	t.Parallel()
+	cfg := workspace.Cfg{
+		Name: "my-function",
+		Subscriptions: []workspace.Subscription{
+			{
+				Name: "orders",
+				Filter: workspace.Filter{Filters: []workspace.EventFilter{
+					{
+						EventSource: workspace.EventFilterProperty{Property: "source", Value: "my-app"},
+						EventType:   workspace.EventFilterProperty{Property: "type", Value: "order.created.v1"},
+					},
+				}},
+			},
+		},
+	}
+
+	headers, err := CloudEventHeaders(map[string]string{"type": "order.created.v1"}, cfg)
+	require.NoError(t, err)
+	require.Equal(t, "1.0", headers.Get("ce-specversion"))
+	require.Equal(t, "order.created.v1", headers.Get("ce-type"))
+	require.Equal(t, "my-app", headers.Get("ce-source"), "The source of the subscription must be used by default")
+	require.NotEmpty(t, headers.Get("ce-id"))
+
+	headers, err = CloudEventHeaders(map[string]string{"type": "order.created.v1", "source": "other", "id": "1"}, cfg)
+	require.NoError(t, err)
+	require.Equal(t, "other", headers.Get("ce-source"))
+	require.Equal(t, "1", headers.Get("ce-id"))
+
+	_, err = CloudEventHeaders(map[string]string{"type": "order.deleted.v1"}, cfg)
+	require.EqualError(t, err, "Event type 'order.deleted.v1' is not declared in the subscriptions of Function 'my-function'. Declared event types: order.created.v1")
+
+	_, err = CloudEventHeaders(map[string]string{"source": "my-app"}, cfg)
+	require.Error(t, err, "The event type is required")
+
+	_, err = CloudEventHeaders(map[string]string{"type": "order.created.v1", "Invalid-Name": "x"}, cfg)
+	require.Error(t, err)
+
+	_, err = CloudEventHeaders(map[string]string{"type": "order.created.v1"}, workspace.Cfg{Name: "my-function"})
+	require.Error(t, err, "Functions without subscriptions cannot receive CloudEvents")
+}
@@ -0,0 +1,161 @@
+package serverless
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultEnvFile is the env file in the folder of the config file which is used if the cluster is not available
+const DefaultEnvFile = ".env"
+
+// EnvResolver resolves the values of the environment variables of a Function
+type EnvResolver struct {
+	// Overrides are the values from the env file, which take precedence over the values of the Function's configuration
+	Overrides map[string]string
+	// Client is used to read referenced ConfigMaps and Secrets. If it is nil, the references which are not overridden stay unresolved.
+	Client    kubernetes.Interface
+	Namespace string
+
+	configMaps map[string]map[string]string
+	secrets    map[string]map[string][]byte
+}
+
+// Resolve returns the environment variables in the format 'NAME=VALUE', and the names of the environment variables which reference ConfigMaps or Secrets and could not be resolved without a client
+func (r *EnvResolver) Resolve(ctx context.Context, envVars []workspace.EnvVar) ([]string, []string, error) {
+	var envs []string
+	var unresolved []string
+	for _, env := range envVars {
+		if value, ok := r.Overrides[env.Name]; ok {
+			envs = append(envs, fmt.Sprintf("%s=%s", env.Name, value))
+			continue
+		}
+		if env.ValueFrom == nil {
+			envs = append(envs, fmt.Sprintf("%s=%s", env.Name, env.Value))
+			continue
+		}
+		if r.Client == nil {
+			unresolved = append(unresolved, env.Name)
+			continue
+		}
+
+		value, err := r.valueFrom(ctx, *env.ValueFrom)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Could not resolve the value of the environment variable '%s'", env.Name)
+		}
+		envs = append(envs, fmt.Sprintf("%s=%s", env.Name, value))
+	}
+	return envs, unresolved, nil
+}
+
+func (r *EnvResolver) valueFrom(ctx context.Context, source workspace.EnvVarSource) (string, error) {
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		ref := source.ConfigMapKeyRef
+		data, err := r.configMap(ctx, ref.Name)
+		if err != nil {
+			return "", err
+		}
+		value, ok := data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("ConfigMap '%s' has no key '%s'", ref.Name, ref.Key)
+		}
+		return value, nil
+	case source.SecretKeyRef != nil:
+		ref := source.SecretKeyRef
+		data, err := r.secret(ctx, ref.Name)
+		if err != nil {
+			return "", err
+		}
+		value, ok := data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("Secret '%s' has no key '%s'", ref.Name, ref.Key)
+		}
+		return string(value), nil
+	default:
+		return "", errors.New("Only references to ConfigMaps and Secrets are supported")
+	}
+}
+
+func (r *EnvResolver) configMap(ctx context.Context, name string) (map[string]string, error) {
+	if data, ok := r.configMaps[name]; ok {
+		return data, nil
+	}
+	cm, err := r.Client.CoreV1().ConfigMaps(r.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if r.configMaps == nil {
+		r.configMaps = map[string]map[string]string{}
+	}
+	r.configMaps[name] = cm.Data
+	return cm.Data, nil
+}
+
+func (r *EnvResolver) secret(ctx context.Context, name string) (map[string][]byte, error) {
+	if data, ok := r.secrets[name]; ok {
+		return data, nil
+	}
+	secret, err := r.Client.CoreV1().Secrets(r.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if r.secrets == nil {
+		r.secrets = map[string]map[string][]byte{}
+	}
+	r.secrets[name] = secret.Data
+	return secret.Data, nil
+}
+
+// HasReferences returns true if one of the environment variables references a ConfigMap or Secret and is not overridden
+func HasReferences(envVars []workspace.EnvVar, overrides map[string]string) bool {
+	for _, env := range envVars {
+		if _, ok := overrides[env.Name]; !ok && env.ValueFrom != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadEnvFile reads a file with lines in the format 'NAME=VALUE'. Empty lines and lines starting with '#' are ignored.
+func ReadEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	envs := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		parts := strings.SplitN(line, "=", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" {
+			return nil, fmt.Errorf("Invalid line %d in env file '%s'. Lines must be in the format 'NAME=VALUE'", lineNumber, path)
+		}
+		envs[name] = unquote(strings.TrimSpace(parts[1]))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return envs, nil
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
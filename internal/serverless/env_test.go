@@ -0,0 +1,93 @@
+package serverless
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testEnvVars() []workspace.EnvVar {
+	return []workspace.EnvVar{
+		{Name: "PLAIN", Value: "plain-value"},
+		{Name: "FROM_CM", ValueFrom: &workspace.EnvVarSource{ConfigMapKeyRef: &workspace.ConfigMapKeySelector{Name: "my-config", Key: "url"}}},
+		{Name: "FROM_SECRET", ValueFrom: &workspace.EnvVarSource{SecretKeyRef: &workspace.SecretKeySelector{Name: "my-secret", Key: "password"}}},
+	}
+}
+
+func TestResolveEnvs(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-config", Namespace: "test"},
+			Data:       map[string]string{"url": "http://example.com"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "test"},
+			Data:       map[string][]byte{"password": []byte("s3cr3t")},
+		},
+	)
+
+	t.Run("resolve from cluster", func(t *testing.T) {
+		r := &EnvResolver{Client: client, Namespace: "test"}
+		envs, unresolved, err := r.Resolve(context.Background(), testEnvVars())
+		require.NoError(t, err)
+		require.Equal(t, []string{"PLAIN=plain-value", "FROM_CM=http://example.com", "FROM_SECRET=s3cr3t"}, envs)
+		require.Empty(t, unresolved)
+	})
+
+	t.Run("overrides take precedence", func(t *testing.T) {
+		r := &EnvResolver{Client: client, Namespace: "test", Overrides: map[string]string{"PLAIN": "local", "FROM_SECRET": "local-secret"}}
+		envs, _, err := r.Resolve(context.Background(), testEnvVars())
+		require.NoError(t, err)
+		require.Equal(t, []string{"PLAIN=local", "FROM_CM=http://example.com", "FROM_SECRET=local-secret"}, envs)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		r := &EnvResolver{Client: client, Namespace: "test"}
+		_, _, err := r.Resolve(context.Background(), []workspace.EnvVar{
+			{Name: "MISSING", ValueFrom: &workspace.EnvVarSource{ConfigMapKeyRef: &workspace.ConfigMapKeySelector{Name: "my-config", Key: "missing"}}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		r := &EnvResolver{Overrides: map[string]string{"FROM_CM": "http://localhost"}}
+		envs, unresolved, err := r.Resolve(context.Background(), testEnvVars())
+		require.NoError(t, err)
+		require.Equal(t, []string{"PLAIN=plain-value", "FROM_CM=http://localhost"}, envs)
+		require.Equal(t, []string{"FROM_SECRET"}, unresolved)
+
+		require.True(t, HasReferences(testEnvVars(), r.Overrides))
+		r.Overrides["FROM_SECRET"] = "local-secret"
+		require.False(t, HasReferences(testEnvVars(), r.Overrides))
+		envs, unresolved, err = r.Resolve(context.Background(), testEnvVars())
+		require.NoError(t, err)
+		require.Equal(t, []string{"PLAIN=plain-value", "FROM_CM=http://localhost", "FROM_SECRET=local-secret"}, envs)
+		require.Empty(t, unresolved)
+	})
+}
+
+func TestReadEnvFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "env-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, ".env")
+	content := "# comment\n\nexport FIRST=1\nSECOND = \"two words\"\nTHIRD='a=b'\nEMPTY=\n"
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+	envs, err := ReadEnvFile(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"FIRST": "1", "SECOND": "two words", "THIRD": "a=b", "EMPTY": ""}, envs)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("INVALID\n"), 0600))
+	_, err = ReadEnvFile(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,296 @@
+// Package serverless provides helpers to inspect the state of Functions and their Pods on a Kyma cluster.
+package serverless
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/operator"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// FunctionNameLabel is the label which the Function controller sets on all resources of a Function
+	FunctionNameLabel = "serverless.kyma-project.io/function-name"
+
+	// ConditionConfigurationReady indicates whether the ConfigMaps of the Function are created
+	ConditionConfigurationReady = "ConfigurationReady"
+	// ConditionBuildReady indicates whether the image of the Function is built
+	ConditionBuildReady = "BuildReady"
+	// ConditionRunning indicates whether the Function is deployed and ready to serve requests
+	ConditionRunning = "Running"
+
+	reasonJobFailed = "JobFailed"
+	istioSidecar    = "istio-proxy"
+	pollInterval    = 2 * time.Second
+)
+
+// waitingFailures are the reasons of waiting containers which don't resolve without a change of the Function
+var waitingFailures = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"CreateContainerConfigError": true,
+	"InvalidImageName":           true,
+}
+
+// Condition of a Function
+type Condition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// Status of a Function
+type Status struct {
+	Conditions []Condition
+	Generation int64
+	// Observed is true if the Function controller processed the latest generation of the Function
+	Observed bool
+}
+
+// Condition returns the condition of the given type
+func (s Status) Condition(conditionType string) (Condition, bool) {
+	for _, c := range s.Conditions {
+		if c.Type == conditionType {
+			return c, true
+		}
+	}
+	return Condition{}, false
+}
+
+// Running returns true if the latest generation of the Function is deployed and ready to serve requests
+func (s Status) Running() bool {
+	c, ok := s.Condition(ConditionRunning)
+	return s.Observed && ok && c.Status == string(corev1.ConditionTrue)
+}
+
+// UpdatedSince returns true if one of the conditions of the Function changed after the given time
+func (s Status) UpdatedSince(t time.Time) bool {
+	// condition timestamps have a precision of one second
+	t = t.Truncate(time.Second)
+	for _, c := range s.Conditions {
+		if !c.LastTransitionTime.Before(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildFailed returns the condition of the failed build of the latest generation of the Function
+func (s Status) BuildFailed() (Condition, bool) {
+	c, ok := s.Condition(ConditionBuildReady)
+	if !s.Observed || !ok || c.Status != string(corev1.ConditionFalse) || c.Reason != reasonJobFailed {
+		return Condition{}, false
+	}
+	return c, true
+}
+
+// String returns a short description of the conditions of the Function
+func (s Status) String() string {
+	if len(s.Conditions) == 0 {
+		return "no status reported yet"
+	}
+	var parts []string
+	for _, c := range s.Conditions {
+		part := fmt.Sprintf("%s=%s", c.Type, c.Status)
+		if c.Reason != "" {
+			part = fmt.Sprintf("%s (%s)", part, c.Reason)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// StatusFromUnstructured reads the status of a Function from its unstructured representation
+func StatusFromUnstructured(u *unstructured.Unstructured) Status {
+	s := Status{Generation: u.GetGeneration()}
+
+	observed, found, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration")
+	// older Function controllers don't report the observed generation
+	s.Observed = !found || observed >= s.Generation
+
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, item := range conditions {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		c := Condition{
+			Type:    fmt.Sprint(m["type"]),
+			Status:  fmt.Sprint(m["status"]),
+			Reason:  stringValue(m["reason"]),
+			Message: stringValue(m["message"]),
+		}
+		if t, ok := m["lastTransitionTime"].(string); ok {
+			c.LastTransitionTime, _ = time.Parse(time.RFC3339, t)
+		}
+		s.Conditions = append(s.Conditions, c)
+	}
+	return s
+}
+
+// GetStatus returns the current status of a Function
+func GetStatus(ctx context.Context, client dynamic.Interface, namespace, name string) (Status, error) {
+	u, err := client.Resource(operator.GVRFunction).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return Status{}, err
+	}
+	return StatusFromUnstructured(u), nil
+}
+
+// FailedError is returned if a Function cannot become running without a change of the Function
+type FailedError struct {
+	// Pod which failed, if any. Its logs usually contain the cause of the failure.
+	Pod     *corev1.Pod
+	message string
+}
+
+func (e *FailedError) Error() string {
+	return e.message
+}
+
+// WaitForRunning waits until the Function is running.
+// If the Function was changed, the time of the change must be provided, so the status of the previous version of the Function is not considered.
+// It returns a FailedError as soon as the build of the Function fails or one of its Pods created after the change cannot recover.
+func WaitForRunning(ctx context.Context, client dynamic.Interface, static kubernetes.Interface, namespace, name string, since time.Time) (Status, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := GetStatus(ctx, client, namespace, name)
+		if err != nil {
+			return status, err
+		}
+		if !since.IsZero() && !status.UpdatedSince(since) {
+			// the Function controller has not processed the change yet
+			if err := sleep(ctx, ticker, name, status); err != nil {
+				return status, err
+			}
+			continue
+		}
+		if status.Running() {
+			return status, nil
+		}
+
+		pod, reason, podFailed := failingPod(ctx, static, namespace, name, since)
+		if c, failed := status.BuildFailed(); failed {
+			e := &FailedError{message: fmt.Sprintf("Build of Function '%s' failed: %s", name, c.Message)}
+			if podFailed {
+				e.Pod = &pod
+			}
+			return status, e
+		}
+		if podFailed {
+			return status, &FailedError{
+				Pod:     &pod,
+				message: fmt.Sprintf("Pod '%s' of Function '%s' is failing: %s", pod.Name, name, reason),
+			}
+		}
+
+		if err := sleep(ctx, ticker, name, status); err != nil {
+			return status, err
+		}
+	}
+}
+
+func sleep(ctx context.Context, ticker *time.Ticker, name string, status Status) error {
+	select {
+	case <-ctx.Done():
+		return errors.Wrapf(ctx.Err(), "Function '%s' is not running (%s)", name, status)
+	case <-ticker.C:
+		return nil
+	}
+}
+
+// Pods returns the Pods of a Function sorted by their creation time with the newest Pod first
+func Pods(ctx context.Context, static kubernetes.Interface, namespace, name string) ([]corev1.Pod, error) {
+	pods, err := static.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", FunctionNameLabel, name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := pods.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[j].CreationTimestamp.Before(&items[i].CreationTimestamp)
+	})
+	return items, nil
+}
+
+// WriteLogs writes the logs of all containers of a Pod except the Istio sidecar to the writer
+func WriteLogs(ctx context.Context, static kubernetes.Interface, pod corev1.Pod, follow bool, w io.Writer) error {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == istioSidecar {
+			continue
+		}
+		stream, err := static.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container: c.Name,
+			Follow:    follow,
+		}).Stream(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "Could not get the logs of container '%s' in Pod '%s'", c.Name, pod.Name)
+		}
+		_, err = io.Copy(w, stream)
+		stream.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// failingPod returns the newest Pod of the Function created after the given time which has failed or cannot recover on its own
+func failingPod(ctx context.Context, static kubernetes.Interface, namespace, name string, since time.Time) (corev1.Pod, string, bool) {
+	pods, err := Pods(ctx, static, namespace, name)
+	if err != nil {
+		return corev1.Pod{}, "", false
+	}
+	for _, pod := range pods {
+		if pod.CreationTimestamp.Time.Before(since) {
+			continue
+		}
+		if reason, failed := PodFailure(pod); failed {
+			return pod, reason, true
+		}
+	}
+	return corev1.Pod{}, "", false
+}
+
+// PodFailure returns the reason why a Pod has failed or cannot recover on its own
+func PodFailure(pod corev1.Pod) (string, bool) {
+	if pod.Status.Phase == corev1.PodFailed {
+		return valueOrDefault(pod.Status.Reason, string(corev1.PodFailed)), true
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && waitingFailures[cs.State.Waiting.Reason] {
+			return cs.State.Waiting.Reason, true
+		}
+	}
+	return "", false
+}
+
+func stringValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
+
+func valueOrDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
@@ -0,0 +1,135 @@
+package serverless
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newFunction(generation, observed int64, conditions ...map[string]interface{}) *unstructured.Unstructured {
+	items := make([]interface{}, 0, len(conditions))
+	for _, c := range conditions {
+		items = append(items, c)
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serverless.kyma-project.io/v1alpha1",
+		"kind":       "Function",
+		"metadata": map[string]interface{}{
+			"name":       "my-function",
+			"namespace":  "default",
+			"generation": generation,
+		},
+		"status": map[string]interface{}{
+			"observedGeneration": observed,
+			"conditions":         items,
+		},
+	}}
+	return u
+}
+
+func condition(conditionType, status, reason string, t time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"type":               conditionType,
+		"status":             status,
+		"reason":             reason,
+		"message":            "some message",
+		"lastTransitionTime": t.UTC().Format(time.RFC3339),
+	}
+}
+
+func TestStatusFromUnstructured(t *testing.T) {
+	now := time.Now()
+
+	t.Run("running", func(t *testing.T) {
+		s := StatusFromUnstructured(newFunction(2, 2,
+			condition(ConditionBuildReady, "True", "JobFinished", now),
+			condition(ConditionRunning, "True", "DeploymentReady", now),
+		))
+		require.True(t, s.Running())
+		_, failed := s.BuildFailed()
+		require.False(t, failed)
+		require.Equal(t, "BuildReady=True (JobFinished), Running=True (DeploymentReady)", s.String())
+	})
+
+	t.Run("build failed", func(t *testing.T) {
+		s := StatusFromUnstructured(newFunction(1, 1, condition(ConditionBuildReady, "False", "JobFailed", now)))
+		require.False(t, s.Running())
+		c, failed := s.BuildFailed()
+		require.True(t, failed)
+		require.Equal(t, "some message", c.Message)
+	})
+
+	t.Run("not observed yet", func(t *testing.T) {
+		s := StatusFromUnstructured(newFunction(3, 2, condition(ConditionRunning, "True", "DeploymentReady", now)))
+		require.False(t, s.Running())
+	})
+
+	t.Run("updated since", func(t *testing.T) {
+		s := StatusFromUnstructured(newFunction(1, 1, condition(ConditionRunning, "True", "", now.Add(-time.Hour))))
+		require.False(t, s.UpdatedSince(now))
+		require.True(t, s.UpdatedSince(now.Add(-2*time.Hour)))
+	})
+}
+
+func TestWaitForRunning(t *testing.T) {
+	now := time.Now()
+
+	t.Run("running", func(t *testing.T) {
+		dyn := dynFake.NewSimpleDynamicClient(runtime.NewScheme(), newFunction(1, 1, condition(ConditionRunning, "True", "", now)))
+		s, err := WaitForRunning(context.Background(), dyn, fake.NewSimpleClientset(), "default", "my-function", now)
+		require.NoError(t, err)
+		require.True(t, s.Running())
+	})
+
+	t.Run("build failed", func(t *testing.T) {
+		dyn := dynFake.NewSimpleDynamicClient(runtime.NewScheme(), newFunction(1, 1, condition(ConditionBuildReady, "False", "JobFailed", now)))
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "my-function-build-abcde",
+				Namespace:         "default",
+				Labels:            map[string]string{FunctionNameLabel: "my-function"},
+				CreationTimestamp: metav1.NewTime(now.Add(time.Second)),
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodFailed},
+		}
+		_, err := WaitForRunning(context.Background(), dyn, fake.NewSimpleClientset(pod), "default", "my-function", now)
+		require.Error(t, err)
+		failed, ok := err.(*FailedError)
+		require.True(t, ok)
+		require.Equal(t, "my-function-build-abcde", failed.Pod.Name)
+	})
+
+	t.Run("crashing pod", func(t *testing.T) {
+		dyn := dynFake.NewSimpleDynamicClient(runtime.NewScheme(), newFunction(1, 1, condition(ConditionRunning, "False", "MinimumReplicasUnavailable", now)))
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "my-function-abcde",
+				Namespace:         "default",
+				Labels:            map[string]string{FunctionNameLabel: "my-function"},
+				CreationTimestamp: metav1.NewTime(now.Add(time.Second)),
+			},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			}},
+		}
+		_, err := WaitForRunning(context.Background(), dyn, fake.NewSimpleClientset(pod), "default", "my-function", now)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "CrashLoopBackOff")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		dyn := dynFake.NewSimpleDynamicClient(runtime.NewScheme(), newFunction(1, 1, condition(ConditionRunning, "True", "", now.Add(-time.Hour))))
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := WaitForRunning(ctx, dyn, fake.NewSimpleClientset(), "default", "my-function", now)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,174 @@
+package serverless
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// OverlayFilename returns the path of the overlay of the config file for the given environment.
+// For example, the overlay of 'config.yaml' for the environment 'stage' is 'config.stage.yaml'.
+func OverlayFilename(filename, env string) string {
+	ext := filepath.Ext(filename)
+	return fmt.Sprintf("%s.%s%s", strings.TrimSuffix(filename, ext), env, ext)
+}
+
+// LoadOverlay reads the overlay file and merges it on top of the configuration of the Function.
+// The merged configuration is validated like a config file, it returns its warnings, or a ValidationError for the overlay file if the merged configuration contains errors.
+func LoadOverlay(cfg workspace.Cfg, filename string) (workspace.Cfg, []Issue, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return cfg, nil, err
+	}
+	var overlay workspace.Cfg
+	if err := yaml.UnmarshalStrict(content, &overlay); err != nil {
+		return cfg, nil, errors.Wrapf(err, "Could not decode the overlay file '%s'", filename)
+	}
+	merged := MergeOverlay(cfg, overlay)
+
+	// the encoding keeps an empty source type, which stands for inline sources in a config file
+	encoded := merged
+	if encoded.Source.Type == "" {
+		encoded.Source.Type = workspace.SourceTypeInline
+	}
+	mergedContent, err := yaml.Marshal(encoded)
+	if err != nil {
+		return cfg, nil, errors.Wrap(err, "Could not encode the merged configuration")
+	}
+	warnings, err := ValidateConfigFile(filename, mergedContent)
+	// the lines point to the encoded configuration and not to any of the files
+	clearLines(warnings)
+	if validationErr, ok := err.(*ValidationError); ok {
+		clearLines(validationErr.Issues)
+	}
+	if err != nil {
+		return cfg, nil, err
+	}
+	return merged, warnings, nil
+}
+
+func clearLines(issues []Issue) {
+	for i := range issues {
+		issues[i].Line = 0
+	}
+}
+
+// MergeOverlay merges the overlay on top of the base configuration:
+//  - fields with a value in the overlay replace the fields of the base
+//  - labels and resources are merged by key, with the values of the overlay taking precedence
+//  - env variables, APIRules and subscriptions are merged by name. An entry of the overlay replaces the entry of the base with the same name, other entries are appended.
+func MergeOverlay(base, overlay workspace.Cfg) workspace.Cfg {
+	merged := base
+	merged.Name = mergeString(base.Name, overlay.Name)
+	merged.Namespace = mergeString(base.Namespace, overlay.Namespace)
+	if overlay.Runtime != "" {
+		merged.Runtime = overlay.Runtime
+	}
+	merged.Labels = mergeMap(base.Labels, overlay.Labels)
+	merged.Source = mergeSource(base.Source, overlay.Source)
+	merged.Resources.Limits = mergeResources(base.Resources.Limits, overlay.Resources.Limits)
+	merged.Resources.Requests = mergeResources(base.Resources.Requests, overlay.Resources.Requests)
+
+	if len(overlay.Env) > 0 {
+		merged.Env = append([]workspace.EnvVar{}, base.Env...)
+	}
+	for _, env := range overlay.Env {
+		if i := indexOf(len(merged.Env), func(i int) bool { return merged.Env[i].Name == env.Name }); i >= 0 {
+			merged.Env[i] = env
+		} else {
+			merged.Env = append(merged.Env, env)
+		}
+	}
+
+	// APIRules without a name are named after the Function
+	apiRuleName := func(r workspace.APIRule) string {
+		return mergeString(merged.Name, r.Name)
+	}
+	if len(overlay.APIRules) > 0 {
+		merged.APIRules = append([]workspace.APIRule{}, base.APIRules...)
+	}
+	for _, rule := range overlay.APIRules {
+		if i := indexOf(len(merged.APIRules), func(i int) bool { return apiRuleName(merged.APIRules[i]) == apiRuleName(rule) }); i >= 0 {
+			merged.APIRules[i] = rule
+		} else {
+			merged.APIRules = append(merged.APIRules, rule)
+		}
+	}
+
+	if len(overlay.Subscriptions) > 0 {
+		merged.Subscriptions = append([]workspace.Subscription{}, base.Subscriptions...)
+	}
+	for _, subscription := range overlay.Subscriptions {
+		if i := indexOf(len(merged.Subscriptions), func(i int) bool { return merged.Subscriptions[i].Name == subscription.Name }); i >= 0 {
+			merged.Subscriptions[i] = subscription
+		} else {
+			merged.Subscriptions = append(merged.Subscriptions, subscription)
+		}
+	}
+	return merged
+}
+
+func mergeSource(base, overlay workspace.Source) workspace.Source {
+	if overlay.Type != "" {
+		base.Type = overlay.Type
+	}
+	base.SourcePath = mergeString(base.SourcePath, overlay.SourcePath)
+	base.SourceHandlerName = mergeString(base.SourceHandlerName, overlay.SourceHandlerName)
+	base.DepsHandlerName = mergeString(base.DepsHandlerName, overlay.DepsHandlerName)
+	base.URL = mergeString(base.URL, overlay.URL)
+	base.Repository = mergeString(base.Repository, overlay.Repository)
+	base.Reference = mergeString(base.Reference, overlay.Reference)
+	base.BaseDir = mergeString(base.BaseDir, overlay.BaseDir)
+	base.CredentialsSecretName = mergeString(base.CredentialsSecretName, overlay.CredentialsSecretName)
+	return base
+}
+
+func mergeString(base, overlay string) string {
+	if overlay != "" {
+		return overlay
+	}
+	return base
+}
+
+func mergeMap(base, overlay map[string]string) map[string]string {
+	if len(overlay) == 0 {
+		return base
+	}
+	merged := map[string]string{}
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		merged[k] = v
+	}
+	return merged
+}
+
+func mergeResources(base, overlay workspace.ResourceList) workspace.ResourceList {
+	if len(overlay) == 0 {
+		return base
+	}
+	merged := workspace.ResourceList{}
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		merged[k] = v
+	}
+	return merged
+}
+
+// indexOf returns the index of the first element matching the predicate, or -1
+func indexOf(length int, matches func(i int) bool) int {
+	for i := 0; i < length; i++ {
+		if matches(i) {
+			return i
+		}
+	}
+	return -1
+}
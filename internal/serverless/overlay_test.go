@@ -0,0 +1,117 @@
+package serverless
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlayFilename(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "config.stage.yaml", OverlayFilename("config.yaml", "stage"))
+	require.Equal(t, filepath.Join("project", "config.prod.yaml"), OverlayFilename(filepath.Join("project", "config.yaml"), "prod"))
+}
+
+func TestMergeOverlay(t *testing.T) {
+	t.Parallel()
+	base := workspace.Cfg{
+		Name:      "orders",
+		Namespace: "dev",
+		Runtime:   "nodejs12",
+		Labels:    map[string]string{"app": "orders", "stage": "dev"},
+		Source:    workspace.Source{Type: workspace.SourceTypeInline, SourceInline: workspace.SourceInline{SourcePath: "/project"}},
+		Resources: workspace.Resources{Limits: workspace.ResourceList{"cpu": "100m", "memory": "128Mi"}},
+		Env: []workspace.EnvVar{
+			{Name: "LOG_LEVEL", Value: "debug"},
+			{Name: "DB_URL", ValueFrom: &workspace.EnvVarSource{SecretKeyRef: &workspace.SecretKeySelector{Name: "db", Key: "url"}}},
+		},
+		APIRules: []workspace.APIRule{
+			{Service: workspace.Service{Host: "orders-dev"}},
+			{Name: "admin", Service: workspace.Service{Host: "orders-admin-dev"}},
+		},
+		Subscriptions: []workspace.Subscription{
+			{Name: "created", Protocol: "", Filter: workspace.Filter{Filters: []workspace.EventFilter{{EventType: workspace.EventFilterProperty{Value: "order.created.dev.v1"}}}}},
+		},
+	}
+	overlay := workspace.Cfg{
+		Namespace: "stage",
+		Labels:    map[string]string{"stage": "stage"},
+		Resources: workspace.Resources{Limits: workspace.ResourceList{"memory": "256Mi"}},
+		Env: []workspace.EnvVar{
+			{Name: "LOG_LEVEL", Value: "info"},
+			{Name: "REGION", Value: "eu"},
+		},
+		APIRules: []workspace.APIRule{
+			{Name: "orders", Service: workspace.Service{Host: "orders-stage"}},
+		},
+		Subscriptions: []workspace.Subscription{
+			{Name: "created", Filter: workspace.Filter{Filters: []workspace.EventFilter{{EventType: workspace.EventFilterProperty{Value: "order.created.stage.v1"}}}}},
+			{Name: "cancelled", Filter: workspace.Filter{Filters: []workspace.EventFilter{{EventType: workspace.EventFilterProperty{Value: "order.cancelled.v1"}}}}},
+		},
+	}
+
+	merged := MergeOverlay(base, overlay)
+	require.Equal(t, "orders", merged.Name)
+	require.Equal(t, "stage", merged.Namespace)
+	require.Equal(t, base.Runtime, merged.Runtime)
+	require.Equal(t, "/project", merged.Source.SourcePath)
+	require.Equal(t, map[string]string{"app": "orders", "stage": "stage"}, merged.Labels)
+	require.Equal(t, workspace.ResourceList{"cpu": "100m", "memory": "256Mi"}, merged.Resources.Limits)
+	require.Equal(t, []workspace.EnvVar{
+		{Name: "LOG_LEVEL", Value: "info"},
+		base.Env[1],
+		{Name: "REGION", Value: "eu"},
+	}, merged.Env)
+	require.Equal(t, []workspace.APIRule{overlay.APIRules[0], base.APIRules[1]}, merged.APIRules)
+	require.Equal(t, overlay.Subscriptions, merged.Subscriptions)
+
+	// the base is not modified
+	require.Equal(t, "debug", base.Env[0].Value)
+	require.Equal(t, "dev", base.Labels["stage"])
+
+	// an empty overlay keeps the base
+	require.Equal(t, base, MergeOverlay(base, workspace.Cfg{}))
+}
+
+func TestLoadOverlay(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "overlay")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	base := workspace.Cfg{Name: "orders", Namespace: "dev", Runtime: "nodejs14", Source: workspace.Source{Type: workspace.SourceTypeInline}}
+	filename := filepath.Join(dir, "config.stage.yaml")
+	require.NoError(t, ioutil.WriteFile(filename, []byte("namespace: stage\nenv:\n- name: LOG_LEVEL\n  value: info\n"), 0600))
+	merged, warnings, err := LoadOverlay(base, filename)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	require.Equal(t, "stage", merged.Namespace)
+	require.Equal(t, []workspace.EnvVar{{Name: "LOG_LEVEL", Value: "info"}}, merged.Env)
+
+	// the merged configuration is validated
+	require.NoError(t, ioutil.WriteFile(filename, []byte("resource:\n  limits:\n    memory: 128Mi\n  requests:\n    memory: 1Gi\n"), 0600))
+	_, warnings, err = LoadOverlay(base, filename)
+	require.NoError(t, err)
+	require.Equal(t, []Issue{{Field: "resource.requests.memory", Message: "The request 1Gi exceeds the limit 128Mi", Warning: true}}, warnings)
+
+	require.NoError(t, ioutil.WriteFile(filename, []byte("runtime: go\n"), 0600))
+	_, _, err = LoadOverlay(base, filename)
+	require.Error(t, err)
+	validationErr, ok := err.(*ValidationError)
+	require.True(t, ok)
+	require.Equal(t, filename, validationErr.Filename)
+	require.Equal(t, "runtime", validationErr.Issues[0].Field)
+	require.Zero(t, validationErr.Issues[0].Line)
+
+	// unknown fields are rejected to detect typos in the overlay
+	require.NoError(t, ioutil.WriteFile(filename, []byte("namspace: stage\n"), 0600))
+	_, _, err = LoadOverlay(base, filename)
+	require.Error(t, err)
+
+	_, _, err = LoadOverlay(base, filepath.Join(dir, "config.prod.yaml"))
+	require.Error(t, err)
+}
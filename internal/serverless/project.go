@@ -0,0 +1,124 @@
+package serverless
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ProjectFilename is the name of the manifest which lists the Functions of a project
+const ProjectFilename = "functions.yaml"
+
+// ignoredDirs are not searched for config files of Functions
+var ignoredDirs = map[string]bool{
+	"node_modules": true,
+	"__pycache__":  true,
+}
+
+// Project groups several Functions which are managed together
+type Project struct {
+	// Namespace of all Functions of the project. If it is empty, the Namespaces of the config files are used.
+	Namespace string `yaml:"namespace,omitempty"`
+	// Labels added to all Functions of the project. Labels of the config files take precedence.
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// Functions are the paths of the config files, or of the folders containing them, relative to the manifest
+	Functions []string `yaml:"functions"`
+}
+
+// LoadProject returns the project in the folder and the paths of the config files of its Functions.
+// If the folder contains no project manifest, the config files are discovered recursively.
+func LoadProject(dir string) (Project, []string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, ProjectFilename))
+	if os.IsNotExist(err) {
+		files, err := DiscoverConfigs(dir)
+		return Project{}, files, err
+	}
+	if err != nil {
+		return Project{}, nil, err
+	}
+
+	var project Project
+	if err := yaml.Unmarshal(content, &project); err != nil {
+		return Project{}, nil, errors.Wrapf(err, "Could not decode the project manifest '%s'", ProjectFilename)
+	}
+
+	var files []string
+	for _, path := range project.Functions {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return Project{}, nil, errors.Wrapf(err, "Invalid Function in the project manifest '%s'", ProjectFilename)
+		}
+		if info.IsDir() {
+			path = filepath.Join(path, workspace.CfgFilename)
+		}
+		files = append(files, path)
+	}
+	return project, files, nil
+}
+
+// DiscoverConfigs returns the paths of all config files of Functions in the folder and its subfolders.
+// Hidden folders and folders with dependencies are skipped.
+func DiscoverConfigs(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && (strings.HasPrefix(info.Name(), ".") || ignoredDirs[info.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Name() == workspace.CfgFilename {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Apply sets the Namespace and the labels of the project on the configuration of a Function
+func (p Project) Apply(cfg *workspace.Cfg) {
+	if p.Namespace != "" {
+		cfg.Namespace = p.Namespace
+	}
+	if len(p.Labels) == 0 {
+		return
+	}
+	labels := map[string]string{}
+	for k, v := range p.Labels {
+		labels[k] = v
+	}
+	for k, v := range cfg.Labels {
+		labels[k] = v
+	}
+	cfg.Labels = labels
+}
+
+// ValidateNames verifies that no two Functions of a project have the same name in the same Namespace
+func ValidateNames(cfgs []workspace.Cfg, files []string) error {
+	seen := map[string]string{}
+	for i, cfg := range cfgs {
+		key := fmt.Sprintf("%s/%s", cfg.Namespace, cfg.Name)
+		if other, ok := seen[key]; ok {
+			return fmt.Errorf("Function '%s' in Namespace '%s' is defined in '%s' and '%s'", cfg.Name, cfg.Namespace, other, files[i])
+		}
+		seen[key] = files[i]
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package serverless
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProject(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "project")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, path := range []string{
+		"b/config.yaml",
+		"a/config.yaml",
+		"a/nested/config.yaml",
+		".git/config.yaml",
+		"a/node_modules/pkg/config.yaml",
+		"a/handler.js",
+	} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, ioutil.WriteFile(path, []byte{}, 0600))
+	}
+
+	// without a manifest the config files are discovered recursively
+	project, files, err := LoadProject(dir)
+	require.NoError(t, err)
+	require.Equal(t, Project{}, project)
+	require.Equal(t, []string{
+		filepath.Join(dir, "a", workspace.CfgFilename),
+		filepath.Join(dir, "a", "nested", workspace.CfgFilename),
+		filepath.Join(dir, "b", workspace.CfgFilename),
+	}, files)
+
+	// the manifest lists the Functions in its own order
+	manifest := "namespace: dev\nlabels:\n  app: shop\nfunctions:\n- b\n- a/nested/config.yaml\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ProjectFilename), []byte(manifest), 0600))
+	project, files, err = LoadProject(dir)
+	require.NoError(t, err)
+	require.Equal(t, "dev", project.Namespace)
+	require.Equal(t, map[string]string{"app": "shop"}, project.Labels)
+	require.Equal(t, []string{
+		filepath.Join(dir, "b", workspace.CfgFilename),
+		filepath.Join(dir, "a", "nested", workspace.CfgFilename),
+	}, files)
+
+	// Functions missing in the workspace are reported
+	manifest = "functions:\n- missing\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ProjectFilename), []byte(manifest), 0600))
+	_, _, err = LoadProject(dir)
+	require.Error(t, err)
+}
+
+func TestProjectApply(t *testing.T) {
+	t.Parallel()
+	project := Project{Namespace: "prod", Labels: map[string]string{"app": "shop", "team": "checkout"}}
+
+	cfg := workspace.Cfg{Namespace: "dev", Labels: map[string]string{"app": "orders"}}
+	project.Apply(&cfg)
+	require.Equal(t, "prod", cfg.Namespace)
+	require.Equal(t, map[string]string{"app": "orders", "team": "checkout"}, cfg.Labels)
+
+	cfg = workspace.Cfg{Namespace: "dev"}
+	Project{}.Apply(&cfg)
+	require.Equal(t, "dev", cfg.Namespace)
+	require.Nil(t, cfg.Labels)
+}
+
+func TestValidateNames(t *testing.T) {
+	t.Parallel()
+	files := []string{"a/config.yaml", "b/config.yaml"}
+
+	require.NoError(t, ValidateNames([]workspace.Cfg{{Name: "orders", Namespace: "dev"}, {Name: "orders", Namespace: "prod"}}, files))
+	require.Error(t, ValidateNames([]workspace.Cfg{{Name: "orders", Namespace: "dev"}, {Name: "orders", Namespace: "dev"}}, files))
+}
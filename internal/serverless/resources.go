@@ -0,0 +1,200 @@
+package serverless
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/operator"
+	"github.com/kyma-incubator/hydroform/function/pkg/resources/types"
+	"github.com/pkg/errors"
+	istio "istio.io/client-go/pkg/clientset/versioned"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// FunctionResourceLabel is the label which the Function controller sets to distinguish the build resources from the runtime resources of a Function
+	FunctionResourceLabel = "serverless.kyma-project.io/resource"
+	// ResourceBuild is the value of the FunctionResourceLabel of the build Jobs and their Pods
+	ResourceBuild = "build"
+	// ResourceDeployment is the value of the FunctionResourceLabel of the runtime Deployment and its Pods
+	ResourceDeployment = "deployment"
+
+	// CreatedByLabel marks the resources which Kyma CLI created for a Function and may delete together with it
+	CreatedByLabel = "serverless.kyma-project.io/created-by"
+	// CreatedByCLI is the value of the CreatedByLabel of the resources created by Kyma CLI
+	CreatedByCLI = "kyma-cli"
+
+	// JobRunning indicates that the build Job has neither succeeded nor failed yet
+	JobRunning = "Running"
+	// JobSucceeded indicates that the build Job finished successfully
+	JobSucceeded = "Succeeded"
+	// JobFailed indicates that the build Job failed
+	JobFailed = "Failed"
+
+	apiRuleStatusOK      = "OK"
+	kymaGateway          = "kyma-gateway"
+	kymaGatewayNamespace = "kyma-system"
+)
+
+// ResourceStatus is the readiness of a resource exposing or triggering a Function
+type ResourceStatus struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+	// Details contains additional information about the resource, such as the host of an APIRule
+	Details string `json:"details,omitempty"`
+}
+
+// BuildJob returns the newest build Job of the Function or nil if the Function has no build Job
+func BuildJob(ctx context.Context, static kubernetes.Interface, namespace, name string) (*batchv1.Job, error) {
+	jobs, err := static.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: resourceSelector(name, ResourceBuild),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs.Items) == 0 {
+		return nil, nil
+	}
+	items := jobs.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[j].CreationTimestamp.Before(&items[i].CreationTimestamp)
+	})
+	return &items[0], nil
+}
+
+// JobState returns whether the Job is running, succeeded or failed
+func JobState(job batchv1.Job) string {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return JobSucceeded
+		case batchv1.JobFailed:
+			return JobFailed
+		}
+	}
+	return JobRunning
+}
+
+// Deployment returns the runtime Deployment of the Function or nil if the Function is not deployed
+func Deployment(ctx context.Context, static kubernetes.Interface, namespace, name string) (*appsv1.Deployment, error) {
+	deployments, err := static.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: resourceSelector(name, ResourceDeployment),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(deployments.Items) == 0 {
+		return nil, nil
+	}
+	return &deployments.Items[0], nil
+}
+
+// ResourcePods returns the build or runtime Pods of a Function sorted by their creation time with the newest Pod first
+func ResourcePods(ctx context.Context, static kubernetes.Interface, namespace, name, resource string) ([]corev1.Pod, error) {
+	pods, err := static.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: resourceSelector(name, resource),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := pods.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[j].CreationTimestamp.Before(&items[i].CreationTimestamp)
+	})
+	return items, nil
+}
+
+// APIRules returns the status of the APIRules exposing the Function
+func APIRules(ctx context.Context, client dynamic.Interface, namespace, name string) ([]ResourceStatus, error) {
+	list, err := client.Resource(operator.GVRApiRule).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ResourceStatus
+	for _, item := range list.Items {
+		var apiRule types.APIRule
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &apiRule); err != nil {
+			return nil, err
+		}
+		if !apiRule.IsReference(name) {
+			continue
+		}
+		code, _, _ := unstructured.NestedString(item.Object, "status", "APIRuleStatus", "code")
+		result = append(result, ResourceStatus{
+			Name:    apiRule.Name,
+			Ready:   code == apiRuleStatusOK,
+			Details: apiRule.Spec.Service.Host,
+		})
+	}
+	return result, nil
+}
+
+// Subscriptions returns the status of the Subscriptions sending events to the Function
+func Subscriptions(ctx context.Context, client dynamic.Interface, namespace, name string) ([]ResourceStatus, error) {
+	list, err := client.Resource(operator.GVRSubscription).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ResourceStatus
+	for _, item := range list.Items {
+		var subscription types.Subscription
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &subscription); err != nil {
+			return nil, err
+		}
+		if !subscription.IsReference(name, namespace) {
+			continue
+		}
+		ready, _, _ := unstructured.NestedBool(item.Object, "status", "ready")
+		result = append(result, ResourceStatus{
+			Name:    subscription.Name,
+			Ready:   ready,
+			Details: eventTypes(subscription),
+		})
+	}
+	return result, nil
+}
+
+func eventTypes(subscription types.Subscription) string {
+	var result string
+	for i, f := range subscription.Spec.Filter.Filters {
+		if i > 0 {
+			result += ", "
+		}
+		result += f.EventType.Value
+	}
+	return result
+}
+
+func resourceSelector(name, resource string) string {
+	return fmt.Sprintf("%s=%s,%s=%s", FunctionNameLabel, name, FunctionResourceLabel, resource)
+}
+
+// KymaHostAddress returns the domain of the Kyma cluster which is used for the hosts of APIRules
+func KymaHostAddress(ctx context.Context, istioClient istio.Interface) (string, error) {
+	var url string
+	vs, err := istioClient.NetworkingV1alpha3().Gateways(kymaGatewayNamespace).Get(ctx, kymaGateway, metav1.GetOptions{})
+	switch {
+	case err != nil:
+		err = errors.Wrapf(err, "Unable to read the Kyma host URL due to error")
+	case vs != nil && len(vs.Spec.GetServers()) > 0 && len(vs.Spec.Servers[0].Hosts) > 0:
+		url = strings.TrimPrefix(vs.Spec.Servers[0].Hosts[0], "*.")
+	default:
+		err = errors.New("kyma host URL could not be obtained")
+	}
+
+	return url, err
+}
@@ -0,0 +1,101 @@
+package serverless
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newJob(name string, created time.Time, conditions ...batchv1.JobCondition) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{FunctionNameLabel: "my-function", FunctionResourceLabel: ResourceBuild},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Status: batchv1.JobStatus{Conditions: conditions},
+	}
+}
+
+func TestBuildJob(t *testing.T) {
+	now := time.Now()
+	static := fake.NewSimpleClientset(
+		newJob("old", now.Add(-time.Hour), batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}),
+		newJob("new", now, batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}),
+	)
+
+	job, err := BuildJob(context.Background(), static, "default", "my-function")
+	require.NoError(t, err)
+	require.Equal(t, "new", job.Name)
+	require.Equal(t, JobSucceeded, JobState(*job))
+
+	job, err = BuildJob(context.Background(), static, "default", "other-function")
+	require.NoError(t, err)
+	require.Nil(t, job)
+}
+
+func TestJobState(t *testing.T) {
+	require.Equal(t, JobRunning, JobState(*newJob("running", time.Now())))
+	require.Equal(t, JobFailed, JobState(*newJob("failed", time.Now(), batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue})))
+}
+
+func TestAPIRulesAndSubscriptions(t *testing.T) {
+	apiRule := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.kyma-project.io/v1alpha1",
+		"kind":       "APIRule",
+		"metadata":   map[string]interface{}{"name": "my-rule", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"service": map[string]interface{}{"name": "my-function", "host": "my-function.example.com", "port": int64(80)},
+		},
+		"status": map[string]interface{}{"APIRuleStatus": map[string]interface{}{"code": "OK"}},
+	}}
+	otherAPIRule := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.kyma-project.io/v1alpha1",
+		"kind":       "APIRule",
+		"metadata":   map[string]interface{}{"name": "other-rule", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"service": map[string]interface{}{"name": "other-function", "host": "other.example.com", "port": int64(80)},
+		},
+	}}
+	subscription := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "eventing.kyma-project.io/v1alpha1",
+		"kind":       "Subscription",
+		"metadata":   map[string]interface{}{"name": "my-subscription", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"sink": "http://my-function.default.svc.cluster.local",
+			"filter": map[string]interface{}{
+				"filters": []interface{}{
+					map[string]interface{}{"eventType": map[string]interface{}{"property": "type", "value": "order.created.v1"}},
+				},
+			},
+		},
+	}}
+
+	scheme := runtime.NewScheme()
+	for _, gvk := range []schema.GroupVersionKind{
+		{Group: "gateway.kyma-project.io", Version: "v1alpha1", Kind: "APIRuleList"},
+		{Group: "eventing.kyma-project.io", Version: "v1alpha1", Kind: "SubscriptionList"},
+	} {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.UnstructuredList{})
+	}
+	dyn := dynFake.NewSimpleDynamicClient(scheme, apiRule, otherAPIRule, subscription)
+
+	apiRules, err := APIRules(context.Background(), dyn, "default", "my-function")
+	require.NoError(t, err)
+	require.Equal(t, []ResourceStatus{{Name: "my-rule", Ready: true, Details: "my-function.example.com"}}, apiRules)
+
+	subscriptions, err := Subscriptions(context.Background(), dyn, "default", "my-function")
+	require.NoError(t, err)
+	require.Equal(t, []ResourceStatus{{Name: "my-subscription", Ready: false, Details: "order.created.v1"}}, subscriptions)
+}
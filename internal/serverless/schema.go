@@ -0,0 +1,194 @@
+package serverless
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/resources/types"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+)
+
+const (
+	schemaVersion = "http://json-schema.org/draft-07/schema#"
+	// quantityPattern matches the resource quantities of Kubernetes, such as "100m" or "128Mi"
+	quantityPattern = `^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$`
+)
+
+// jsonSchema is a JSON Schema object
+type jsonSchema = map[string]interface{}
+
+var quantitySchema = jsonSchema{
+	"type":    []string{"string", "number"},
+	"pattern": quantityPattern,
+}
+
+var resourceListSchema = jsonSchema{
+	"properties": jsonSchema{
+		workspace.ResourceNameCPU:    quantitySchema,
+		workspace.ResourceNameMemory: quantitySchema,
+	},
+	"additionalProperties": false,
+}
+
+// schemaOverrides refine the schema generated from the fields of workspace.Cfg.
+// The keys are the paths of the fields, where '[]' stands for the items of a list.
+var schemaOverrides = map[string]jsonSchema{
+	"": {
+		"title":    "Function configuration",
+		"required": []string{"name", "runtime"},
+	},
+	"name": {
+		"description": "Name of the Function.",
+		"pattern":     `^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`,
+		"maxLength":   63,
+	},
+	"namespace": {
+		"description": "Namespace to which the Function is applied.",
+	},
+	"runtime": {
+		"description": "Runtime of the Function.",
+		"enum":        []string{types.Nodejs12, types.Nodejs14, types.Python38, types.Python39},
+	},
+	"labels": {
+		"description": "Labels of the Function.",
+	},
+	"source": {
+		"description": "Sources of the Function, either inline in the folder of the config file or in a Git repository.",
+	},
+	"source.sourceType": {
+		"enum": []string{string(workspace.SourceTypeInline), string(workspace.SourceTypeGit)},
+	},
+	"resource": {
+		"description": "Limits and requests of the Function's CPU and memory.",
+	},
+	"resource.limits":   resourceListSchema,
+	"resource.requests": resourceListSchema,
+	"subscriptions": {
+		"description": "Subscriptions of the Function to events.",
+	},
+	"subscriptions[]": {
+		"required": []string{"name"},
+	},
+	"subscriptions[].filter.filters[].eventSource.property": {
+		"enum": []string{"source"},
+	},
+	"subscriptions[].filter.filters[].eventSource.type": {
+		"enum": []string{"exact"},
+	},
+	"subscriptions[].filter.filters[].eventType.property": {
+		"enum": []string{"type"},
+	},
+	"subscriptions[].filter.filters[].eventType.type": {
+		"enum": []string{"exact"},
+	},
+	"env": {
+		"description": "Environment variables of the Function.",
+	},
+	"env[]": {
+		"required": []string{"name"},
+	},
+	"env[].name": {
+		"pattern": `^[-._a-zA-Z][-._a-zA-Z0-9]*$`,
+	},
+	"env[].valueFrom.configMapKeyRef": {
+		"required": []string{"name", "key"},
+	},
+	"env[].valueFrom.secretKeyRef": {
+		"required": []string{"name", "key"},
+	},
+	"apiRules": {
+		"description": "API Rules which expose the Function.",
+	},
+	"apiRules[].service": {
+		"required": []string{"host"},
+	},
+	"apiRules[].service.port": {
+		"minimum": 1,
+		"maximum": 65535,
+	},
+	"apiRules[].rules[].methods[]": {
+		"enum": []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+	},
+	"apiRules[].rules[].accessStrategies[]": {
+		"required": []string{"handler"},
+	},
+	"apiRules[].rules[].accessStrategies[].handler": {
+		"enum": []string{"allow", "noop", "jwt", "oauth2_introspection"},
+	},
+}
+
+// Schema returns the JSON Schema of the config file of a Function
+func Schema() ([]byte, error) {
+	return json.MarshalIndent(configSchema(), "", "  ")
+}
+
+func configSchema() jsonSchema {
+	schema := schemaFor(reflect.TypeOf(workspace.Cfg{}), "")
+	schema["$schema"] = schemaVersion
+	return schema
+}
+
+// schemaFor generates the schema of the type from its yaml tags and applies the overrides of the path
+func schemaFor(t reflect.Type, path string) jsonSchema {
+	var schema jsonSchema
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaFor(t.Elem(), path)
+	case reflect.Struct:
+		properties := jsonSchema{}
+		addProperties(properties, t, path)
+		schema = jsonSchema{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	case reflect.Slice:
+		schema = jsonSchema{
+			"type":  "array",
+			"items": schemaFor(t.Elem(), path+"[]"),
+		}
+	case reflect.Map:
+		schema = jsonSchema{"type": "object"}
+		if t.Elem().Kind() == reflect.String {
+			schema["additionalProperties"] = jsonSchema{"type": "string"}
+		}
+	case reflect.String:
+		schema = jsonSchema{"type": "string"}
+	case reflect.Bool:
+		schema = jsonSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		schema = jsonSchema{"type": "integer"}
+	default:
+		schema = jsonSchema{}
+	}
+
+	for key, value := range schemaOverrides[path] {
+		schema[key] = value
+	}
+	return schema
+}
+
+// addProperties adds the schemas of the fields of the struct to the properties, including the fields of inlined structs
+func addProperties(properties jsonSchema, t reflect.Type, path string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("yaml"), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+		if len(tag) > 1 && tag[1] == "inline" {
+			addProperties(properties, field.Type, path)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+		properties[name] = schemaFor(field.Type, fieldPath)
+	}
+}
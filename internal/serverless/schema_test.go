@@ -0,0 +1,52 @@
+package serverless
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+	content, err := Schema()
+	require.NoError(t, err)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &schema))
+	require.Equal(t, schemaVersion, schema["$schema"])
+	require.Equal(t, []interface{}{"name", "runtime"}, schema["required"])
+
+	properties := schema["properties"].(map[string]interface{})
+	for _, field := range []string{"name", "namespace", "labels", "runtime", "source", "resource", "subscriptions", "env", "apiRules"} {
+		require.Contains(t, properties, field)
+	}
+	// the fields of the inline and Git sources are inlined
+	sourceProperties := properties["source"].(map[string]interface{})["properties"].(map[string]interface{})
+	for _, field := range []string{"sourceType", "sourcePath", "url", "reference"} {
+		require.Contains(t, sourceProperties, field)
+	}
+}
+
+// TestSchemaOverrides ensures that all overrides refer to fields of the configuration, so they are not lost if the fields are renamed
+func TestSchemaOverrides(t *testing.T) {
+	t.Parallel()
+	schema := configSchema()
+	for path := range schemaOverrides {
+		if path == "" {
+			continue
+		}
+		current := schema
+		for _, segment := range strings.Split(path, ".") {
+			name := strings.TrimSuffix(segment, "[]")
+			properties, ok := current["properties"].(jsonSchema)
+			require.True(t, ok, "Override '%s' refers to a field without properties", path)
+			current, ok = properties[name].(jsonSchema)
+			require.True(t, ok, "Override '%s' refers to an unknown field", path)
+			for i := strings.Count(segment, "[]"); i > 0; i-- {
+				current = current["items"].(jsonSchema)
+			}
+		}
+	}
+}
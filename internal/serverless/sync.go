@@ -0,0 +1,207 @@
+package serverless
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/client"
+	"github.com/kyma-incubator/hydroform/function/pkg/operator"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SyncStateFile is the file in the folder of a Function which records the state of its last synchronisation
+const SyncStateFile = ".kyma-sync.yaml"
+
+// SyncState is the state of a Function on the cluster at its last synchronisation
+type SyncState struct {
+	ResourceVersion string `yaml:"resourceVersion"`
+	// Files are the checksums of the synchronised files by their name
+	Files map[string]string `yaml:"files"`
+}
+
+// LoadSyncState reads the state of the last synchronisation from the folder. The state is empty if the folder was never synchronised.
+func LoadSyncState(dir string) (SyncState, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, SyncStateFile))
+	if os.IsNotExist(err) {
+		return SyncState{}, nil
+	}
+	if err != nil {
+		return SyncState{}, errors.Wrap(err, "Could not read the state of the last synchronisation")
+	}
+	var state SyncState
+	if err := yaml.Unmarshal(content, &state); err != nil {
+		return SyncState{}, errors.Wrapf(err, "Could not decode the state of the last synchronisation. Remove '%s' to synchronise from scratch", filepath.Join(dir, SyncStateFile))
+	}
+	return state, nil
+}
+
+// Save writes the state to the folder
+func (s SyncState) Save(dir string) error {
+	content, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, SyncStateFile), content, 0600)
+}
+
+// FileDiff compares a file of the Function on the cluster with the local file
+type FileDiff struct {
+	Name    string
+	Local   []byte
+	Cluster []byte
+	// LocalExists is not set if the file exists only on the cluster
+	LocalExists bool
+	// LocalChanged and ClusterChanged tell whether the file changed since the last synchronisation
+	LocalChanged   bool
+	ClusterChanged bool
+}
+
+// Changed tells whether the local file differs from the file on the cluster
+func (d FileDiff) Changed() bool {
+	return !d.LocalExists || !bytes.Equal(d.Local, d.Cluster)
+}
+
+// Conflict tells whether the file changed both locally and on the cluster since the last synchronisation
+func (d FileDiff) Conflict() bool {
+	return d.Changed() && d.LocalChanged && d.ClusterChanged
+}
+
+// String describes how the file differs
+func (d FileDiff) String() string {
+	switch {
+	case !d.Changed():
+		return fmt.Sprintf("%s: unchanged", d.Name)
+	case !d.LocalExists:
+		return fmt.Sprintf("%s: exists only on the cluster", d.Name)
+	case d.Conflict():
+		return fmt.Sprintf("%s: changed locally and on the cluster", d.Name)
+	case d.LocalChanged:
+		return fmt.Sprintf("%s: changed locally", d.Name)
+	default:
+		return fmt.Sprintf("%s: changed on the cluster", d.Name)
+	}
+}
+
+// Unified returns the differences between the local file and the file on the cluster in the unified format
+func (d FileDiff) Unified() (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(d.Local)),
+		B:        difflib.SplitLines(string(d.Cluster)),
+		FromFile: "local/" + d.Name,
+		ToFile:   "cluster/" + d.Name,
+		Context:  3,
+	})
+}
+
+// DownloadFunction synchronises the Function from the cluster into a temporary folder, so that it can be compared with the local folder before anything is overwritten.
+// The config file in the temporary folder points to the local folder. It returns the temporary folder, which the caller must remove, and the resourceVersion of the Function.
+func DownloadFunction(ctx context.Context, cfg workspace.Cfg, localDir string, build client.Build) (string, string, error) {
+	// the resourceVersion is read before the sources, so that a concurrent change is detected by the next synchronisation
+	function, err := build(cfg.Namespace, operator.GVRFunction).Get(ctx, cfg.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", "", err
+	}
+
+	dir, err := ioutil.TempDir("", "kyma-sync")
+	if err != nil {
+		return "", "", err
+	}
+	if err := workspace.Synchronise(ctx, cfg, dir, build); err != nil {
+		os.RemoveAll(dir)
+		return "", "", err
+	}
+	if err := setSourcePath(filepath.Join(dir, workspace.CfgFilename), localDir); err != nil {
+		os.RemoveAll(dir)
+		return "", "", err
+	}
+	return dir, function.GetResourceVersion(), nil
+}
+
+// setSourcePath replaces the source path of an inline Function in the config file
+func setSourcePath(filename, sourcePath string) error {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	var cfg workspace.Cfg
+	if err := yamlv3.Unmarshal(content, &cfg); err != nil {
+		return err
+	}
+	if cfg.Source.Type != workspace.SourceTypeInline {
+		return nil
+	}
+	cfg.Source.SourcePath = sourcePath
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	// the config file is encoded like by the workspace package, so that it does not differ from a config file written by it
+	return yamlv3.NewEncoder(file).Encode(&cfg)
+}
+
+// DiffFunction compares the files of the Function downloaded from the cluster with the local files, based on the state of the last synchronisation.
+// Files which exist only locally, such as tests, are not compared.
+func DiffFunction(localDir, clusterDir string, state SyncState) ([]FileDiff, error) {
+	infos, err := ioutil.ReadDir(clusterDir)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []FileDiff
+	for _, info := range infos {
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		diff := FileDiff{Name: info.Name()}
+		if diff.Cluster, err = ioutil.ReadFile(filepath.Join(clusterDir, diff.Name)); err != nil {
+			return nil, err
+		}
+		diff.Local, err = ioutil.ReadFile(filepath.Join(localDir, diff.Name))
+		switch {
+		case err == nil:
+			diff.LocalExists = true
+		case !os.IsNotExist(err):
+			return nil, errors.Wrapf(err, "Could not read the local file '%s'", diff.Name)
+		}
+
+		synced, ok := state.Files[diff.Name]
+		if ok {
+			diff.LocalChanged = !diff.LocalExists || Checksum(diff.Local) != synced
+			diff.ClusterChanged = Checksum(diff.Cluster) != synced
+		} else {
+			// without a previous synchronisation, it is unknown which side changed the file
+			diff.LocalChanged, diff.ClusterChanged = diff.LocalExists, true
+		}
+		diffs = append(diffs, diff)
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	return diffs, nil
+}
+
+// NewSyncState returns the state of the Function downloaded from the cluster
+func NewSyncState(resourceVersion string, diffs []FileDiff) SyncState {
+	state := SyncState{ResourceVersion: resourceVersion, Files: map[string]string{}}
+	for _, diff := range diffs {
+		state.Files[diff.Name] = Checksum(diff.Cluster)
+	}
+	return state
+}
+
+// Checksum returns the SHA-256 checksum of the content of a file
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,108 @@
+package serverless
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncState(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "sync")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	state, err := LoadSyncState(dir)
+	require.NoError(t, err)
+	require.Empty(t, state.ResourceVersion, "A folder which was never synchronised has no state")
+
+	expected := SyncState{ResourceVersion: "42", Files: map[string]string{"handler.js": Checksum([]byte("code"))}}
+	require.NoError(t, expected.Save(dir))
+	state, err = LoadSyncState(dir)
+	require.NoError(t, err)
+	require.Equal(t, expected, state)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, SyncStateFile), []byte("files: [invalid"), 0600))
+	_, err = LoadSyncState(dir)
+	require.Error(t, err)
+}
+
+func TestDiffFunction(t *testing.T) {
+	t.Parallel()
+	localDir, err := ioutil.TempDir("", "local")
+	require.NoError(t, err)
+	defer os.RemoveAll(localDir)
+	clusterDir, err := ioutil.TempDir("", "cluster")
+	require.NoError(t, err)
+	defer os.RemoveAll(clusterDir)
+
+	write := func(dir string, files map[string]string) {
+		for name, content := range files {
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+		}
+	}
+	write(clusterDir, map[string]string{
+		"config.yaml":  "name: cluster",
+		"handler.js":   "synced",
+		"package.json": "cluster",
+		"deps.txt":     "cluster",
+	})
+	write(localDir, map[string]string{
+		"config.yaml":     "name: local",
+		"handler.js":      "local",
+		"package.json":    "synced",
+		"handler.test.js": "test",
+	})
+
+	t.Run("without previous synchronisation", func(t *testing.T) {
+		diffs, err := DiffFunction(localDir, clusterDir, SyncState{})
+		require.NoError(t, err)
+		require.Len(t, diffs, 4, "Files which exist only locally are not compared")
+		for _, diff := range diffs {
+			switch diff.Name {
+			case "deps.txt":
+				require.False(t, diff.Conflict(), "A file which exists only on the cluster is no conflict")
+				require.True(t, diff.ClusterChanged)
+			default:
+				require.True(t, diff.Conflict(), "Unknown changes of file '%s' are conflicts", diff.Name)
+			}
+		}
+	})
+
+	t.Run("with previous synchronisation", func(t *testing.T) {
+		synced := Checksum([]byte("synced"))
+		state := SyncState{ResourceVersion: "1", Files: map[string]string{
+			"config.yaml":  Checksum([]byte("name: old")),
+			"handler.js":   synced,
+			"package.json": synced,
+		}}
+		diffs, err := DiffFunction(localDir, clusterDir, state)
+		require.NoError(t, err)
+		require.Len(t, diffs, 4)
+
+		require.Equal(t, "config.yaml", diffs[0].Name)
+		require.True(t, diffs[0].Conflict())
+		require.Equal(t, "deps.txt", diffs[1].Name)
+		require.True(t, diffs[1].ClusterChanged)
+		require.Equal(t, "handler.js", diffs[2].Name)
+		require.False(t, diffs[2].Conflict())
+		require.True(t, diffs[2].LocalChanged)
+		require.False(t, diffs[2].ClusterChanged)
+		require.Equal(t, "package.json", diffs[3].Name)
+		require.False(t, diffs[3].LocalChanged)
+		require.True(t, diffs[3].ClusterChanged)
+
+		unified, err := diffs[2].Unified()
+		require.NoError(t, err)
+		require.Contains(t, unified, "--- local/handler.js\n+++ cluster/handler.js\n")
+		require.Contains(t, unified, "-local\n+synced\n")
+
+		newState := NewSyncState("2", diffs)
+		require.Equal(t, "2", newState.ResourceVersion)
+		require.Equal(t, synced, newState.Files["handler.js"])
+		require.Len(t, newState.Files, 4)
+	})
+}
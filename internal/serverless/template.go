@@ -0,0 +1,260 @@
+package serverless
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/pkg/errors"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// TemplateManifest is the optional file of a template folder which describes the template
+	TemplateManifest = "template.yaml"
+	// templateSuffix marks the files of a template which are rendered with the template parameters
+	templateSuffix = ".tmpl"
+)
+
+// TemplateParams are the values available in the files of a template
+type TemplateParams struct {
+	Name      string
+	Namespace string
+	Runtime   string
+}
+
+// Template scaffolds the sources of a new Function
+type Template struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	// Runtimes supported by the template. If it is empty, the template supports all runtimes.
+	Runtimes []string `json:"runtimes,omitempty" yaml:"runtimes,omitempty"`
+
+	// files maps the paths of the files relative to the Function's folder to their content for each runtime.
+	// The files of the empty runtime are used for all runtimes.
+	files map[string]map[string]string
+}
+
+// CheckRuntime returns an error if the template can't be used for the runtime
+func (t Template) CheckRuntime(runtime string) error {
+	if len(t.Runtimes) == 0 {
+		return nil
+	}
+	for _, r := range t.Runtimes {
+		if r == runtime {
+			return nil
+		}
+	}
+	return fmt.Errorf("Template '%s' does not support runtime '%s'. Use one of these runtimes: %s", t.Name, runtime, strings.Join(t.Runtimes, ", "))
+}
+
+// Render writes the files of the template for the runtime to the folder. Existing files are overwritten,
+// except for the config file of the Function: the config file of the template is merged on top of it like an overlay.
+func (t Template) Render(dir string, params TemplateParams) error {
+	if err := t.CheckRuntime(params.Runtime); err != nil {
+		return err
+	}
+
+	files := map[string]string{}
+	for path, content := range t.files[""] {
+		files[path] = content
+	}
+	for path, content := range t.files[params.Runtime] {
+		files[path] = content
+	}
+
+	for path, content := range files {
+		if strings.HasSuffix(path, templateSuffix) {
+			rendered, err := renderFile(path, content, params)
+			if err != nil {
+				return err
+			}
+			path, content = strings.TrimSuffix(path, templateSuffix), rendered
+		}
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if path == workspace.CfgFilename {
+			if err := mergeConfig(target, content); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(target, []byte(content), 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderFile(path, content string, params TemplateParams) (string, error) {
+	tmpl, err := template.New(path).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return "", errors.Wrapf(err, "Invalid template file '%s'", path)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, params); err != nil {
+		return "", errors.Wrapf(err, "Could not render template file '%s'", path)
+	}
+	return out.String(), nil
+}
+
+// mergeConfig merges the content on top of the config file. If the config file doesn't exist, it is created.
+func mergeConfig(filename, content string) error {
+	var base, overlay workspace.Cfg
+	if existing, err := ioutil.ReadFile(filename); err == nil {
+		if err := yaml.Unmarshal(existing, &base); err != nil {
+			return errors.Wrap(err, "Could not decode the configuration file")
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	if err := yaml.UnmarshalStrict([]byte(content), &overlay); err != nil {
+		return errors.Wrapf(err, "Could not decode the template file '%s'", workspace.CfgFilename)
+	}
+	merged, err := yaml.Marshal(MergeOverlay(base, overlay))
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, merged, 0600)
+}
+
+// Templates returns the built-in templates sorted by name
+func Templates() []Template {
+	var templates []Template
+	for _, t := range builtInTemplates {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates
+}
+
+// LoadTemplate returns the template with the given name, or loads it from a folder or Git repository.
+// A Git repository is given by its URL, optionally followed by '#' and the branch or tag, such as 'https://github.com/org/templates.git#v1'.
+func LoadTemplate(ctx context.Context, source string) (Template, error) {
+	if t, ok := builtInTemplates[source]; ok {
+		return t, nil
+	}
+	if isGitURL(source) {
+		return loadGitTemplate(ctx, source)
+	}
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return loadDirTemplate(source)
+	}
+
+	var names []string
+	for _, t := range Templates() {
+		names = append(names, t.Name)
+	}
+	return Template{}, fmt.Errorf("Unknown template '%s'. Use one of the built-in templates %s, the path to a folder, or the URL of a Git repository", source, strings.Join(names, ", "))
+}
+
+func isGitURL(source string) bool {
+	for _, prefix := range []string{"https://", "http://", "git@", "ssh://"} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func loadGitTemplate(ctx context.Context, source string) (Template, error) {
+	url, ref := source, ""
+	if i := strings.LastIndex(source, "#"); i > 0 {
+		url, ref = source[:i], source[i+1:]
+	}
+
+	dir, err := ioutil.TempDir("", "function-template")
+	if err != nil {
+		return Template{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	options := &git.CloneOptions{URL: url, Depth: 1}
+	if ref != "" {
+		options.ReferenceName = plumbing.NewBranchReferenceName(ref)
+		options.SingleBranch = true
+	}
+	if _, err := git.PlainCloneContext(ctx, dir, false, options); err != nil {
+		if ref == "" {
+			return Template{}, errors.Wrapf(err, "Could not clone template repository '%s'", url)
+		}
+		// the reference can also be a tag
+		os.RemoveAll(dir)
+		options.ReferenceName = plumbing.NewTagReferenceName(ref)
+		if _, err := git.PlainCloneContext(ctx, dir, false, options); err != nil {
+			return Template{}, errors.Wrapf(err, "Could not clone reference '%s' of template repository '%s'", ref, url)
+		}
+	}
+
+	t, err := loadDirTemplate(dir)
+	if err != nil {
+		return Template{}, err
+	}
+	if t.Name == filepath.Base(dir) {
+		t.Name = source
+	}
+	return t, nil
+}
+
+// loadDirTemplate reads all files of the folder except for hidden files and the template manifest
+func loadDirTemplate(dir string) (Template, error) {
+	t := Template{Name: filepath.Base(dir)}
+	content, err := ioutil.ReadFile(filepath.Join(dir, TemplateManifest))
+	if err == nil {
+		if err := yaml.Unmarshal(content, &t); err != nil {
+			return Template{}, errors.Wrapf(err, "Could not decode the template manifest '%s'", TemplateManifest)
+		}
+	} else if !os.IsNotExist(err) {
+		return Template{}, err
+	}
+
+	files := map[string]string{}
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == TemplateManifest {
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		return Template{}, errors.Wrapf(err, "Could not read template '%s'", dir)
+	}
+	if len(files) == 0 {
+		return Template{}, fmt.Errorf("Template '%s' contains no files", dir)
+	}
+	t.files = map[string]map[string]string{"": files}
+	return t, nil
+}
@@ -0,0 +1,156 @@
+package serverless
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/resources/types"
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestBuiltInTemplates(t *testing.T) {
+	t.Parallel()
+	for _, template := range Templates() {
+		for _, runtime := range []string{types.Nodejs12, types.Nodejs14, types.Python38, types.Python39} {
+			if template.CheckRuntime(runtime) != nil {
+				continue
+			}
+			dir := initWorkspace(t, runtime)
+			require.NoError(t, template.Render(dir, TemplateParams{Name: "orders", Namespace: "shop", Runtime: runtime}), "template %s, runtime %s", template.Name, runtime)
+
+			content, err := ioutil.ReadFile(filepath.Join(dir, workspace.CfgFilename))
+			require.NoError(t, err)
+			warnings, err := ValidateConfigFile(workspace.CfgFilename, content)
+			require.NoError(t, err, "template %s, runtime %s", template.Name, runtime)
+			require.Empty(t, warnings, "template %s, runtime %s", template.Name, runtime)
+
+			var cfg workspace.Cfg
+			require.NoError(t, yaml.Unmarshal(content, &cfg))
+			require.Equal(t, "orders", cfg.Name)
+			require.Equal(t, "shop", cfg.Namespace)
+			require.Equal(t, workspace.SourceTypeInline, cfg.Source.Type)
+
+			files, err := ioutil.ReadDir(dir)
+			require.NoError(t, err)
+			for _, file := range files {
+				require.NotEqual(t, ".tmpl", filepath.Ext(file.Name()), "template %s, runtime %s", template.Name, runtime)
+			}
+		}
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	t.Parallel()
+	dir := initWorkspace(t, types.Nodejs14)
+	template, err := LoadTemplate(context.Background(), "event")
+	require.NoError(t, err)
+	require.NoError(t, template.Render(dir, TemplateParams{Name: "orders", Namespace: "shop", Runtime: types.Nodejs14}))
+
+	handler, err := ioutil.ReadFile(filepath.Join(dir, "handler.js"))
+	require.NoError(t, err)
+	require.Contains(t, string(handler), "orders received event")
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, workspace.CfgFilename))
+	require.NoError(t, err)
+	var cfg workspace.Cfg
+	require.NoError(t, yaml.Unmarshal(content, &cfg))
+	require.Len(t, cfg.Subscriptions, 1)
+	require.Equal(t, "orders", cfg.Subscriptions[0].Name)
+	require.Equal(t, "sap.kyma.custom.shop.orders.v1", cfg.Subscriptions[0].Filter.Filters[0].EventType.Value)
+
+	// the typescript template is available for Node.js only
+	template, err = LoadTemplate(context.Background(), "typescript")
+	require.NoError(t, err)
+	require.Error(t, template.Render(dir, TemplateParams{Name: "orders", Namespace: "shop", Runtime: types.Python39}))
+}
+
+func TestDirTemplate(t *testing.T) {
+	t.Parallel()
+	source, err := ioutil.TempDir("", "template")
+	require.NoError(t, err)
+	defer os.RemoveAll(source)
+	files := map[string]string{
+		TemplateManifest:      "name: custom\ndescription: Custom template\nruntimes:\n  - python39\n",
+		"handler.py.tmpl":     "# {{ .Name }} in {{ .Namespace }} on {{ .Runtime }}\n",
+		"lib/util.py":         "value = '{{ not rendered }}'\n",
+		"config.yaml.tmpl":    "labels:\n  app: {{ .Name }}\n",
+		".git/config":         "ignored",
+		"requirements.txt":    "requests\n",
+		"docs/README.md.tmpl": "# {{ .Name }}\n",
+		"docs/.hidden":        "ignored",
+	}
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(source, path)), 0700))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(source, path), []byte(content), 0600))
+	}
+
+	template, err := LoadTemplate(context.Background(), source)
+	require.NoError(t, err)
+	require.Equal(t, "custom", template.Name)
+	require.Equal(t, "Custom template", template.Description)
+	require.Error(t, template.CheckRuntime(types.Nodejs14))
+
+	dir := initWorkspace(t, types.Python39)
+	require.NoError(t, template.Render(dir, TemplateParams{Name: "orders", Namespace: "shop", Runtime: types.Python39}))
+
+	expected := map[string]string{
+		"handler.py":       "# orders in shop on python39\n",
+		"lib/util.py":      "value = '{{ not rendered }}'\n",
+		"requirements.txt": "requests\n",
+		"docs/README.md":   "# orders\n",
+	}
+	for path, content := range expected {
+		actual, err := ioutil.ReadFile(filepath.Join(dir, path))
+		require.NoError(t, err, path)
+		require.Equal(t, content, string(actual), path)
+	}
+	for _, path := range []string{TemplateManifest, ".git/config", "docs/.hidden", "handler.py.tmpl"} {
+		_, err := os.Stat(filepath.Join(dir, path))
+		require.True(t, os.IsNotExist(err), path)
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, workspace.CfgFilename))
+	require.NoError(t, err)
+	var cfg workspace.Cfg
+	require.NoError(t, yaml.Unmarshal(content, &cfg))
+	require.Equal(t, map[string]string{"app": "orders"}, cfg.Labels)
+	require.Equal(t, types.Python39, cfg.Runtime)
+}
+
+func TestLoadTemplateErrors(t *testing.T) {
+	t.Parallel()
+	_, err := LoadTemplate(context.Background(), "unknown")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "http")
+
+	empty, err := ioutil.TempDir("", "template")
+	require.NoError(t, err)
+	defer os.RemoveAll(empty)
+	_, err = LoadTemplate(context.Background(), empty)
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(empty, "handler.js.tmpl"), []byte("{{ .Unknown }}"), 0600))
+	template, err := LoadTemplate(context.Background(), empty)
+	require.NoError(t, err)
+	require.Error(t, template.Render(initWorkspace(t, types.Nodejs14), TemplateParams{Name: "orders", Runtime: types.Nodejs14}))
+}
+
+// initWorkspace creates the default workspace of a Function in a temporary folder
+func initWorkspace(t *testing.T, runtime string) string {
+	dir, err := ioutil.TempDir("", "function")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	cfg := workspace.Cfg{
+		Name:      "orders",
+		Namespace: "shop",
+		Runtime:   runtime,
+		Source:    workspace.Source{Type: workspace.SourceTypeInline},
+	}
+	require.NoError(t, workspace.Initialize(cfg, dir))
+	return dir
+}
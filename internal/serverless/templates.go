@@ -0,0 +1,236 @@
+package serverless
+
+import (
+	"github.com/kyma-incubator/hydroform/function/pkg/resources/types"
+)
+
+// builtInTemplates are the templates shipped with the CLI, by name
+var builtInTemplates = map[string]Template{
+	"http": {
+		Name:        "http",
+		Description: "HTTP handler exposed with an APIRule",
+		files: map[string]map[string]string{
+			"": {
+				"config.yaml.tmpl": httpConfig,
+			},
+			types.Nodejs12: nodeHTTPFiles,
+			types.Nodejs14: nodeHTTPFiles,
+			types.Python38: pythonHTTPFiles,
+			types.Python39: pythonHTTPFiles,
+		},
+	},
+	"event": {
+		Name:        "event",
+		Description: "Event consumer subscribed to a CloudEvent type",
+		files: map[string]map[string]string{
+			"": {
+				"config.yaml.tmpl": eventConfig,
+			},
+			types.Nodejs12: nodeEventFiles,
+			types.Nodejs14: nodeEventFiles,
+			types.Python38: pythonEventFiles,
+			types.Python39: pythonEventFiles,
+		},
+	},
+	"http-tests": {
+		Name:        "http-tests",
+		Description: "HTTP handler with unit tests run by jest or pytest",
+		files: map[string]map[string]string{
+			"": {
+				"config.yaml.tmpl": httpConfig,
+			},
+			types.Nodejs12: nodeTestFiles,
+			types.Nodejs14: nodeTestFiles,
+			types.Python38: pythonTestFiles,
+			types.Python39: pythonTestFiles,
+		},
+	},
+	"typescript": {
+		Name:        "typescript",
+		Description: "HTTP handler written in TypeScript and compiled to handler.js with 'npm run build'",
+		Runtimes:    []string{types.Nodejs12, types.Nodejs14},
+		files: map[string]map[string]string{
+			"": {
+				"config.yaml.tmpl":  httpConfig,
+				"src/handler.ts":    typescriptHandler,
+				"tsconfig.json":     typescriptConfig,
+				"package.json.tmpl": typescriptPackageJSON,
+				"handler.js":        typescriptCompiledHandler,
+				".gitignore":        "node_modules\n",
+			},
+		},
+	},
+}
+
+const httpConfig = `apiRules:
+  - name: {{ .Name }}
+    service:
+      host: {{ .Name }}
+    rules:
+      - path: /.*
+        methods:
+          - GET
+          - POST
+        accessStrategies:
+          - handler: allow
+`
+
+const eventConfig = `subscriptions:
+  - name: {{ .Name }}
+    protocol: ""
+    filter:
+      filters:
+        - eventSource:
+            property: source
+            type: exact
+            value: ""
+          eventType:
+            property: type
+            type: exact
+            value: sap.kyma.custom.{{ .Namespace }}.{{ .Name }}.v1
+`
+
+var nodeHTTPFiles = map[string]string{
+	"handler.js": `module.exports = {
+    main: function (event, context) {
+        const request = event.extensions.request;
+        return {
+            function: context['function-name'],
+            method: request.method,
+            path: request.path,
+            body: event.data
+        };
+    }
+}
+`,
+}
+
+var pythonHTTPFiles = map[string]string{
+	"handler.py": `def main(event, context):
+    request = event["extensions"]["request"]
+    return {
+        "function": context["function-name"],
+        "method": request.method,
+        "path": request.path,
+        "body": event["data"],
+    }
+`,
+}
+
+var nodeEventFiles = map[string]string{
+	"handler.js.tmpl": `module.exports = {
+    main: function (event, context) {
+        console.log('{{ .Name }} received event', event['ce-type'], 'with ID', event['ce-id']);
+        console.log(JSON.stringify(event.data));
+        return '';
+    }
+}
+`,
+}
+
+var pythonEventFiles = map[string]string{
+	"handler.py.tmpl": `def main(event, context):
+    print("{{ .Name }} received event", event["ce-type"], "with ID", event["ce-id"])
+    print(event["data"])
+    return ""
+`,
+}
+
+var nodeTestFiles = map[string]string{
+	"handler.js": `module.exports = {
+    main: function (event, context) {
+        const name = (event.data && event.data.name) || 'Serverless';
+        return 'Hello ' + name;
+    }
+}
+`,
+	"handler.test.js": `const handler = require('./handler');
+
+test('greets the name from the request body', () => {
+    expect(handler.main({ data: { name: 'Kyma' } }, {})).toBe('Hello Kyma');
+});
+
+test('greets Serverless by default', () => {
+    expect(handler.main({ data: {} }, {})).toBe('Hello Serverless');
+});
+`,
+	"package.json.tmpl": `{
+  "name": "{{ .Name }}",
+  "version": "0.0.1",
+  "scripts": {
+    "test": "jest"
+  },
+  "dependencies": {},
+  "devDependencies": {
+    "jest": "^27.0.6"
+  }
+}
+`,
+	".gitignore": "node_modules\n",
+}
+
+var pythonTestFiles = map[string]string{
+	"handler.py": `def main(event, context):
+    data = event.get("data") or {}
+    return "Hello " + data.get("name", "Serverless")
+`,
+	"test_handler.py": `from handler import main
+
+
+def test_greets_the_name_from_the_request_body():
+    assert main({"data": {"name": "Kyma"}}, {}) == "Hello Kyma"
+
+
+def test_greets_serverless_by_default():
+    assert main({"data": {}}, {}) == "Hello Serverless"
+`,
+	"requirements-dev.txt": "pytest\n",
+}
+
+const typescriptHandler = `interface Event {
+    data?: { name?: string };
+    extensions: { request: { method: string; path: string } };
+}
+
+export function main(event: Event, context: Record<string, unknown>): string {
+    const name = (event.data && event.data.name) || 'Serverless';
+    return 'Hello ' + name;
+}
+`
+
+const typescriptConfig = `{
+  "compilerOptions": {
+    "target": "es2019",
+    "module": "commonjs",
+    "strict": true,
+    "outDir": ".",
+    "rootDir": "src"
+  },
+  "include": ["src"]
+}
+`
+
+const typescriptPackageJSON = `{
+  "name": "{{ .Name }}",
+  "version": "0.0.1",
+  "scripts": {
+    "build": "tsc"
+  },
+  "dependencies": {},
+  "devDependencies": {
+    "typescript": "^4.3.5"
+  }
+}
+`
+
+// typescriptCompiledHandler is the output of 'npm run build' for the handler of the typescript template,
+// so that the Function can be applied before the first build
+const typescriptCompiledHandler = `"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.main = void 0;
+function main(event, context) {
+    const name = (event.data && event.data.name) || 'Serverless';
+    return 'Hello ' + name;
+}
+exports.main = main;
+`
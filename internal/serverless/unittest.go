@@ -0,0 +1,42 @@
+package serverless
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/resources/types"
+)
+
+// TestReportPath is the path of the JUnit XML report written by the test framework in the runtime container, if the framework writes one
+const TestReportPath = "/tmp/kyma-test-report.xml"
+
+// TestEnvs are the environment variables which let the tests run in the runtime container without writing caches to the sources of the Function
+var TestEnvs = []string{
+	"npm_config_cache=/tmp/.npm",
+	"npm_config_update_notifier=false",
+	"PYTHONDONTWRITEBYTECODE=1",
+}
+
+// TestCommands return the shell commands which install the dependencies of the Function, including the development ones, and run its tests in the runtime container. The last command runs the tests.
+// Node.js Functions are tested with "npm test", and Python Functions with pytest, which writes its report to TestReportPath.
+func TestCommands(runtime, sourcesPath string) ([]string, error) {
+	var commands []string
+	switch runtime {
+	case types.Nodejs12, types.Nodejs14:
+		commands = []string{
+			"cd " + sourcesPath,
+			"npm install --no-audit --no-fund --no-package-lock",
+			"npm test",
+		}
+	case types.Python38, types.Python39:
+		commands = []string{
+			"cd " + sourcesPath,
+			"(test ! -f requirements.txt || pip install -r requirements.txt)",
+			"(test ! -f requirements-dev.txt || pip install -r requirements-dev.txt)",
+			"(python -c 'import pytest' 2>/dev/null || pip install pytest)",
+			"python -m pytest -p no:cacheprovider --junitxml=" + TestReportPath,
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported runtime '%s'", runtime)
+	}
+	return commands, nil
+}
@@ -0,0 +1,24 @@
+package serverless
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/resources/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestCommands(t *testing.T) {
+	t.Parallel()
+	commands, err := TestCommands(types.Nodejs14, "/kubeless")
+	require.NoError(t, err)
+	require.Equal(t, "cd /kubeless", commands[0])
+	require.Equal(t, "npm test", commands[len(commands)-1])
+
+	commands, err = TestCommands(types.Python39, "/kubeless")
+	require.NoError(t, err)
+	require.Contains(t, commands, "(test ! -f requirements-dev.txt || pip install -r requirements-dev.txt)")
+	require.Equal(t, "python -m pytest -p no:cacheprovider --junitxml="+TestReportPath, commands[len(commands)-1])
+
+	_, err = TestCommands("java11", "/kubeless")
+	require.Error(t, err)
+}
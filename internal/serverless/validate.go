@@ -0,0 +1,258 @@
+package serverless
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kyma-incubator/hydroform/function/pkg/workspace"
+	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// pathSeparator separates the segments of the paths of the validation errors. It cannot be a dot because labels can contain dots.
+const pathSeparator = "\x00"
+
+// Issue is a problem found in the config file of a Function
+type Issue struct {
+	// Line of the config file where the problem is located, or 0 if it is unknown
+	Line int
+	// Field is the path of the field with the problem, such as 'apiRules.0.service.host'
+	Field   string
+	Message string
+	// Warning is set if the problem does not prevent the Function from being applied
+	Warning bool
+}
+
+func (i Issue) String() string {
+	severity := "error"
+	if i.Warning {
+		severity = "warning"
+	}
+	location := ""
+	if i.Line > 0 {
+		location = fmt.Sprintf("line %d: ", i.Line)
+	}
+	field := ""
+	if i.Field != "" {
+		field = i.Field + ": "
+	}
+	return fmt.Sprintf("%s%s: %s%s", location, severity, field, i.Message)
+}
+
+// ValidationError is returned if the config file of a Function contains errors
+type ValidationError struct {
+	Filename string
+	Issues   []Issue
+}
+
+func (e *ValidationError) Error() string {
+	lines := []string{fmt.Sprintf("Invalid configuration file '%s'", e.Filename)}
+	for _, issue := range e.Issues {
+		lines = append(lines, "  "+issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateConfigFile validates the content of the config file of a Function.
+// It returns the warnings, or a ValidationError if the file contains errors.
+func ValidateConfigFile(filename string, content []byte) ([]Issue, error) {
+	issues, err := ValidateConfig(content)
+	if err != nil {
+		return nil, err
+	}
+	var failed, warnings []Issue
+	for _, issue := range issues {
+		if issue.Warning {
+			warnings = append(warnings, issue)
+		} else {
+			failed = append(failed, issue)
+		}
+	}
+	if len(failed) > 0 {
+		return warnings, &ValidationError{Filename: filename, Issues: issues}
+	}
+	return warnings, nil
+}
+
+// ValidateConfig validates the content of the config file of a Function against its JSON Schema and checks it for common mistakes.
+// It returns an error only if the content is no valid YAML.
+func ValidateConfig(content []byte) ([]Issue, error) {
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal(content, &root); err != nil {
+		return nil, errors.Wrap(err, "Could not decode the configuration file")
+	}
+	var document interface{}
+	if err := root.Decode(&document); err != nil {
+		return nil, errors.Wrap(err, "Could not decode the configuration file")
+	}
+	if document == nil {
+		document = map[string]interface{}{}
+	}
+
+	result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(configSchema()), gojsonschema.NewGoLoader(document))
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not validate the configuration file")
+	}
+
+	var issues []Issue
+	for _, resultErr := range result.Errors() {
+		path := splitPath(resultErr.Context().String(pathSeparator))
+		// the descriptions of some errors start with the field
+		message := strings.TrimPrefix(resultErr.Description(), resultErr.Field()+" ")
+		switch resultErr.Type() {
+		case "required", "additional_property_not_allowed":
+			// point to the missing or unknown property instead of its parent
+			path = append(path, fmt.Sprint(resultErr.Details()["property"]))
+		}
+		issues = append(issues, Issue{
+			Line:    line(&root, path),
+			Field:   strings.Join(path, "."),
+			Message: message,
+		})
+	}
+	if len(issues) > 0 {
+		sortIssues(issues)
+		return issues, nil
+	}
+
+	// the content matches the schema, so it can be decoded to check the values
+	var cfg workspace.Cfg
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, errors.Wrap(err, "Could not decode the configuration file")
+	}
+	for _, issue := range lint(cfg) {
+		issue.Line = line(&root, strings.Split(issue.Field, "."))
+		issues = append(issues, issue)
+	}
+	sortIssues(issues)
+	return issues, nil
+}
+
+func sortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+}
+
+// lint checks the values of the configuration which cannot be expressed in the JSON Schema
+func lint(cfg workspace.Cfg) []Issue {
+	var issues []Issue
+
+	if cfg.Source.Type == workspace.SourceTypeGit && cfg.Source.URL == "" {
+		issues = append(issues, Issue{Field: "source.url", Message: "The URL of the Git repository is required for Git Functions"})
+	}
+
+	quantities := map[string]resource.Quantity{}
+	for _, list := range []struct {
+		name      string
+		resources workspace.ResourceList
+	}{
+		{name: "limits", resources: cfg.Resources.Limits},
+		{name: "requests", resources: cfg.Resources.Requests},
+	} {
+		for resourceName, value := range list.resources {
+			field := fmt.Sprintf("resource.%s.%s", list.name, resourceName)
+			quantity, err := resource.ParseQuantity(fmt.Sprint(value))
+			if err != nil {
+				issues = append(issues, Issue{Field: field, Message: fmt.Sprintf("'%v' is no valid quantity", value)})
+				continue
+			}
+			quantities[field] = quantity
+		}
+	}
+	for _, resourceName := range []string{workspace.ResourceNameCPU, workspace.ResourceNameMemory} {
+		limit, hasLimit := quantities["resource.limits."+resourceName]
+		request, hasRequest := quantities["resource.requests."+resourceName]
+		if hasLimit && hasRequest && request.Cmp(limit) > 0 {
+			issues = append(issues, Issue{
+				Field:   "resource.requests." + resourceName,
+				Message: fmt.Sprintf("The request %s exceeds the limit %s", request.String(), limit.String()),
+				Warning: true,
+			})
+		}
+	}
+
+	names := map[string]bool{}
+	for i, env := range cfg.Env {
+		if names[env.Name] {
+			issues = append(issues, Issue{Field: fmt.Sprintf("env.%d.name", i), Message: fmt.Sprintf("The environment variable '%s' is defined more than once", env.Name), Warning: true})
+		}
+		names[env.Name] = true
+		if env.Value != "" && env.ValueFrom != nil {
+			issues = append(issues, Issue{Field: fmt.Sprintf("env.%d", i), Message: "Only one of value and valueFrom can be set"})
+		}
+	}
+
+	for i, subscription := range cfg.Subscriptions {
+		if len(subscription.Filter.Filters) == 0 {
+			issues = append(issues, Issue{Field: fmt.Sprintf("subscriptions.%d", i), Message: "The subscription has no filters and does not receive any events", Warning: true})
+		}
+		for j, filter := range subscription.Filter.Filters {
+			if filter.EventType.Value == "" {
+				issues = append(issues, Issue{Field: fmt.Sprintf("subscriptions.%d.filter.filters.%d.eventType", i, j), Message: "The event type is required"})
+			}
+		}
+	}
+
+	for i, apiRule := range cfg.APIRules {
+		for j, rule := range apiRule.Rules {
+			for k, strategy := range rule.AccessStrategies {
+				if strategy.Handler == "jwt" && len(strategy.Config.JwksUrls) == 0 {
+					issues = append(issues, Issue{
+						Field:   fmt.Sprintf("apiRules.%d.rules.%d.accessStrategies.%d.config", i, j, k),
+						Message: "The jwt handler requires jwksUrls",
+					})
+				}
+			}
+			if len(rule.AccessStrategies) == 0 {
+				issues = append(issues, Issue{Field: fmt.Sprintf("apiRules.%d.rules.%d", i, j), Message: "The rule has no access strategies", Warning: true})
+			}
+		}
+	}
+	return issues
+}
+
+func splitPath(context string) []string {
+	segments := strings.Split(context, pathSeparator)
+	if len(segments) > 0 && segments[0] == gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+		segments = segments[1:]
+	}
+	return segments
+}
+
+// line returns the line of the deepest node of the YAML document that matches the path
+func line(root *yamlv3.Node, path []string) int {
+	node := root
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	result := node.Line
+	for _, segment := range path {
+		var next *yamlv3.Node
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					result = node.Content[i].Line
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yamlv3.SequenceNode:
+			if index, err := strconv.Atoi(segment); err == nil && index < len(node.Content) {
+				next = node.Content[index]
+				result = next.Line
+			}
+		}
+		if next == nil {
+			return result
+		}
+		node = next
+	}
+	return result
+}
@@ -0,0 +1,126 @@
+package serverless
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    []Issue
+	}{
+		{
+			name: "valid configuration",
+			content: `name: orders
+namespace: default
+runtime: nodejs14
+labels:
+  app.kubernetes.io/name: orders
+resource:
+  limits:
+    cpu: 200m
+    memory: 256Mi
+  requests:
+    cpu: 100m
+env:
+  - name: LOG_LEVEL
+    value: info
+`,
+		},
+		{
+			name: "schema errors",
+			content: `name: orders
+runtime: nodejs10
+unknown: true
+apiRules:
+  - service:
+      port: 80
+    rules:
+      - methods:
+          - FETCH
+`,
+			want: []Issue{
+				{Line: 2, Field: "runtime", Message: `must be one of the following: "nodejs12", "nodejs14", "python38", "python39"`},
+				{Line: 3, Field: "unknown", Message: "Additional property unknown is not allowed"},
+				{Line: 5, Field: "apiRules.0.service.host", Message: "host is required"},
+				{Line: 9, Field: "apiRules.0.rules.0.methods.0", Message: `must be one of the following: "GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"`},
+			},
+		},
+		{
+			name: "missing name",
+			content: `runtime: python38
+`,
+			want: []Issue{
+				{Line: 1, Field: "name", Message: "name is required"},
+			},
+		},
+		{
+			name: "invalid resource quantity",
+			content: `name: orders
+runtime: python38
+resource:
+  limits:
+    memory: lots
+`,
+			want: []Issue{
+				{Line: 5, Field: "resource.limits.memory", Message: `Does not match pattern '` + quantityPattern + `'`},
+			},
+		},
+		{
+			name: "lint errors and warnings",
+			content: `name: orders
+runtime: nodejs14
+resource:
+  limits:
+    memory: 128Mi
+  requests:
+    memory: 1Gi
+subscriptions:
+  - name: orders
+    filter:
+      filters: []
+apiRules:
+  - service:
+      host: orders
+    rules:
+      - methods: [GET]
+        accessStrategies:
+          - handler: jwt
+`,
+			want: []Issue{
+				{Line: 7, Field: "resource.requests.memory", Message: "The request 1Gi exceeds the limit 128Mi", Warning: true},
+				{Line: 9, Field: "subscriptions.0", Message: "The subscription has no filters and does not receive any events", Warning: true},
+				{Line: 18, Field: "apiRules.0.rules.0.accessStrategies.0.config", Message: "The jwt handler requires jwksUrls"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := ValidateConfig([]byte(tt.content))
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.want, issues)
+		})
+	}
+
+	_, err := ValidateConfig([]byte("name: [orders"))
+	require.Error(t, err, "Invalid YAML must be reported as error")
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	t.Parallel()
+	warnings, err := ValidateConfigFile("config.yaml", []byte("name: orders\nruntime: nodejs14\nsubscriptions:\n  - name: orders\n"))
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+
+	_, err = ValidateConfigFile("config.yaml", []byte("name: orders\nruntime: go\n"))
+	require.Error(t, err)
+	validationErr, ok := err.(*ValidationError)
+	require.True(t, ok)
+	require.Equal(t, "config.yaml", validationErr.Filename)
+	require.Equal(t, "Invalid configuration file 'config.yaml'\n  line 2: error: runtime: must be one of the following: \"nodejs12\", \"nodejs14\", \"python38\", \"python39\"", err.Error())
+}
@@ -1,9 +1,14 @@
 package asyncui
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
 	"github.com/kyma-incubator/hydroform/parallel-install/pkg/components"
+	installConfig "github.com/kyma-incubator/hydroform/parallel-install/pkg/config"
 	"github.com/kyma-incubator/hydroform/parallel-install/pkg/deployment"
 	"github.com/kyma-project/cli/pkg/step"
 )
@@ -24,11 +29,27 @@ type AsyncUI struct {
 	StepFactory step.FactoryInterface
 	//a failure occurred
 	Failed bool
+	//Components of the deployment. If they are set and the standard output is a terminal,
+	//the pending components of the ongoing phase are shown with the duration of the phase instead of a step per phase.
+	Components *installConfig.ComponentList
+	//Context stops redrawing the pending components when it is cancelled
+	Context context.Context
+
+	//out is the writer of the progress views (default is the standard output)
+	out   io.Writer
+	views map[deployment.InstallationPhase]*progressView
 }
 
+//isTerminal can be replaced in tests
+var isTerminal = step.IsTerminal
+
 //Start renders the CLI UI and provides the channel for receiving events
 func (ui *AsyncUI) Callback() func(update deployment.ProcessUpdate) {
 	ongoingSteps := make(map[deployment.InstallationPhase]step.Step)
+	ui.views = make(map[deployment.InstallationPhase]*progressView)
+	if ui.out == nil {
+		ui.out = os.Stdout
+	}
 
 	return func(update deployment.ProcessUpdate) {
 		switch update.Event {
@@ -60,12 +81,51 @@ func (ui *AsyncUI) renderStartEvent(procUpdEvent deployment.ProcessUpdate, ongoi
 	if _, exists := ongoingSteps[procUpdEvent.Phase]; exists {
 		return fmt.Errorf("Illegal state: start-step for installation phase '%s' already exists", procUpdEvent.Phase)
 	}
+	if pending, ok := ui.pendingComponents(procUpdEvent.Phase); ok && isTerminal(ui.out) {
+		view := newProgressView(ui.out, ui.majorStepMsg(procUpdEvent), pending)
+		ctx := ui.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		view.Start(ctx)
+		ui.views[procUpdEvent.Phase] = view
+		//the result of the phase is printed below the view after it was stopped
+		ongoingSteps[procUpdEvent.Phase] = ui.viewStep(ui.majorStepMsg(procUpdEvent))
+		return nil
+	}
 	step := ui.StepFactory.NewStep(ui.majorStepMsg(procUpdEvent))
 	step.Start()
 	ongoingSteps[procUpdEvent.Phase] = step
 	return nil
 }
 
+//pendingComponents returns the names of the components deployed in the installation phase
+func (ui *AsyncUI) pendingComponents(phase deployment.InstallationPhase) ([]string, bool) {
+	if ui.Components == nil {
+		return nil, false
+	}
+	var defs []installConfig.ComponentDefinition
+	switch phase {
+	case deployment.InstallPreRequisites, deployment.UninstallPreRequisites:
+		defs = ui.Components.Prerequisites
+	case deployment.InstallComponents, deployment.UninstallComponents:
+		defs = ui.Components.Components
+	default:
+		return nil, false
+	}
+	names := make([]string, 0, len(defs))
+	for _, def := range defs {
+		names = append(names, def.Name)
+	}
+	return names, true
+}
+
+//viewStep creates a step which prints its result in the place of a progress view
+func (ui *AsyncUI) viewStep(msg string) step.Step {
+	f := &step.Factory{Output: ui.out}
+	return f.NewStep(msg)
+}
+
 func (ui *AsyncUI) majorStepMsg(procUpdEvent deployment.ProcessUpdate) string {
 	//create a major step
 	var stepMsg string
@@ -110,6 +170,11 @@ func (ui *AsyncUI) renderStopEventInstallationPhase(procUpdEvent deployment.Proc
 		return fmt.Errorf("Illegal state: step for installation phase '%s' does not exist", installPhase)
 	}
 
+	if view, ok := ui.views[installPhase]; ok {
+		view.Stop()
+		delete(ui.views, installPhase)
+	}
+
 	//all good
 	if event == deployment.ProcessFinished {
 		ongoingSteps[installPhase].Successf("%s finished successfully", ui.majorStepMsg(procUpdEvent))
@@ -141,19 +206,31 @@ func (ui *AsyncUI) renderStopEventComponent(procUpdEvent deployment.ProcessUpdat
 		stepName = fmt.Sprintf(undeployComponentMsg, comp.Name)
 	}
 
-	//create step for processed component
-	step := ui.StepFactory.NewStep(stepName)
-	if comp.Status == components.StatusError {
-		errMsg := fmt.Sprintf("Deployment of component '%s' failed", comp.Name)
-		if cmpErr != nil {
-			errMsg = fmt.Sprintf("%s\n%s", errMsg, cmpErr)
+	var err error
+	stopStep := func(step step.Step) {
+		if comp.Status == components.StatusError {
+			errMsg := fmt.Sprintf("Deployment of component '%s' failed", comp.Name)
+			if cmpErr != nil {
+				errMsg = fmt.Sprintf("%s\n%s", errMsg, cmpErr)
+			}
+			step.Failuref(errMsg)
+			err = fmt.Errorf(errMsg)
+			return
 		}
-		step.Failuref(errMsg)
-		return fmt.Errorf(errMsg)
+		step.Success()
 	}
-	step.Success()
 
-	return nil
+	//print the processed component above the progress view of the phase
+	if view, ok := ui.views[installPhase]; ok {
+		view.Finish(comp.Name, func(elapsed time.Duration) {
+			stopStep(ui.viewStep(fmt.Sprintf("%s (after %s of the phase)", stepName, elapsed)))
+		})
+		return err
+	}
+
+	//create step for processed component
+	stopStep(ui.StepFactory.NewStep(stepName))
+	return err
 }
 
 //AddStep adds an additional installation step
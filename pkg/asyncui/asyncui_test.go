@@ -1,10 +1,15 @@
 package asyncui
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kyma-incubator/hydroform/parallel-install/pkg/components"
+	installConfig "github.com/kyma-incubator/hydroform/parallel-install/pkg/config"
 	"github.com/kyma-incubator/hydroform/parallel-install/pkg/deployment"
 	"github.com/kyma-project/cli/pkg/step"
 	stepMocks "github.com/kyma-project/cli/pkg/step/mocks"
@@ -173,6 +178,96 @@ func TestFailedComponent(t *testing.T) {
 	})
 }
 
+func TestProgressView(t *testing.T) {
+	t.Run("Show pending components on a terminal", func(t *testing.T) {
+		isTerminal = func(io.Writer) bool { return true }
+		defer func() { isTerminal = step.IsTerminal }()
+
+		out := &bytes.Buffer{}
+		mockStepFactory := &StepFactoryMock{}
+		asyncUI := AsyncUI{
+			StepFactory: mockStepFactory,
+			Components: &installConfig.ComponentList{
+				Components: []installConfig.ComponentDefinition{{Name: "comp1"}, {Name: "comp2"}, {Name: "comp3"}},
+			},
+			out: out,
+		}
+		callback := asyncUI.Callback()
+
+		callback(deployment.ProcessUpdate{
+			Event: deployment.ProcessStart,
+			Phase: deployment.InstallComponents,
+		})
+		callback(deployment.ProcessUpdate{
+			Event: deployment.ProcessRunning,
+			Phase: deployment.InstallComponents,
+			Component: components.KymaComponent{
+				Name:   "comp1",
+				Status: components.StatusInstalled,
+			},
+		})
+		callback(deployment.ProcessUpdate{
+			Event: deployment.ProcessExecutionFailure,
+			Phase: deployment.InstallComponents,
+			Component: components.KymaComponent{
+				Name:   "comp2",
+				Status: components.StatusError,
+			},
+		})
+		callback(deployment.ProcessUpdate{
+			Event: deployment.ProcessExecutionFailure,
+			Phase: deployment.InstallComponents,
+		})
+
+		assert.Empty(t, mockStepFactory.Steps, "the progress view replaces the steps of the phase")
+		assert.True(t, asyncUI.Failed)
+		assert.Contains(t, out.String(), "- "+fmt.Sprintf(deployComponentMsg, "comp1")+" (after 0s of the phase)\n")
+		assert.Contains(t, out.String(), "X Deployment of component 'comp2' failed\n")
+		assert.True(t, strings.HasSuffix(out.String(), "X "+deployComponentsPhaseMsg+" failed\n"))
+		assert.Empty(t, asyncUI.views)
+	})
+
+	t.Run("Use steps if the output is no terminal", func(t *testing.T) {
+		t.Parallel()
+		out := &bytes.Buffer{}
+		mockStepFactory := &StepFactoryMock{}
+		asyncUI := AsyncUI{
+			StepFactory: mockStepFactory,
+			Components:  &installConfig.ComponentList{Components: []installConfig.ComponentDefinition{{Name: "comp1"}}},
+			out:         out,
+		}
+		callback := asyncUI.Callback()
+		callback(deployment.ProcessUpdate{
+			Event: deployment.ProcessStart,
+			Phase: deployment.InstallComponents,
+		})
+		callback(deployment.ProcessUpdate{
+			Event: deployment.ProcessFinished,
+			Phase: deployment.InstallComponents,
+		})
+
+		assert.Len(t, mockStepFactory.Steps, 1)
+		assert.True(t, mockStepFactory.Steps[0].IsSuccessful())
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("Render pending components with the duration of the phase", func(t *testing.T) {
+		t.Parallel()
+		pending := []string{"istio"}
+		for i := 0; i < maxPendingLines+2; i++ {
+			pending = append(pending, fmt.Sprintf("c%d", i))
+		}
+		view := newProgressView(&bytes.Buffer{}, deployComponentsPhaseMsg, pending)
+		view.now = func() time.Time { return view.start.Add(83 * time.Second) }
+
+		lines := view.render()
+		assert.Equal(t, "/ "+deployComponentsPhaseMsg+" (phase running for 1m23s)", lines[0])
+		assert.Equal(t, "    istio  pending", lines[1])
+		assert.Len(t, lines, maxPendingLines+2)
+		assert.Equal(t, "    ... and 3 more", lines[len(lines)-1])
+	})
+}
+
 func prepareTest() (func(deployment.ProcessUpdate), *StepFactoryMock) {
 	mockStepFactory := &StepFactoryMock{}
 	asyncUI := AsyncUI{StepFactory: mockStepFactory}
@@ -0,0 +1,148 @@
+package asyncui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	//refreshInterval is the interval of redrawing the progress view
+	refreshInterval = 200 * time.Millisecond
+	//maxPendingLines limits the pending components shown, so that the progress view fits on the screen
+	maxPendingLines = 10
+)
+
+var spinnerFrames = []string{"/", "-", "\\", "|"}
+
+//progressView redraws the ongoing installation phase and its pending components in place on a terminal.
+//Hydroform reports a component only when it is finished, so there is no elapsed time per component. The view shows the duration of the phase instead,
+//and finished components are printed with the duration of the phase at which they finished.
+type progressView struct {
+	out     io.Writer
+	msg     string
+	pending []string
+	start   time.Time
+	now     func() time.Time
+
+	mu     sync.Mutex
+	lines  int //lines written by the last redraw
+	frame  int
+	frozen bool
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newProgressView(out io.Writer, msg string, pending []string) *progressView {
+	return &progressView{
+		out:     out,
+		msg:     msg,
+		pending: append([]string{}, pending...),
+		start:   time.Now(),
+		now:     time.Now,
+		done:    make(chan struct{}),
+	}
+}
+
+//Start redraws the view until it is stopped. If the context is cancelled, the view keeps its last lines and is no longer redrawn,
+//so that it doesn't overwrite the messages printed when Kyma CLI is interrupted.
+func (v *progressView) Start(ctx context.Context) {
+	v.mu.Lock()
+	v.redraw()
+	v.mu.Unlock()
+
+	v.wg.Add(1)
+	go func() {
+		defer v.wg.Done()
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-v.done:
+				return
+			case <-ctx.Done():
+				v.mu.Lock()
+				v.frozen = true
+				v.lines = 0
+				v.mu.Unlock()
+				return
+			case <-ticker.C:
+				v.mu.Lock()
+				v.redraw()
+				v.mu.Unlock()
+			}
+		}
+	}()
+}
+
+//Stop removes the view from the terminal, so that the result of the phase can be printed instead
+func (v *progressView) Stop() {
+	close(v.done)
+	v.wg.Wait()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.clear()
+}
+
+//Finish removes the component from the pending components and calls print to write its result above the view
+func (v *progressView) Finish(component string, print func(elapsed time.Duration)) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.clear()
+	for i, name := range v.pending {
+		if name == component {
+			v.pending = append(v.pending[:i], v.pending[i+1:]...)
+			break
+		}
+	}
+	print(v.elapsed())
+	v.redraw()
+}
+
+func (v *progressView) elapsed() time.Duration {
+	return v.now().Sub(v.start).Round(time.Second)
+}
+
+//redraw replaces the lines of the last redraw, the caller must hold the lock
+func (v *progressView) redraw() {
+	if v.frozen {
+		return
+	}
+	v.clear()
+	lines := v.render()
+	for _, line := range lines {
+		fmt.Fprintln(v.out, line)
+	}
+	v.lines = len(lines)
+	v.frame++
+}
+
+//clear moves the cursor up to the first line of the last redraw and erases the lines below, the caller must hold the lock
+func (v *progressView) clear() {
+	if v.lines > 0 {
+		fmt.Fprintf(v.out, "\033[%dA\033[J", v.lines)
+		v.lines = 0
+	}
+}
+
+//render returns the lines of the view: the phase with its duration followed by its pending components
+func (v *progressView) render() []string {
+	lines := []string{fmt.Sprintf("%s %s (phase running for %s)", spinnerFrames[v.frame%len(spinnerFrames)], v.msg, v.elapsed())}
+
+	width := 0
+	for _, name := range v.pending {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	for i, name := range v.pending {
+		if i == maxPendingLines {
+			lines = append(lines, fmt.Sprintf("    ... and %d more", len(v.pending)-i))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("    %-*s  pending", width, name))
+	}
+	return lines
+}
@@ -0,0 +1,54 @@
+package docker
+
+import (
+	"context"
+	"io"
+
+	"github.com/docker/cli/cli/streams"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	docker "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+//ContainerClient is the part of the Docker client which creates and starts containers
+type ContainerClient interface {
+	ImagePull(ctx context.Context, ref string, options types.ImagePullOptions) (io.ReadCloser, error)
+	ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.ContainerCreateCreatedBody, error)
+	ContainerStart(ctx context.Context, containerID string, options types.ContainerStartOptions) error
+}
+
+//CreateContainer creates a container and returns its ID. If the image is not available locally, it is pulled first and the progress of the pull is written to the writer.
+func CreateContainer(ctx context.Context, c ContainerClient, config *container.Config, hostConfig *container.HostConfig, name string, out io.Writer) (string, error) {
+	body, err := c.ContainerCreate(ctx, config, hostConfig, nil, nil, name)
+	if docker.IsErrNotFound(err) {
+		var r io.ReadCloser
+		r, err = c.ImagePull(ctx, config.Image, types.ImagePullOptions{})
+		if err != nil {
+			return "", err
+		}
+		defer r.Close()
+		if err = jsonmessage.DisplayJSONMessagesToStream(r, streams.NewOut(out), nil); err != nil {
+			return "", err
+		}
+		body, err = c.ContainerCreate(ctx, config, hostConfig, nil, nil, name)
+	}
+	if err != nil {
+		return "", err
+	}
+	return body.ID, nil
+}
+
+//RunContainer creates a container like CreateContainer, starts it, and returns its ID
+func RunContainer(ctx context.Context, c ContainerClient, config *container.Config, hostConfig *container.HostConfig, name string, out io.Writer) (string, error) {
+	id, err := CreateContainer(ctx, c, config, hostConfig, name, out)
+	if err != nil {
+		return "", err
+	}
+	if err := c.ContainerStart(ctx, id, types.ContainerStartOptions{}); err != nil {
+		return "", err
+	}
+	return id, nil
+}
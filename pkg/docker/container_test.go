@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeContainerClient creates containers only from pulled images
+type fakeContainerClient struct {
+	pulled  []string
+	started []string
+}
+
+func (f *fakeContainerClient) ImagePull(_ context.Context, ref string, _ types.ImagePullOptions) (io.ReadCloser, error) {
+	f.pulled = append(f.pulled, ref)
+	return ioutil.NopCloser(strings.NewReader(`{"status":"Pulling"}` + "\n")), nil
+}
+
+func (f *fakeContainerClient) ContainerCreate(_ context.Context, config *container.Config, _ *container.HostConfig, _ *network.NetworkingConfig, _ *specs.Platform, _ string) (container.ContainerCreateCreatedBody, error) {
+	for _, image := range f.pulled {
+		if image == config.Image {
+			return container.ContainerCreateCreatedBody{ID: "id-" + image}, nil
+		}
+	}
+	return container.ContainerCreateCreatedBody{}, errdefs.NotFound(errors.New("no such image"))
+}
+
+func (f *fakeContainerClient) ContainerStart(_ context.Context, id string, _ types.ContainerStartOptions) error {
+	f.started = append(f.started, id)
+	return nil
+}
+
+func TestCreateContainer(t *testing.T) {
+	t.Run("pull missing image", func(t *testing.T) {
+		c := &fakeContainerClient{}
+		out := &bytes.Buffer{}
+		id, err := CreateContainer(context.Background(), c, &container.Config{Image: "fn:1"}, &container.HostConfig{}, "", out)
+		require.NoError(t, err)
+		require.Equal(t, "id-fn:1", id)
+		require.Equal(t, []string{"fn:1"}, c.pulled)
+		require.Contains(t, out.String(), "Pulling")
+		require.Empty(t, c.started, "A created container must not be started")
+	})
+
+	t.Run("use local image", func(t *testing.T) {
+		c := &fakeContainerClient{pulled: []string{"fn:1"}}
+		_, err := CreateContainer(context.Background(), c, &container.Config{Image: "fn:1"}, &container.HostConfig{}, "", &bytes.Buffer{})
+		require.NoError(t, err)
+		require.Len(t, c.pulled, 1)
+	})
+}
+
+func TestRunContainer(t *testing.T) {
+	c := &fakeContainerClient{}
+	id, err := RunContainer(context.Background(), c, &container.Config{Image: "fn:1"}, &container.HostConfig{}, "fn", &bytes.Buffer{})
+	require.NoError(t, err)
+	require.Equal(t, []string{id}, c.started)
+}
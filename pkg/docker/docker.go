@@ -122,7 +122,32 @@ func (k *kymaDockerClient) BuildKymaInstaller(localSrcPath, imageName string) er
 func (k *kymaDockerClient) PushKymaInstaller(image string, currentStep step.Step) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(300)*time.Second)
 	defer cancel()
-	k.Docker.NegotiateAPIVersion(ctx)
+	currentStep.LogInfof("Pushing Docker image: '%s'", image)
+	return PushImage(ctx, k.Docker, image)
+}
+
+//BuildImage builds an image from the build context, which must contain a Dockerfile, and tags it.
+//The output of the build is written to the writer, if it is set.
+func BuildImage(ctx context.Context, c Client, buildContext io.Reader, tag string, out io.Writer) error {
+	c.NegotiateAPIVersion(ctx)
+	response, err := c.ImageBuild(ctx, buildContext, types.ImageBuildOptions{
+		Tags:        []string{tag},
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return readStream(response.Body, out, func(message string) error {
+		return fmt.Errorf("failed to build Docker image: %s", message)
+	})
+}
+
+//PushImage pushes the image to its registry with the credentials of the local Docker configuration
+func PushImage(ctx context.Context, c Client, image string) error {
+	c.NegotiateAPIVersion(ctx)
 	domain, _ := splitDockerDomain(image)
 	auth, err := resolve(domain)
 	if err != nil {
@@ -135,32 +160,50 @@ func (k *kymaDockerClient) PushKymaInstaller(image string, currentStep step.Step
 	}
 	authStr := base64.URLEncoding.EncodeToString(encodedJSON)
 
-	currentStep.LogInfof("Pushing Docker image: '%s'", image)
-
-	pusher, err := k.Docker.ImagePush(ctx, image, types.ImagePushOptions{RegistryAuth: authStr})
+	pusher, err := c.ImagePush(ctx, image, types.ImagePushOptions{RegistryAuth: authStr})
 	if err != nil {
 		return err
 	}
 
 	defer pusher.Close()
 
-	var errorMessage ErrorMessage
-	buffIOReader := bufio.NewReader(pusher)
+	return readStream(pusher, nil, func(message string) error {
+		if strings.Contains(message, "unauthorized") || strings.Contains(message, "requested access to the resource is denied") {
+			return fmt.Errorf("missing permissions to push Docker image: %s\nPlease run `docker login` to authenticate", message)
+		}
+		return fmt.Errorf("failed to push Docker image: %s", message)
+	})
+}
+
+//HasRegistry returns true if the image name contains the address of a registry, such as "localhost:5000/app"
+func HasRegistry(image string) bool {
+	domain, _ := splitDockerDomain(image)
+	return domain != defaultRegistry || strings.HasPrefix(image, defaultRegistry+"/")
+}
+
+//readStream reads the JSON messages streamed by Docker until the stream ends or contains an error
+func readStream(stream io.Reader, out io.Writer, onError func(message string) error) error {
+	var message struct {
+		ErrorMessage
+		Stream string
+	}
+	buffIOReader := bufio.NewReader(stream)
 
 	for {
 		streamBytes, err := buffIOReader.ReadBytes('\n')
 		if err == io.EOF {
 			break
 		}
-		err = json.Unmarshal(streamBytes, &errorMessage)
+		message.Error, message.Stream = "", ""
+		err = json.Unmarshal(streamBytes, &message)
 		if err != nil {
 			return err
 		}
-		if errorMessage.Error != "" {
-			if strings.Contains(errorMessage.Error, "unauthorized") || strings.Contains(errorMessage.Error, "requested access to the resource is denied") {
-				return fmt.Errorf("missing permissions to push Docker image: %s\nPlease run `docker login` to authenticate", errorMessage.Error)
-			}
-			return fmt.Errorf("failed to push Docker image: %s", errorMessage.Error)
+		if message.Error != "" {
+			return onError(message.Error)
+		}
+		if out != nil && message.Stream != "" {
+			fmt.Fprint(out, message.Stream)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package docker
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -167,3 +168,41 @@ func Test_PushKymaInstaller(t *testing.T) {
 	assert.NilError(t, err)
 
 }
+
+func Test_BuildImage(t *testing.T) {
+	t.Parallel()
+	buildContext := strings.NewReader("context")
+	options := imageTypes.ImageBuildOptions{
+		Tags:        []string{"orders:latest"},
+		Remove:      true,
+		ForceRemove: true,
+	}
+
+	mockDocker := &mocks.Client{}
+	mockDocker.On("NegotiateAPIVersion", mock.Anything).Return(nil)
+	mockDocker.On("ImageBuild", mock.Anything, buildContext, options).Return(imageTypes.ImageBuildResponse{
+		Body: ioutil.NopCloser(strings.NewReader("{\"stream\":\"Step 1/2 : FROM base\\n\"}\n{\"stream\":\"Successfully built\\n\"}\n")),
+	}, nil).Once()
+
+	var out strings.Builder
+	err := BuildImage(context.Background(), mockDocker, buildContext, "orders:latest", &out)
+	require.NoError(t, err)
+	require.Equal(t, "Step 1/2 : FROM base\nSuccessfully built\n", out.String())
+
+	mockDocker.On("ImageBuild", mock.Anything, buildContext, options).Return(imageTypes.ImageBuildResponse{
+		Body: ioutil.NopCloser(strings.NewReader("{\"stream\":\"Step 1/2 : FROM base\\n\"}\n{\"error\":\"npm install failed\"}\n")),
+	}, nil).Once()
+
+	err = BuildImage(context.Background(), mockDocker, buildContext, "orders:latest", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "npm install failed")
+}
+
+func Test_HasRegistry(t *testing.T) {
+	t.Parallel()
+	require.True(t, HasRegistry("localhost:5000/orders"))
+	require.True(t, HasRegistry("eu.gcr.io/project/orders:1.0"))
+	require.True(t, HasRegistry("index.docker.io/user/orders"))
+	require.False(t, HasRegistry("orders:latest"))
+	require.False(t, HasRegistry("user/orders"))
+}
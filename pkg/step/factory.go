@@ -1,7 +1,8 @@
 package step
 
 import (
-	"runtime"
+	"io"
+	"os"
 )
 
 //FactoryInterface is an abstraction for step factory
@@ -13,14 +14,20 @@ type FactoryInterface interface {
 type Factory struct {
 	NonInteractive bool
 	UseLogger      bool
+	// Output is the writer of the steps. If it is set, the steps are printed without spinner to this writer instead of the standard output.
+	Output io.Writer
 }
 
 // NewStep creates a new Step to print out the current status with or without a spinner.
+// The spinner is only used if the standard output is a terminal, otherwise each status is printed on a new line.
 func (f *Factory) NewStep(msg string) Step {
+	if f.Output != nil {
+		return newWriterStep(msg, f.Output)
+	}
 	if f.UseLogger {
 		return newLogStep(msg)
 	}
-	if f.NonInteractive || runtime.GOOS != "darwin" {
+	if f.NonInteractive || !IsTerminal(os.Stdout) {
 		return newSimpleStep(msg)
 	}
 	return newStepWithSpinner(msg)
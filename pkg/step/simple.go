@@ -3,6 +3,7 @@ package step
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -10,19 +11,25 @@ import (
 )
 
 func newSimpleStep(msg string) Step {
-	return &simpleStep{msg}
+	return &simpleStep{msg: msg, out: os.Stdout, errOut: os.Stderr}
+}
+
+func newWriterStep(msg string, out io.Writer) Step {
+	return &simpleStep{msg: msg, out: out, errOut: out}
 }
 
 type simpleStep struct {
-	msg string
+	msg    string
+	out    io.Writer
+	errOut io.Writer
 }
 
 func (s *simpleStep) Start() {
-	fmt.Println(s.msg)
+	fmt.Fprintln(s.out, s.msg)
 }
 
 func (s *simpleStep) Status(msg string) {
-	fmt.Printf("%s: %s\n", s.msg, msg)
+	fmt.Fprintf(s.out, "%s: %s\n", s.msg, msg)
 }
 
 func (s *simpleStep) Success() {
@@ -53,11 +60,11 @@ func (s *simpleStep) Stop(success bool) {
 	} else {
 		glyph = failureGlyph
 	}
-	fmt.Printf("%s%s\n", glyph, s.msg)
+	fmt.Fprintf(s.out, "%s%s\n", glyph, s.msg)
 }
 
 func (s *simpleStep) LogInfo(msg string) {
-	fmt.Printf("%s%s\n", infoGlyph, msg)
+	fmt.Fprintf(s.out, "%s%s\n", infoGlyph, msg)
 }
 
 func (s *simpleStep) LogInfof(format string, args ...interface{}) {
@@ -65,7 +72,7 @@ func (s *simpleStep) LogInfof(format string, args ...interface{}) {
 }
 
 func (s *simpleStep) LogError(msg string) {
-	fmt.Fprintf(os.Stderr, "%s%s\n", warningGlyph, msg)
+	fmt.Fprintf(s.errOut, "%s%s\n", warningGlyph, msg)
 }
 
 func (s *simpleStep) LogErrorf(format string, args ...interface{}) {
@@ -74,13 +81,13 @@ func (s *simpleStep) LogErrorf(format string, args ...interface{}) {
 
 func (s *simpleStep) Prompt(msg string) (string, error) {
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("%s%s", questionGlyph, msg)
+	fmt.Fprintf(s.out, "%s%s", questionGlyph, msg)
 	answer, err := reader.ReadString('\n')
 	return strings.TrimSpace(answer), err
 }
 
 func (s *simpleStep) PromptYesNo(msg string) bool {
-	fmt.Printf("%s%s", questionGlyph, msg)
+	fmt.Fprintf(s.out, "%s%s", questionGlyph, msg)
 	answer := root.PromptUser()
 	return answer
 }
@@ -0,0 +1,18 @@
+package step
+
+import (
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// IsTerminal tells whether the writer is a terminal which can redraw lines, as required by the spinner.
+// Terminals which declare themselves as "dumb" in the TERM environment variable are treated as no terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}